@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_order_type_check;
+ALTER TABLE orders
+ADD CONSTRAINT orders_order_type_check CHECK (
+        order_type IN ('MARKET', 'LIMIT', 'STOP_MARKET', 'STOP_LIMIT')
+    );
+-- Last trade price that releases a stop order into the book
+ALTER TABLE orders
+ADD COLUMN trigger_price_cents BIGINT,
+    ADD COLUMN triggered_at TIMESTAMPTZ;
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE orders DROP COLUMN IF EXISTS triggered_at,
+    DROP COLUMN IF EXISTS trigger_price_cents;
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_order_type_check;
+ALTER TABLE orders
+ADD CONSTRAINT orders_order_type_check CHECK (order_type IN ('MARKET', 'LIMIT'));
+-- +goose StatementEnd
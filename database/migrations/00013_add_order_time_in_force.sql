@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE orders
+ADD COLUMN time_in_force TEXT NOT NULL DEFAULT 'GTC' CHECK (
+        time_in_force IN ('GTC', 'IOC', 'FOK', 'DAY', 'GTD')
+    ),
+    ADD COLUMN expires_at TIMESTAMPTZ,
+    ADD COLUMN cancel_reason TEXT;
+CREATE INDEX idx_orders_expires_at ON orders(expires_at)
+WHERE expires_at IS NOT NULL
+    AND status IN ('PENDING', 'PARTIAL');
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_orders_expires_at;
+ALTER TABLE orders DROP COLUMN IF EXISTS cancel_reason,
+    DROP COLUMN IF EXISTS expires_at,
+    DROP COLUMN IF EXISTS time_in_force;
+-- +goose StatementEnd
@@ -0,0 +1,86 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE orders
+ADD COLUMN display_quantity BIGINT CHECK (display_quantity > 0);
+-- Only the visible slice of iceberg orders is published as depth
+CREATE OR REPLACE FUNCTION get_order_book(p_stock_ticker TEXT, p_depth INTEGER DEFAULT 10) RETURNS TABLE(
+        side TEXT,
+        price_cents BIGINT,
+        quantity BIGINT
+    ) AS $$ BEGIN RETURN QUERY (
+        SELECT 'BUY'::TEXT,
+            o.limit_price_cents,
+            SUM(
+                LEAST(
+                    COALESCE(o.display_quantity, o.remaining_quantity),
+                    o.remaining_quantity
+                )
+            )::BIGINT
+        FROM orders o
+        WHERE o.stock_ticker = p_stock_ticker
+            AND o.side = 'BUY'
+            AND o.status IN ('PENDING', 'PARTIAL')
+            AND o.order_type = 'LIMIT'
+        GROUP BY o.limit_price_cents
+        ORDER BY o.limit_price_cents DESC
+        LIMIT p_depth
+    )
+UNION ALL
+(
+    SELECT 'SELL'::TEXT,
+        o.limit_price_cents,
+        SUM(
+            LEAST(
+                COALESCE(o.display_quantity, o.remaining_quantity),
+                o.remaining_quantity
+            )
+        )::BIGINT
+    FROM orders o
+    WHERE o.stock_ticker = p_stock_ticker
+        AND o.side = 'SELL'
+        AND o.status IN ('PENDING', 'PARTIAL')
+        AND o.order_type = 'LIMIT'
+    GROUP BY o.limit_price_cents
+    ORDER BY o.limit_price_cents ASC
+    LIMIT p_depth
+);
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION get_order_book(p_stock_ticker TEXT, p_depth INTEGER DEFAULT 10) RETURNS TABLE(
+        side TEXT,
+        price_cents BIGINT,
+        quantity BIGINT
+    ) AS $$ BEGIN RETURN QUERY (
+        SELECT 'BUY'::TEXT,
+            o.limit_price_cents,
+            SUM(o.remaining_quantity)
+        FROM orders o
+        WHERE o.stock_ticker = p_stock_ticker
+            AND o.side = 'BUY'
+            AND o.status IN ('PENDING', 'PARTIAL')
+            AND o.order_type = 'LIMIT'
+        GROUP BY o.limit_price_cents
+        ORDER BY o.limit_price_cents DESC
+        LIMIT p_depth
+    )
+UNION ALL
+(
+    SELECT 'SELL'::TEXT,
+        o.limit_price_cents,
+        SUM(o.remaining_quantity)
+    FROM orders o
+    WHERE o.stock_ticker = p_stock_ticker
+        AND o.side = 'SELL'
+        AND o.status IN ('PENDING', 'PARTIAL')
+        AND o.order_type = 'LIMIT'
+    GROUP BY o.limit_price_cents
+    ORDER BY o.limit_price_cents ASC
+    LIMIT p_depth
+);
+END;
+$$ LANGUAGE plpgsql;
+ALTER TABLE orders DROP COLUMN IF EXISTS display_quantity;
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE self_trade_preventions (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    stock_ticker TEXT NOT NULL REFERENCES stocks(ticker) ON DELETE CASCADE,
+    incoming_order_id UUID NOT NULL REFERENCES orders(id),
+    resting_order_id UUID NOT NULL REFERENCES orders(id),
+    incoming_trader_id BIGINT NOT NULL REFERENCES traders(id),
+    resting_trader_id BIGINT NOT NULL REFERENCES traders(id),
+    mode TEXT NOT NULL CHECK (
+        mode IN (
+            'ALLOW',
+            'CANCEL_NEWEST',
+            'CANCEL_OLDEST',
+            'CANCEL_BOTH',
+            'DECREMENT'
+        )
+    ),
+    quantity BIGINT NOT NULL CHECK (quantity > 0),
+    price_cents BIGINT NOT NULL CHECK (price_cents > 0),
+    prevented_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX idx_self_trade_preventions_incoming_trader ON self_trade_preventions(incoming_trader_id, prevented_at DESC);
+CREATE INDEX idx_self_trade_preventions_stock ON self_trade_preventions(stock_ticker, prevented_at DESC);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS self_trade_preventions CASCADE;
+-- +goose StatementEnd
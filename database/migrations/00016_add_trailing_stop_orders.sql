@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_order_type_check;
+ALTER TABLE orders
+ADD CONSTRAINT orders_order_type_check CHECK (
+        order_type IN (
+            'MARKET',
+            'LIMIT',
+            'STOP_MARKET',
+            'STOP_LIMIT',
+            'TRAILING_STOP'
+        )
+    );
+-- Distance of a trailing stop's trigger from the best price seen, in cents or basis points.
+-- trigger_price_cents holds the trigger at placement and is updated to the final trigger when it fires.
+ALTER TABLE orders
+ADD COLUMN trailing_offset_cents BIGINT CHECK (trailing_offset_cents > 0),
+    ADD COLUMN trailing_offset_bps BIGINT CHECK (
+        trailing_offset_bps > 0
+        AND trailing_offset_bps < 10000
+    );
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE orders DROP COLUMN IF EXISTS trailing_offset_bps,
+    DROP COLUMN IF EXISTS trailing_offset_cents;
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_order_type_check;
+ALTER TABLE orders
+ADD CONSTRAINT orders_order_type_check CHECK (
+        order_type IN ('MARKET', 'LIMIT', 'STOP_MARKET', 'STOP_LIMIT')
+    );
+-- +goose StatementEnd
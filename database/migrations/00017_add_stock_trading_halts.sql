@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- End of the current price-band halt, NULL while the stock is trading
+ALTER TABLE stocks
+ADD COLUMN halted_until TIMESTAMPTZ;
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE stocks DROP COLUMN IF EXISTS halted_until;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Price and quantity grid the matching engine enforces for each stock
+ALTER TABLE stocks
+ADD COLUMN tick_size_cents BIGINT NOT NULL DEFAULT 1 CHECK (tick_size_cents > 0),
+    ADD COLUMN lot_size BIGINT NOT NULL DEFAULT 1 CHECK (lot_size > 0),
+    ADD COLUMN min_quantity BIGINT NOT NULL DEFAULT 1 CHECK (min_quantity > 0);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE stocks DROP COLUMN IF EXISTS tick_size_cents,
+    DROP COLUMN IF EXISTS lot_size,
+    DROP COLUMN IF EXISTS min_quantity;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- History of the market's trading-day phases; the latest row is the current phase
+CREATE TABLE market_session_phases (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    phase TEXT NOT NULL CHECK (
+        phase IN ('PRE_OPEN', 'CONTINUOUS', 'CLOSE', 'CLOSED')
+    ),
+    previous_phase TEXT NOT NULL CHECK (
+        previous_phase IN ('PRE_OPEN', 'CONTINUOUS', 'CLOSE', 'CLOSED')
+    ),
+    changed_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX idx_market_session_phases_changed_at ON market_session_phases(changed_at DESC);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS market_session_phases CASCADE;
+-- +goose StatementEnd
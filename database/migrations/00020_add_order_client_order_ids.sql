@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The trader's own ID for an order, sent with PlaceOrder; not unique once the engine's
+-- deduplication window has passed
+ALTER TABLE orders
+ADD COLUMN client_order_id TEXT;
+CREATE INDEX idx_orders_trader_client_order_id ON orders(trader_id, client_order_id)
+WHERE client_order_id IS NOT NULL;
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_orders_trader_client_order_id;
+ALTER TABLE orders DROP COLUMN IF EXISTS client_order_id;
+-- +goose StatementEnd
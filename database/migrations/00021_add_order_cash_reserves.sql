@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Cash held for a stop-market or trailing-stop buy from the moment it is placed, so the
+-- market order it triggers into can't spend cash the trader has used since: its quantity at
+-- the trigger price plus the engine's slippage buffer, at most the trader's balance. Its
+-- trades are paid from the reserve and what is left is released when the order closes.
+ALTER TABLE orders
+ADD COLUMN cash_reserved_cents BIGINT NOT NULL DEFAULT 0 CHECK (cash_reserved_cents >= 0);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE orders DROP COLUMN IF EXISTS cash_reserved_cents;
+-- +goose StatementEnd
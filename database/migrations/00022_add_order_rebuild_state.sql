@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+-- What the engine needs to put an open order back where it was when it rebuilds its books:
+-- the per-order self-trade prevention mode (NULL for the engine's default), the post-only
+-- settings an amendment is checked against, and when the order took its place in its price
+-- level's queue. A re-queueing amendment moves queued_at on; orders from before this
+-- migration fall back to created_at.
+ALTER TABLE orders
+ADD COLUMN self_trade_prevention TEXT CHECK (
+        self_trade_prevention IN (
+            'ALLOW',
+            'CANCEL_NEWEST',
+            'CANCEL_OLDEST',
+            'CANCEL_BOTH',
+            'DECREMENT'
+        )
+    ),
+    ADD COLUMN post_only BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN post_only_reprice BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN queued_at TIMESTAMPTZ;
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE orders DROP COLUMN IF EXISTS queued_at,
+    DROP COLUMN IF EXISTS post_only_reprice,
+    DROP COLUMN IF EXISTS post_only,
+    DROP COLUMN IF EXISTS self_trade_prevention;
+-- +goose StatementEnd
@@ -9,17 +9,35 @@ WITH inserted_order AS (
             quantity,
             remaining_quantity,
             limit_price_cents,
+            trigger_price_cents,
+            time_in_force,
+            expires_at,
+            display_quantity,
+            client_order_id,
+            self_trade_prevention,
+            post_only,
+            post_only_reprice,
+            queued_at,
             status
         )
     VALUES (
             $1,
             $2,
             $3,
-            'LIMIT',
-            'BUY',
-            $4,
             $4,
+            'BUY',
             $5,
+            $5,
+            $6,
+            $7,
+            $8,
+            $9,
+            $10,
+            $11,
+            $12,
+            $13,
+            $14,
+            $15,
             'PENDING'
         )
     RETURNING id,
@@ -38,28 +56,62 @@ lock_trader_cash AS (
 )
 SELECT 1;
 -- name: HandleMarketBuyOrderPlaced :exec
-INSERT INTO orders (
-        id,
+WITH inserted_order AS (
+    INSERT INTO orders (
+            id,
+            trader_id,
+            stock_ticker,
+            order_type,
+            side,
+            quantity,
+            remaining_quantity,
+            limit_price_cents,
+            trigger_price_cents,
+            time_in_force,
+            expires_at,
+            trailing_offset_cents,
+            trailing_offset_bps,
+            client_order_id,
+            cash_reserved_cents,
+            self_trade_prevention,
+            queued_at,
+            status
+        )
+    VALUES (
+            $1,
+            $2,
+            $3,
+            $4,
+            'BUY',
+            $5,
+            $5,
+            NULL,
+            $6,
+            $7,
+            $8,
+            $9,
+            $10,
+            $11,
+            $12,
+            $13,
+            $14,
+            'PENDING'
+        )
+    RETURNING id,
         trader_id,
-        stock_ticker,
-        order_type,
-        side,
-        quantity,
-        remaining_quantity,
-        limit_price_cents,
-        status
-    )
-VALUES (
-        $1,
-        $2,
-        $3,
-        'MARKET',
-        'BUY',
-        $4,
-        $4,
-        NULL,
-        'PENDING'
-    );
+        cash_reserved_cents
+),
+-- Hold the cash reserved for a stop-market or trailing-stop buy, 0 for market buys
+reserve_trader_cash AS (
+    UPDATE traders
+    SET cash_balance_cents = traders.cash_balance_cents - io.cash_reserved_cents,
+        cash_hold_cents = traders.cash_hold_cents + io.cash_reserved_cents,
+        updated_at = NOW()
+    FROM inserted_order io
+    WHERE traders.id = io.trader_id
+        AND io.cash_reserved_cents > 0
+)
+SELECT 1;
 -- name: HandleSellOrderPlaced :exec
 WITH inserted_order AS (
     INSERT INTO orders (
@@ -71,9 +123,41 @@ WITH inserted_order AS (
             quantity,
             remaining_quantity,
             limit_price_cents,
+            trigger_price_cents,
+            time_in_force,
+            expires_at,
+            display_quantity,
+            trailing_offset_cents,
+            trailing_offset_bps,
+            client_order_id,
+            self_trade_prevention,
+            post_only,
+            post_only_reprice,
+            queued_at,
             status
         )
-    VALUES ($1, $2, $3, $4, 'SELL', $5, $5, $6, 'PENDING')
+    VALUES (
+            $1,
+            $2,
+            $3,
+            $4,
+            'SELL',
+            $5,
+            $5,
+            $6,
+            $7,
+            $8,
+            $9,
+            $10,
+            $11,
+            $12,
+            $13,
+            $14,
+            $15,
+            $16,
+            $17,
+            'PENDING'
+        )
     RETURNING id,
         trader_id,
         stock_ticker,
@@ -212,12 +296,29 @@ WITH trade_info AS (
         price_cents,
         total_value_cents
 ),
--- Deduct cash directly from buyer's balance (no hold exists)
+-- Part of the trade paid from the order's cash reserve; market buys have none
+buyer_reserve AS (
+    SELECT LEAST(o.cash_reserved_cents, ti.total_value_cents) AS spent
+    FROM orders o
+        INNER JOIN trade_info ti ON o.id = ti.buyer_order_id
+),
+spend_buyer_reserve AS (
+    UPDATE orders
+    SET cash_reserved_cents = orders.cash_reserved_cents - br.spent,
+        updated_at = NOW()
+    FROM trade_info ti
+        CROSS JOIN buyer_reserve br
+    WHERE orders.id = ti.buyer_order_id
+        AND br.spent > 0
+),
+-- Pay from the reserve's hold, and the rest directly from the buyer's balance
 deduct_buyer_cash AS (
     UPDATE traders
-    SET cash_balance_cents = traders.cash_balance_cents - ti.total_value_cents,
+    SET cash_hold_cents = traders.cash_hold_cents - COALESCE(br.spent, 0),
+        cash_balance_cents = traders.cash_balance_cents - (ti.total_value_cents - COALESCE(br.spent, 0)),
         updated_at = NOW()
     FROM trade_info ti
+        LEFT JOIN buyer_reserve br ON TRUE
     WHERE traders.id = ti.buyer_trader_id
 ),
 -- Add shares to buyer's position
@@ -280,14 +381,177 @@ update_seller_portfolio AS (
     FROM trade_info ti
 )
 SELECT 1;
--- name: HandleOrderFilled :exec
+-- name: HandleOrderTriggered :exec
 UPDATE orders
-SET status = 'FILLED',
-    filled_quantity = orders.quantity,
-    remaining_quantity = 0,
-    filled_at = NOW(),
+SET triggered_at = NOW(),
+    trigger_price_cents = $2,
+    queued_at = $3,
     updated_at = NOW()
-WHERE orders.id = $1;
+WHERE orders.id = $1
+    AND status = 'PENDING';
+-- name: HandleTrailingStopMoved :exec
+UPDATE orders
+SET trigger_price_cents = $2,
+    updated_at = NOW()
+WHERE orders.id = $1
+    AND status = 'PENDING'
+    AND triggered_at IS NULL;
+-- name: HandleLimitBuyOrderAmended :exec
+WITH old_order AS (
+    SELECT id,
+        trader_id,
+        remaining_quantity,
+        limit_price_cents
+    FROM orders
+    WHERE orders.id = $1
+        AND status IN ('PENDING', 'PARTIAL') FOR
+    UPDATE
+),
+amended_order AS (
+    UPDATE orders
+    SET quantity = orders.filled_quantity + $2,
+        remaining_quantity = $2,
+        limit_price_cents = $3,
+        queued_at = COALESCE(sqlc.narg(queued_at), orders.queued_at),
+        updated_at = NOW()
+    FROM old_order oo
+    WHERE orders.id = oo.id
+),
+-- Move the cash hold difference between the old and new remaining order
+adjust_trader_cash AS (
+    UPDATE traders
+    SET cash_balance_cents = traders.cash_balance_cents - (
+            ($2 * $3) - (oo.remaining_quantity * oo.limit_price_cents)
+        ),
+        cash_hold_cents = traders.cash_hold_cents + (
+            ($2 * $3) - (oo.remaining_quantity * oo.limit_price_cents)
+        ),
+        updated_at = NOW()
+    FROM old_order oo
+    WHERE traders.id = oo.trader_id
+)
+SELECT 1;
+-- name: HandleReservedBuyOrderAmended :exec
+WITH old_order AS (
+    SELECT id,
+        trader_id,
+        cash_reserved_cents
+    FROM orders
+    WHERE orders.id = $1
+        AND status IN ('PENDING', 'PARTIAL') FOR
+    UPDATE
+),
+amended_order AS (
+    UPDATE orders
+    SET quantity = orders.filled_quantity + $2,
+        remaining_quantity = $2,
+        cash_reserved_cents = $3,
+        updated_at = NOW()
+    FROM old_order oo
+    WHERE orders.id = oo.id
+),
+-- Move the difference between the old and new reserve of a held stop-market or trailing-stop buy
+adjust_trader_cash AS (
+    UPDATE traders
+    SET cash_balance_cents = traders.cash_balance_cents - ($3 - oo.cash_reserved_cents),
+        cash_hold_cents = traders.cash_hold_cents + ($3 - oo.cash_reserved_cents),
+        updated_at = NOW()
+    FROM old_order oo
+    WHERE traders.id = oo.trader_id
+)
+SELECT 1;
+-- name: HandleSellOrderAmended :exec
+WITH old_order AS (
+    SELECT id,
+        trader_id,
+        stock_ticker,
+        remaining_quantity
+    FROM orders
+    WHERE orders.id = $1
+        AND status IN ('PENDING', 'PARTIAL') FOR
+    UPDATE
+),
+amended_order AS (
+    UPDATE orders
+    SET quantity = orders.filled_quantity + $2,
+        remaining_quantity = $2,
+        limit_price_cents = $3,
+        queued_at = COALESCE(sqlc.narg(queued_at), orders.queued_at),
+        updated_at = NOW()
+    FROM old_order oo
+    WHERE orders.id = oo.id
+),
+-- Move the share hold difference between the old and new remaining order
+adjust_trader_shares AS (
+    UPDATE positions
+    SET quantity = positions.quantity - ($2 - oo.remaining_quantity),
+        quantity_hold = positions.quantity_hold + ($2 - oo.remaining_quantity),
+        updated_at = NOW()
+    FROM old_order oo
+    WHERE positions.trader_id = oo.trader_id
+        AND positions.stock_ticker = oo.stock_ticker
+)
+SELECT 1;
+-- name: HandleSelfTradePrevented :exec
+INSERT INTO self_trade_preventions (
+        stock_ticker,
+        incoming_order_id,
+        resting_order_id,
+        incoming_trader_id,
+        resting_trader_id,
+        mode,
+        quantity,
+        price_cents
+    )
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+-- name: HandleClosingAuction :exec
+UPDATE stocks
+SET previous_close_cents = $2,
+    updated_at = NOW()
+WHERE ticker = $1;
+-- name: HandleTradingHalted :exec
+UPDATE stocks
+SET halted_until = $2,
+    updated_at = NOW()
+WHERE ticker = $1;
+-- name: HandleTradingResumed :exec
+UPDATE stocks
+SET halted_until = NULL,
+    updated_at = NOW()
+WHERE ticker = $1;
+-- name: HandleSessionPhaseChanged :exec
+INSERT INTO market_session_phases (phase, previous_phase, changed_at)
+VALUES ($1, $2, $3);
+-- name: HandleOrderFilled :exec
+WITH filled_order AS (
+    UPDATE orders
+    SET status = 'FILLED',
+        filled_quantity = orders.quantity,
+        remaining_quantity = 0,
+        cash_reserved_cents = 0,
+        filled_at = NOW(),
+        updated_at = NOW()
+    FROM (
+            SELECT o.id,
+                o.cash_reserved_cents
+            FROM orders o
+            WHERE o.id = $1
+        ) prior
+    WHERE orders.id = prior.id
+    RETURNING orders.trader_id,
+        prior.cash_reserved_cents
+),
+-- Return cash a filled stop-market or trailing-stop buy didn't spend
+release_reserved_cash AS (
+    UPDATE traders
+    SET cash_balance_cents = traders.cash_balance_cents + fo.cash_reserved_cents,
+        cash_hold_cents = traders.cash_hold_cents - fo.cash_reserved_cents,
+        updated_at = NOW()
+    FROM filled_order fo
+    WHERE traders.id = fo.trader_id
+        AND fo.cash_reserved_cents > 0
+)
+SELECT 1;
 -- name: HandleOrderPartiallyFilled :exec
 UPDATE orders
 SET filled_quantity = orders.filled_quantity + $2,
@@ -299,6 +563,7 @@ WHERE orders.id = $1;
 WITH cancelled_order AS (
     UPDATE orders
     SET status = 'CANCELLED',
+        cancel_reason = $2,
         cancelled_at = NOW(),
         updated_at = NOW()
     WHERE orders.id = $1
@@ -319,16 +584,40 @@ return_trader_cash AS (
 )
 SELECT 1;
 -- name: HandleMarketBuyOrderCancelled :exec
-UPDATE orders
-SET status = 'CANCELLED',
-    cancelled_at = NOW(),
-    updated_at = NOW()
-WHERE orders.id = $1
-    AND status IN ('PENDING', 'PARTIAL');
+WITH cancelled_order AS (
+    UPDATE orders
+    SET status = 'CANCELLED',
+        cancel_reason = $2,
+        cancelled_at = NOW(),
+        cash_reserved_cents = 0,
+        updated_at = NOW()
+    FROM (
+            SELECT o.id,
+                o.cash_reserved_cents
+            FROM orders o
+            WHERE o.id = $1
+        ) prior
+    WHERE orders.id = prior.id
+        AND orders.status IN ('PENDING', 'PARTIAL')
+    RETURNING orders.trader_id,
+        prior.cash_reserved_cents
+),
+-- Release what is left of a stop-market or trailing-stop buy's cash reserve
+return_reserved_cash AS (
+    UPDATE traders
+    SET cash_balance_cents = traders.cash_balance_cents + co.cash_reserved_cents,
+        cash_hold_cents = traders.cash_hold_cents - co.cash_reserved_cents,
+        updated_at = NOW()
+    FROM cancelled_order co
+    WHERE traders.id = co.trader_id
+        AND co.cash_reserved_cents > 0
+)
+SELECT 1;
 -- name: HandleSellOrderCancelled :exec
 WITH cancelled_order AS (
     UPDATE orders
     SET status = 'CANCELLED',
+        cancel_reason = $2,
         cancelled_at = NOW(),
         updated_at = NOW()
     WHERE orders.id = $1
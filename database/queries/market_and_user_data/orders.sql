@@ -15,6 +15,12 @@ RETURNING *;
 SELECT *
 FROM orders
 WHERE id = $1;
+-- name: GetOrderByClientOrderID :many
+SELECT *
+FROM orders
+WHERE trader_id = $1
+    AND client_order_id = $2
+ORDER BY created_at DESC;
 -- name: GetPendingOrdersForStock :many
 SELECT *
 FROM orders
@@ -23,7 +29,12 @@ WHERE stock_ticker = $1
 ORDER BY created_at;
 -- name: GetOrderBookBuys :many
 SELECT limit_price_cents,
-    SUM(remaining_quantity) as quantity
+    SUM(
+        LEAST(
+            COALESCE(display_quantity, remaining_quantity),
+            remaining_quantity
+        )
+    ) as quantity
 FROM orders
 WHERE stock_ticker = $1
     AND side = 'BUY'
@@ -34,7 +45,12 @@ ORDER BY limit_price_cents DESC
 LIMIT $2;
 -- name: GetOrderBookSells :many
 SELECT limit_price_cents,
-    SUM(remaining_quantity) as quantity
+    SUM(
+        LEAST(
+            COALESCE(display_quantity, remaining_quantity),
+            remaining_quantity
+        )
+    ) as quantity
 FROM orders
 WHERE stock_ticker = $1
     AND side = 'SELL'
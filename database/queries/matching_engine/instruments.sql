@@ -0,0 +1,14 @@
+-- name: ListInstruments :many
+SELECT ticker,
+    tick_size_cents,
+    lot_size,
+    min_quantity,
+    is_active
+FROM stocks
+ORDER BY ticker;
+-- name: ListPreviousCloses :many
+SELECT ticker,
+    previous_close_cents::BIGINT AS previous_close_cents
+FROM stocks
+WHERE previous_close_cents IS NOT NULL
+ORDER BY ticker;
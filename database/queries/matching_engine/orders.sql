@@ -0,0 +1,54 @@
+-- name: ListOpenOrders :many
+SELECT o.id,
+    o.trader_id,
+    t.owner_trader_id,
+    o.stock_ticker,
+    o.order_type,
+    o.side,
+    o.remaining_quantity,
+    o.limit_price_cents,
+    o.trigger_price_cents,
+    o.triggered_at,
+    o.trailing_offset_cents,
+    o.trailing_offset_bps,
+    o.cash_reserved_cents,
+    o.time_in_force,
+    o.expires_at,
+    o.display_quantity,
+    o.client_order_id,
+    o.self_trade_prevention,
+    o.post_only,
+    o.post_only_reprice,
+    o.created_at,
+    COALESCE(o.queued_at, o.created_at)::TIMESTAMPTZ AS queued_at
+FROM orders o
+    JOIN traders t ON t.id = o.trader_id
+WHERE o.status IN ('PENDING', 'PARTIAL')
+ORDER BY COALESCE(o.queued_at, o.created_at),
+    o.created_at,
+    o.id;
+-- name: ListStockPrices :many
+SELECT ticker,
+    current_price_cents,
+    previous_close_cents
+FROM stocks
+ORDER BY ticker;
+-- name: SumOpenOrderQuantities :many
+SELECT stock_ticker,
+    side,
+    SUM(remaining_quantity)::BIGINT AS remaining_quantity
+FROM orders
+WHERE status IN ('PENDING', 'PARTIAL')
+GROUP BY stock_ticker,
+    side;
+-- name: ListCashHolds :many
+SELECT id,
+    cash_hold_cents
+FROM traders
+WHERE cash_hold_cents <> 0;
+-- name: ListShareHolds :many
+SELECT trader_id,
+    stock_ticker,
+    quantity_hold
+FROM positions
+WHERE quantity_hold <> 0;
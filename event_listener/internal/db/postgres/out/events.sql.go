@@ -11,10 +11,82 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const handleClosingAuction = `-- name: HandleClosingAuction :exec
+UPDATE stocks
+SET previous_close_cents = $2,
+    updated_at = NOW()
+WHERE ticker = $1
+`
+
+type HandleClosingAuctionParams struct {
+	Ticker             string      `json:"ticker"`
+	PreviousCloseCents pgtype.Int8 `json:"previous_close_cents"`
+}
+
+func (q *Queries) HandleClosingAuction(ctx context.Context, arg HandleClosingAuctionParams) error {
+	_, err := q.db.Exec(ctx, handleClosingAuction, arg.Ticker, arg.PreviousCloseCents)
+	return err
+}
+
+const handleLimitBuyOrderAmended = `-- name: HandleLimitBuyOrderAmended :exec
+WITH old_order AS (
+    SELECT id,
+        trader_id,
+        remaining_quantity,
+        limit_price_cents
+    FROM orders
+    WHERE orders.id = $1
+        AND status IN ('PENDING', 'PARTIAL') FOR
+    UPDATE
+),
+amended_order AS (
+    UPDATE orders
+    SET quantity = orders.filled_quantity + $2,
+        remaining_quantity = $2,
+        limit_price_cents = $3,
+        queued_at = COALESCE($4, orders.queued_at),
+        updated_at = NOW()
+    FROM old_order oo
+    WHERE orders.id = oo.id
+),
+adjust_trader_cash AS (
+    UPDATE traders
+    SET cash_balance_cents = traders.cash_balance_cents - (
+            ($2 * $3) - (oo.remaining_quantity * oo.limit_price_cents)
+        ),
+        cash_hold_cents = traders.cash_hold_cents + (
+            ($2 * $3) - (oo.remaining_quantity * oo.limit_price_cents)
+        ),
+        updated_at = NOW()
+    FROM old_order oo
+    WHERE traders.id = oo.trader_id
+)
+SELECT 1
+`
+
+type HandleLimitBuyOrderAmendedParams struct {
+	ID                pgtype.UUID        `json:"id"`
+	RemainingQuantity int64              `json:"remaining_quantity"`
+	LimitPriceCents   pgtype.Int8        `json:"limit_price_cents"`
+	QueuedAt          pgtype.Timestamptz `json:"queued_at"`
+}
+
+// Move the cash hold difference between the old and new remaining order
+func (q *Queries) HandleLimitBuyOrderAmended(ctx context.Context, arg HandleLimitBuyOrderAmendedParams) error {
+	_, err := q.db.Exec(ctx, handleLimitBuyOrderAmended,
+		arg.ID,
+		arg.RemainingQuantity,
+		arg.LimitPriceCents,
+		arg.QueuedAt,
+	)
+	return err
+}
+
 const handleLimitBuyOrderCancelled = `-- name: HandleLimitBuyOrderCancelled :exec
 WITH cancelled_order AS (
     UPDATE orders
     SET status = 'CANCELLED',
+        cancel_reason = $2,
         cancelled_at = NOW(),
         updated_at = NOW()
     WHERE orders.id = $1
@@ -35,9 +107,14 @@ return_trader_cash AS (
 SELECT 1
 `
 
+type HandleLimitBuyOrderCancelledParams struct {
+	ID           pgtype.UUID `json:"id"`
+	CancelReason pgtype.Text `json:"cancel_reason"`
+}
+
 // Release cash hold for limit buy
-func (q *Queries) HandleLimitBuyOrderCancelled(ctx context.Context, id pgtype.UUID) error {
-	_, err := q.db.Exec(ctx, handleLimitBuyOrderCancelled, id)
+func (q *Queries) HandleLimitBuyOrderCancelled(ctx context.Context, arg HandleLimitBuyOrderCancelledParams) error {
+	_, err := q.db.Exec(ctx, handleLimitBuyOrderCancelled, arg.ID, arg.CancelReason)
 	return err
 }
 
@@ -52,17 +129,35 @@ WITH inserted_order AS (
             quantity,
             remaining_quantity,
             limit_price_cents,
+            trigger_price_cents,
+            time_in_force,
+            expires_at,
+            display_quantity,
+            client_order_id,
+            self_trade_prevention,
+            post_only,
+            post_only_reprice,
+            queued_at,
             status
         )
     VALUES (
             $1,
             $2,
             $3,
-            'LIMIT',
-            'BUY',
-            $4,
             $4,
+            'BUY',
+            $5,
             $5,
+            $6,
+            $7,
+            $8,
+            $9,
+            $10,
+            $11,
+            $12,
+            $13,
+            $14,
+            $15,
             'PENDING'
         )
     RETURNING id,
@@ -82,11 +177,21 @@ SELECT 1
 `
 
 type HandleLimitBuyOrderPlacedParams struct {
-	ID              pgtype.UUID `json:"id"`
-	TraderID        int64       `json:"trader_id"`
-	StockTicker     string      `json:"stock_ticker"`
-	Quantity        int64       `json:"quantity"`
-	LimitPriceCents pgtype.Int8 `json:"limit_price_cents"`
+	ID                  pgtype.UUID        `json:"id"`
+	TraderID            int64              `json:"trader_id"`
+	StockTicker         string             `json:"stock_ticker"`
+	OrderType           string             `json:"order_type"`
+	Quantity            int64              `json:"quantity"`
+	LimitPriceCents     pgtype.Int8        `json:"limit_price_cents"`
+	TriggerPriceCents   pgtype.Int8        `json:"trigger_price_cents"`
+	TimeInForce         string             `json:"time_in_force"`
+	ExpiresAt           pgtype.Timestamptz `json:"expires_at"`
+	DisplayQuantity     pgtype.Int8        `json:"display_quantity"`
+	ClientOrderID       pgtype.Text        `json:"client_order_id"`
+	SelfTradePrevention pgtype.Text        `json:"self_trade_prevention"`
+	PostOnly            bool               `json:"post_only"`
+	PostOnlyReprice     bool               `json:"post_only_reprice"`
+	QueuedAt            pgtype.Timestamptz `json:"queued_at"`
 }
 
 // Lock cash at limit price
@@ -95,8 +200,18 @@ func (q *Queries) HandleLimitBuyOrderPlaced(ctx context.Context, arg HandleLimit
 		arg.ID,
 		arg.TraderID,
 		arg.StockTicker,
+		arg.OrderType,
 		arg.Quantity,
 		arg.LimitPriceCents,
+		arg.TriggerPriceCents,
+		arg.TimeInForce,
+		arg.ExpiresAt,
+		arg.DisplayQuantity,
+		arg.ClientOrderID,
+		arg.SelfTradePrevention,
+		arg.PostOnly,
+		arg.PostOnlyReprice,
+		arg.QueuedAt,
 	)
 	return err
 }
@@ -229,57 +344,139 @@ func (q *Queries) HandleLimitBuyTradeExecuted(ctx context.Context, arg HandleLim
 }
 
 const handleMarketBuyOrderCancelled = `-- name: HandleMarketBuyOrderCancelled :exec
-UPDATE orders
-SET status = 'CANCELLED',
-    cancelled_at = NOW(),
-    updated_at = NOW()
-WHERE orders.id = $1
-    AND status IN ('PENDING', 'PARTIAL')
+WITH cancelled_order AS (
+    UPDATE orders
+    SET status = 'CANCELLED',
+        cancel_reason = $2,
+        cancelled_at = NOW(),
+        cash_reserved_cents = 0,
+        updated_at = NOW()
+    FROM (
+            SELECT o.id,
+                o.cash_reserved_cents
+            FROM orders o
+            WHERE o.id = $1
+        ) prior
+    WHERE orders.id = prior.id
+        AND orders.status IN ('PENDING', 'PARTIAL')
+    RETURNING orders.trader_id,
+        prior.cash_reserved_cents
+),
+return_reserved_cash AS (
+    UPDATE traders
+    SET cash_balance_cents = traders.cash_balance_cents + co.cash_reserved_cents,
+        cash_hold_cents = traders.cash_hold_cents - co.cash_reserved_cents,
+        updated_at = NOW()
+    FROM cancelled_order co
+    WHERE traders.id = co.trader_id
+        AND co.cash_reserved_cents > 0
+)
+SELECT 1
 `
 
-func (q *Queries) HandleMarketBuyOrderCancelled(ctx context.Context, id pgtype.UUID) error {
-	_, err := q.db.Exec(ctx, handleMarketBuyOrderCancelled, id)
+type HandleMarketBuyOrderCancelledParams struct {
+	ID           pgtype.UUID `json:"id"`
+	CancelReason pgtype.Text `json:"cancel_reason"`
+}
+
+// Release what is left of a stop-market or trailing-stop buy's cash reserve
+func (q *Queries) HandleMarketBuyOrderCancelled(ctx context.Context, arg HandleMarketBuyOrderCancelledParams) error {
+	_, err := q.db.Exec(ctx, handleMarketBuyOrderCancelled, arg.ID, arg.CancelReason)
 	return err
 }
 
 const handleMarketBuyOrderPlaced = `-- name: HandleMarketBuyOrderPlaced :exec
-INSERT INTO orders (
-        id,
+WITH inserted_order AS (
+    INSERT INTO orders (
+            id,
+            trader_id,
+            stock_ticker,
+            order_type,
+            side,
+            quantity,
+            remaining_quantity,
+            limit_price_cents,
+            trigger_price_cents,
+            time_in_force,
+            expires_at,
+            trailing_offset_cents,
+            trailing_offset_bps,
+            client_order_id,
+            cash_reserved_cents,
+            self_trade_prevention,
+            queued_at,
+            status
+        )
+    VALUES (
+            $1,
+            $2,
+            $3,
+            $4,
+            'BUY',
+            $5,
+            $5,
+            NULL,
+            $6,
+            $7,
+            $8,
+            $9,
+            $10,
+            $11,
+            $12,
+            $13,
+            $14,
+            'PENDING'
+        )
+    RETURNING id,
         trader_id,
-        stock_ticker,
-        order_type,
-        side,
-        quantity,
-        remaining_quantity,
-        limit_price_cents,
-        status
-    )
-VALUES (
-        $1,
-        $2,
-        $3,
-        'MARKET',
-        'BUY',
-        $4,
-        $4,
-        NULL,
-        'PENDING'
-    )
+        cash_reserved_cents
+),
+reserve_trader_cash AS (
+    UPDATE traders
+    SET cash_balance_cents = traders.cash_balance_cents - io.cash_reserved_cents,
+        cash_hold_cents = traders.cash_hold_cents + io.cash_reserved_cents,
+        updated_at = NOW()
+    FROM inserted_order io
+    WHERE traders.id = io.trader_id
+        AND io.cash_reserved_cents > 0
+)
+SELECT 1
 `
 
 type HandleMarketBuyOrderPlacedParams struct {
-	ID          pgtype.UUID `json:"id"`
-	TraderID    int64       `json:"trader_id"`
-	StockTicker string      `json:"stock_ticker"`
-	Quantity    int64       `json:"quantity"`
+	ID                  pgtype.UUID        `json:"id"`
+	TraderID            int64              `json:"trader_id"`
+	StockTicker         string             `json:"stock_ticker"`
+	OrderType           string             `json:"order_type"`
+	Quantity            int64              `json:"quantity"`
+	TriggerPriceCents   pgtype.Int8        `json:"trigger_price_cents"`
+	TimeInForce         string             `json:"time_in_force"`
+	ExpiresAt           pgtype.Timestamptz `json:"expires_at"`
+	TrailingOffsetCents pgtype.Int8        `json:"trailing_offset_cents"`
+	TrailingOffsetBps   pgtype.Int8        `json:"trailing_offset_bps"`
+	ClientOrderID       pgtype.Text        `json:"client_order_id"`
+	CashReservedCents   int64              `json:"cash_reserved_cents"`
+	SelfTradePrevention pgtype.Text        `json:"self_trade_prevention"`
+	QueuedAt            pgtype.Timestamptz `json:"queued_at"`
 }
 
+// Hold the cash reserved for a stop-market or trailing-stop buy, 0 for market buys
 func (q *Queries) HandleMarketBuyOrderPlaced(ctx context.Context, arg HandleMarketBuyOrderPlacedParams) error {
 	_, err := q.db.Exec(ctx, handleMarketBuyOrderPlaced,
 		arg.ID,
 		arg.TraderID,
 		arg.StockTicker,
+		arg.OrderType,
 		arg.Quantity,
+		arg.TriggerPriceCents,
+		arg.TimeInForce,
+		arg.ExpiresAt,
+		arg.TrailingOffsetCents,
+		arg.TrailingOffsetBps,
+		arg.ClientOrderID,
+		arg.CashReservedCents,
+		arg.SelfTradePrevention,
+		arg.QueuedAt,
 	)
 	return err
 }
@@ -306,11 +503,27 @@ WITH trade_info AS (
         price_cents,
         total_value_cents
 ),
+buyer_reserve AS (
+    SELECT LEAST(o.cash_reserved_cents, ti.total_value_cents) AS spent
+    FROM orders o
+        INNER JOIN trade_info ti ON o.id = ti.buyer_order_id
+),
+spend_buyer_reserve AS (
+    UPDATE orders
+    SET cash_reserved_cents = orders.cash_reserved_cents - br.spent,
+        updated_at = NOW()
+    FROM trade_info ti
+        CROSS JOIN buyer_reserve br
+    WHERE orders.id = ti.buyer_order_id
+        AND br.spent > 0
+),
 deduct_buyer_cash AS (
     UPDATE traders
-    SET cash_balance_cents = traders.cash_balance_cents - ti.total_value_cents,
+    SET cash_hold_cents = traders.cash_hold_cents - COALESCE(br.spent, 0),
+        cash_balance_cents = traders.cash_balance_cents - (ti.total_value_cents - COALESCE(br.spent, 0)),
         updated_at = NOW()
     FROM trade_info ti
+        LEFT JOIN buyer_reserve br ON TRUE
     WHERE traders.id = ti.buyer_trader_id
 ),
 buyer_add_position AS (
@@ -380,7 +593,8 @@ type HandleMarketBuyTradeExecutedParams struct {
 	TotalValueCents int64       `json:"total_value_cents"`
 }
 
-// Deduct cash directly from buyer's balance (no hold exists)
+// Part of the trade paid from the order's cash reserve; market buys have none
+// Pay from the reserve's hold, and the rest directly from the buyer's balance
 // Add shares to buyer's position
 // Release seller's share hold
 // Add cash to seller
@@ -402,15 +616,37 @@ func (q *Queries) HandleMarketBuyTradeExecuted(ctx context.Context, arg HandleMa
 }
 
 const handleOrderFilled = `-- name: HandleOrderFilled :exec
-UPDATE orders
-SET status = 'FILLED',
-    filled_quantity = orders.quantity,
-    remaining_quantity = 0,
-    filled_at = NOW(),
-    updated_at = NOW()
-WHERE orders.id = $1
+WITH filled_order AS (
+    UPDATE orders
+    SET status = 'FILLED',
+        filled_quantity = orders.quantity,
+        remaining_quantity = 0,
+        cash_reserved_cents = 0,
+        filled_at = NOW(),
+        updated_at = NOW()
+    FROM (
+            SELECT o.id,
+                o.cash_reserved_cents
+            FROM orders o
+            WHERE o.id = $1
+        ) prior
+    WHERE orders.id = prior.id
+    RETURNING orders.trader_id,
+        prior.cash_reserved_cents
+),
+release_reserved_cash AS (
+    UPDATE traders
+    SET cash_balance_cents = traders.cash_balance_cents + fo.cash_reserved_cents,
+        cash_hold_cents = traders.cash_hold_cents - fo.cash_reserved_cents,
+        updated_at = NOW()
+    FROM filled_order fo
+    WHERE traders.id = fo.trader_id
+        AND fo.cash_reserved_cents > 0
+)
+SELECT 1
 `
 
+// Return cash a filled stop-market or trailing-stop buy didn't spend
 func (q *Queries) HandleOrderFilled(ctx context.Context, id pgtype.UUID) error {
 	_, err := q.db.Exec(ctx, handleOrderFilled, id)
 	return err
@@ -454,10 +690,164 @@ func (q *Queries) HandleOrderRejected(ctx context.Context, arg HandleOrderReject
 	return err
 }
 
+const handleOrderTriggered = `-- name: HandleOrderTriggered :exec
+UPDATE orders
+SET triggered_at = NOW(),
+    trigger_price_cents = $2,
+    queued_at = $3,
+    updated_at = NOW()
+WHERE orders.id = $1
+    AND status = 'PENDING'
+`
+
+type HandleOrderTriggeredParams struct {
+	ID                pgtype.UUID        `json:"id"`
+	TriggerPriceCents pgtype.Int8        `json:"trigger_price_cents"`
+	QueuedAt          pgtype.Timestamptz `json:"queued_at"`
+}
+
+func (q *Queries) HandleOrderTriggered(ctx context.Context, arg HandleOrderTriggeredParams) error {
+	_, err := q.db.Exec(ctx, handleOrderTriggered, arg.ID, arg.TriggerPriceCents, arg.QueuedAt)
+	return err
+}
+
+const handleReservedBuyOrderAmended = `-- name: HandleReservedBuyOrderAmended :exec
+WITH old_order AS (
+    SELECT id,
+        trader_id,
+        cash_reserved_cents
+    FROM orders
+    WHERE orders.id = $1
+        AND status IN ('PENDING', 'PARTIAL') FOR
+    UPDATE
+),
+amended_order AS (
+    UPDATE orders
+    SET quantity = orders.filled_quantity + $2,
+        remaining_quantity = $2,
+        cash_reserved_cents = $3,
+        updated_at = NOW()
+    FROM old_order oo
+    WHERE orders.id = oo.id
+),
+adjust_trader_cash AS (
+    UPDATE traders
+    SET cash_balance_cents = traders.cash_balance_cents - ($3 - oo.cash_reserved_cents),
+        cash_hold_cents = traders.cash_hold_cents + ($3 - oo.cash_reserved_cents),
+        updated_at = NOW()
+    FROM old_order oo
+    WHERE traders.id = oo.trader_id
+)
+SELECT 1
+`
+
+type HandleReservedBuyOrderAmendedParams struct {
+	ID                pgtype.UUID `json:"id"`
+	RemainingQuantity int64       `json:"remaining_quantity"`
+	CashReservedCents int64       `json:"cash_reserved_cents"`
+}
+
+// Move the difference between the old and new reserve of a held stop-market or trailing-stop buy
+func (q *Queries) HandleReservedBuyOrderAmended(ctx context.Context, arg HandleReservedBuyOrderAmendedParams) error {
+	_, err := q.db.Exec(ctx, handleReservedBuyOrderAmended, arg.ID, arg.RemainingQuantity, arg.CashReservedCents)
+	return err
+}
+
+const handleSelfTradePrevented = `-- name: HandleSelfTradePrevented :exec
+INSERT INTO self_trade_preventions (
+        stock_ticker,
+        incoming_order_id,
+        resting_order_id,
+        incoming_trader_id,
+        resting_trader_id,
+        mode,
+        quantity,
+        price_cents
+    )
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+`
+
+type HandleSelfTradePreventedParams struct {
+	StockTicker      string      `json:"stock_ticker"`
+	IncomingOrderID  pgtype.UUID `json:"incoming_order_id"`
+	RestingOrderID   pgtype.UUID `json:"resting_order_id"`
+	IncomingTraderID int64       `json:"incoming_trader_id"`
+	RestingTraderID  int64       `json:"resting_trader_id"`
+	Mode             string      `json:"mode"`
+	Quantity         int64       `json:"quantity"`
+	PriceCents       int64       `json:"price_cents"`
+}
+
+func (q *Queries) HandleSelfTradePrevented(ctx context.Context, arg HandleSelfTradePreventedParams) error {
+	_, err := q.db.Exec(ctx, handleSelfTradePrevented,
+		arg.StockTicker,
+		arg.IncomingOrderID,
+		arg.RestingOrderID,
+		arg.IncomingTraderID,
+		arg.RestingTraderID,
+		arg.Mode,
+		arg.Quantity,
+		arg.PriceCents,
+	)
+	return err
+}
+
+const handleSellOrderAmended = `-- name: HandleSellOrderAmended :exec
+WITH old_order AS (
+    SELECT id,
+        trader_id,
+        stock_ticker,
+        remaining_quantity
+    FROM orders
+    WHERE orders.id = $1
+        AND status IN ('PENDING', 'PARTIAL') FOR
+    UPDATE
+),
+amended_order AS (
+    UPDATE orders
+    SET quantity = orders.filled_quantity + $2,
+        remaining_quantity = $2,
+        limit_price_cents = $3,
+        queued_at = COALESCE($4, orders.queued_at),
+        updated_at = NOW()
+    FROM old_order oo
+    WHERE orders.id = oo.id
+),
+adjust_trader_shares AS (
+    UPDATE positions
+    SET quantity = positions.quantity - ($2 - oo.remaining_quantity),
+        quantity_hold = positions.quantity_hold + ($2 - oo.remaining_quantity),
+        updated_at = NOW()
+    FROM old_order oo
+    WHERE positions.trader_id = oo.trader_id
+        AND positions.stock_ticker = oo.stock_ticker
+)
+SELECT 1
+`
+
+type HandleSellOrderAmendedParams struct {
+	ID                pgtype.UUID        `json:"id"`
+	RemainingQuantity int64              `json:"remaining_quantity"`
+	LimitPriceCents   pgtype.Int8        `json:"limit_price_cents"`
+	QueuedAt          pgtype.Timestamptz `json:"queued_at"`
+}
+
+// Move the share hold difference between the old and new remaining order
+func (q *Queries) HandleSellOrderAmended(ctx context.Context, arg HandleSellOrderAmendedParams) error {
+	_, err := q.db.Exec(ctx, handleSellOrderAmended,
+		arg.ID,
+		arg.RemainingQuantity,
+		arg.LimitPriceCents,
+		arg.QueuedAt,
+	)
+	return err
+}
+
 const handleSellOrderCancelled = `-- name: HandleSellOrderCancelled :exec
 WITH cancelled_order AS (
     UPDATE orders
     SET status = 'CANCELLED',
+        cancel_reason = $2,
         cancelled_at = NOW(),
         updated_at = NOW()
     WHERE orders.id = $1
@@ -479,9 +869,14 @@ return_trader_shares AS (
 SELECT 1
 `
 
+type HandleSellOrderCancelledParams struct {
+	ID           pgtype.UUID `json:"id"`
+	CancelReason pgtype.Text `json:"cancel_reason"`
+}
+
 // Release share hold for sell orders
-func (q *Queries) HandleSellOrderCancelled(ctx context.Context, id pgtype.UUID) error {
-	_, err := q.db.Exec(ctx, handleSellOrderCancelled, id)
+func (q *Queries) HandleSellOrderCancelled(ctx context.Context, arg HandleSellOrderCancelledParams) error {
+	_, err := q.db.Exec(ctx, handleSellOrderCancelled, arg.ID, arg.CancelReason)
 	return err
 }
 
@@ -496,9 +891,41 @@ WITH inserted_order AS (
             quantity,
             remaining_quantity,
             limit_price_cents,
+            trigger_price_cents,
+            time_in_force,
+            expires_at,
+            display_quantity,
+            trailing_offset_cents,
+            trailing_offset_bps,
+            client_order_id,
+            self_trade_prevention,
+            post_only,
+            post_only_reprice,
+            queued_at,
             status
         )
-    VALUES ($1, $2, $3, $4, 'SELL', $5, $5, $6, 'PENDING')
+    VALUES (
+            $1,
+            $2,
+            $3,
+            $4,
+            'SELL',
+            $5,
+            $5,
+            $6,
+            $7,
+            $8,
+            $9,
+            $10,
+            $11,
+            $12,
+            $13,
+            $14,
+            $15,
+            $16,
+            $17,
+            'PENDING'
+        )
     RETURNING id,
         trader_id,
         stock_ticker,
@@ -517,12 +944,23 @@ SELECT 1
 `
 
 type HandleSellOrderPlacedParams struct {
-	ID              pgtype.UUID `json:"id"`
-	TraderID        int64       `json:"trader_id"`
-	StockTicker     string      `json:"stock_ticker"`
-	OrderType       string      `json:"order_type"`
-	Quantity        int64       `json:"quantity"`
-	LimitPriceCents pgtype.Int8 `json:"limit_price_cents"`
+	ID                  pgtype.UUID        `json:"id"`
+	TraderID            int64              `json:"trader_id"`
+	StockTicker         string             `json:"stock_ticker"`
+	OrderType           string             `json:"order_type"`
+	Quantity            int64              `json:"quantity"`
+	LimitPriceCents     pgtype.Int8        `json:"limit_price_cents"`
+	TriggerPriceCents   pgtype.Int8        `json:"trigger_price_cents"`
+	TimeInForce         string             `json:"time_in_force"`
+	ExpiresAt           pgtype.Timestamptz `json:"expires_at"`
+	DisplayQuantity     pgtype.Int8        `json:"display_quantity"`
+	TrailingOffsetCents pgtype.Int8        `json:"trailing_offset_cents"`
+	TrailingOffsetBps   pgtype.Int8        `json:"trailing_offset_bps"`
+	ClientOrderID       pgtype.Text        `json:"client_order_id"`
+	SelfTradePrevention pgtype.Text        `json:"self_trade_prevention"`
+	PostOnly            bool               `json:"post_only"`
+	PostOnlyReprice     bool               `json:"post_only_reprice"`
+	QueuedAt            pgtype.Timestamptz `json:"queued_at"`
 }
 
 // Lock shares for sell
@@ -534,6 +972,81 @@ func (q *Queries) HandleSellOrderPlaced(ctx context.Context, arg HandleSellOrder
 		arg.OrderType,
 		arg.Quantity,
 		arg.LimitPriceCents,
+		arg.TriggerPriceCents,
+		arg.TimeInForce,
+		arg.ExpiresAt,
+		arg.DisplayQuantity,
+		arg.TrailingOffsetCents,
+		arg.TrailingOffsetBps,
+		arg.ClientOrderID,
+		arg.SelfTradePrevention,
+		arg.PostOnly,
+		arg.PostOnlyReprice,
+		arg.QueuedAt,
 	)
 	return err
 }
+
+const handleSessionPhaseChanged = `-- name: HandleSessionPhaseChanged :exec
+INSERT INTO market_session_phases (phase, previous_phase, changed_at)
+VALUES ($1, $2, $3)
+`
+
+type HandleSessionPhaseChangedParams struct {
+	Phase         string             `json:"phase"`
+	PreviousPhase string             `json:"previous_phase"`
+	ChangedAt     pgtype.Timestamptz `json:"changed_at"`
+}
+
+func (q *Queries) HandleSessionPhaseChanged(ctx context.Context, arg HandleSessionPhaseChangedParams) error {
+	_, err := q.db.Exec(ctx, handleSessionPhaseChanged, arg.Phase, arg.PreviousPhase, arg.ChangedAt)
+	return err
+}
+
+const handleTradingHalted = `-- name: HandleTradingHalted :exec
+UPDATE stocks
+SET halted_until = $2,
+    updated_at = NOW()
+WHERE ticker = $1
+`
+
+type HandleTradingHaltedParams struct {
+	Ticker      string             `json:"ticker"`
+	HaltedUntil pgtype.Timestamptz `json:"halted_until"`
+}
+
+func (q *Queries) HandleTradingHalted(ctx context.Context, arg HandleTradingHaltedParams) error {
+	_, err := q.db.Exec(ctx, handleTradingHalted, arg.Ticker, arg.HaltedUntil)
+	return err
+}
+
+const handleTradingResumed = `-- name: HandleTradingResumed :exec
+UPDATE stocks
+SET halted_until = NULL,
+    updated_at = NOW()
+WHERE ticker = $1
+`
+
+func (q *Queries) HandleTradingResumed(ctx context.Context, ticker string) error {
+	_, err := q.db.Exec(ctx, handleTradingResumed, ticker)
+	return err
+}
+
+const handleTrailingStopMoved = `-- name: HandleTrailingStopMoved :exec
+UPDATE orders
+SET trigger_price_cents = $2,
+    updated_at = NOW()
+WHERE orders.id = $1
+    AND status = 'PENDING'
+    AND triggered_at IS NULL
+`
+
+type HandleTrailingStopMovedParams struct {
+	ID                pgtype.UUID `json:"id"`
+	TriggerPriceCents pgtype.Int8 `json:"trigger_price_cents"`
+}
+
+func (q *Queries) HandleTrailingStopMoved(ctx context.Context, arg HandleTrailingStopMovedParams) error {
+	_, err := q.db.Exec(ctx, handleTrailingStopMoved, arg.ID, arg.TriggerPriceCents)
+	return err
+}
@@ -18,21 +18,42 @@ type Leaderboard struct {
 	LastUpdated              interface{} `json:"last_updated"`
 }
 
+type MarketSessionPhase struct {
+	ID            int64              `json:"id"`
+	Phase         string             `json:"phase"`
+	PreviousPhase string             `json:"previous_phase"`
+	ChangedAt     pgtype.Timestamptz `json:"changed_at"`
+}
+
 type Order struct {
-	ID                pgtype.UUID        `json:"id"`
-	TraderID          int64              `json:"trader_id"`
-	StockTicker       string             `json:"stock_ticker"`
-	OrderType         string             `json:"order_type"`
-	Side              string             `json:"side"`
-	Quantity          int64              `json:"quantity"`
-	FilledQuantity    pgtype.Int8        `json:"filled_quantity"`
-	RemainingQuantity int64              `json:"remaining_quantity"`
-	LimitPriceCents   pgtype.Int8        `json:"limit_price_cents"`
-	Status            pgtype.Text        `json:"status"`
-	CreatedAt         pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
-	FilledAt          pgtype.Timestamptz `json:"filled_at"`
-	CancelledAt       pgtype.Timestamptz `json:"cancelled_at"`
+	ID                  pgtype.UUID        `json:"id"`
+	TraderID            int64              `json:"trader_id"`
+	StockTicker         string             `json:"stock_ticker"`
+	OrderType           string             `json:"order_type"`
+	Side                string             `json:"side"`
+	Quantity            int64              `json:"quantity"`
+	FilledQuantity      pgtype.Int8        `json:"filled_quantity"`
+	RemainingQuantity   int64              `json:"remaining_quantity"`
+	LimitPriceCents     pgtype.Int8        `json:"limit_price_cents"`
+	Status              pgtype.Text        `json:"status"`
+	CreatedAt           pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt           pgtype.Timestamptz `json:"updated_at"`
+	FilledAt            pgtype.Timestamptz `json:"filled_at"`
+	CancelledAt         pgtype.Timestamptz `json:"cancelled_at"`
+	TriggerPriceCents   pgtype.Int8        `json:"trigger_price_cents"`
+	TriggeredAt         pgtype.Timestamptz `json:"triggered_at"`
+	TimeInForce         string             `json:"time_in_force"`
+	ExpiresAt           pgtype.Timestamptz `json:"expires_at"`
+	CancelReason        pgtype.Text        `json:"cancel_reason"`
+	DisplayQuantity     pgtype.Int8        `json:"display_quantity"`
+	TrailingOffsetCents pgtype.Int8        `json:"trailing_offset_cents"`
+	TrailingOffsetBps   pgtype.Int8        `json:"trailing_offset_bps"`
+	ClientOrderID       pgtype.Text        `json:"client_order_id"`
+	CashReservedCents   int64              `json:"cash_reserved_cents"`
+	SelfTradePrevention pgtype.Text        `json:"self_trade_prevention"`
+	PostOnly            bool               `json:"post_only"`
+	PostOnlyReprice     bool               `json:"post_only_reprice"`
+	QueuedAt            pgtype.Timestamptz `json:"queued_at"`
 }
 
 type Position struct {
@@ -68,6 +89,19 @@ type Price1min struct {
 	TradeCount  int64       `json:"trade_count"`
 }
 
+type SelfTradePrevention struct {
+	ID               int64              `json:"id"`
+	StockTicker      string             `json:"stock_ticker"`
+	IncomingOrderID  pgtype.UUID        `json:"incoming_order_id"`
+	RestingOrderID   pgtype.UUID        `json:"resting_order_id"`
+	IncomingTraderID int64              `json:"incoming_trader_id"`
+	RestingTraderID  int64              `json:"resting_trader_id"`
+	Mode             string             `json:"mode"`
+	Quantity         int64              `json:"quantity"`
+	PriceCents       int64              `json:"price_cents"`
+	PreventedAt      pgtype.Timestamptz `json:"prevented_at"`
+}
+
 type Stock struct {
 	Ticker             string             `json:"ticker"`
 	CompanyName        string             `json:"company_name"`
@@ -79,6 +113,10 @@ type Stock struct {
 	IsActive           pgtype.Bool        `json:"is_active"`
 	CreatedAt          pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+	HaltedUntil        pgtype.Timestamptz `json:"halted_until"`
+	TickSizeCents      int64              `json:"tick_size_cents"`
+	LotSize            int64              `json:"lot_size"`
+	MinQuantity        int64              `json:"min_quantity"`
 }
 
 type Trade struct {
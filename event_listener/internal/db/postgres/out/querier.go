@@ -11,8 +11,11 @@ import (
 )
 
 type Querier interface {
+	HandleClosingAuction(ctx context.Context, arg HandleClosingAuctionParams) error
+	// Move the cash hold difference between the old and new remaining order
+	HandleLimitBuyOrderAmended(ctx context.Context, arg HandleLimitBuyOrderAmendedParams) error
 	// Release cash hold for limit buy
-	HandleLimitBuyOrderCancelled(ctx context.Context, id pgtype.UUID) error
+	HandleLimitBuyOrderCancelled(ctx context.Context, arg HandleLimitBuyOrderCancelledParams) error
 	// Lock cash at limit price
 	HandleLimitBuyOrderPlaced(ctx context.Context, arg HandleLimitBuyOrderPlacedParams) error
 	// Get buyer's limit price for hold release calculation
@@ -24,9 +27,12 @@ type Querier interface {
 	// Update buyer's portfolio value
 	// Update seller's portfolio value
 	HandleLimitBuyTradeExecuted(ctx context.Context, arg HandleLimitBuyTradeExecutedParams) error
-	HandleMarketBuyOrderCancelled(ctx context.Context, id pgtype.UUID) error
+	// Release what is left of a stop-market or trailing-stop buy's cash reserve
+	HandleMarketBuyOrderCancelled(ctx context.Context, arg HandleMarketBuyOrderCancelledParams) error
+	// Hold the cash reserved for a stop-market or trailing-stop buy, 0 for market buys
 	HandleMarketBuyOrderPlaced(ctx context.Context, arg HandleMarketBuyOrderPlacedParams) error
-	// Deduct cash directly from buyer's balance (no hold exists)
+	// Part of the trade paid from the order's cash reserve; market buys have none
+	// Pay from the reserve's hold, and the rest directly from the buyer's balance
 	// Add shares to buyer's position
 	// Release seller's share hold
 	// Add cash to seller
@@ -34,13 +40,24 @@ type Querier interface {
 	// Update buyer's portfolio value
 	// Update seller's portfolio value
 	HandleMarketBuyTradeExecuted(ctx context.Context, arg HandleMarketBuyTradeExecutedParams) error
+	// Return cash a filled stop-market or trailing-stop buy didn't spend
 	HandleOrderFilled(ctx context.Context, id pgtype.UUID) error
 	HandleOrderPartiallyFilled(ctx context.Context, arg HandleOrderPartiallyFilledParams) error
 	HandleOrderRejected(ctx context.Context, arg HandleOrderRejectedParams) error
+	HandleOrderTriggered(ctx context.Context, arg HandleOrderTriggeredParams) error
+	// Move the difference between the old and new reserve of a held stop-market or trailing-stop buy
+	HandleReservedBuyOrderAmended(ctx context.Context, arg HandleReservedBuyOrderAmendedParams) error
+	HandleSelfTradePrevented(ctx context.Context, arg HandleSelfTradePreventedParams) error
+	// Move the share hold difference between the old and new remaining order
+	HandleSellOrderAmended(ctx context.Context, arg HandleSellOrderAmendedParams) error
 	// Release share hold for sell orders
-	HandleSellOrderCancelled(ctx context.Context, id pgtype.UUID) error
+	HandleSellOrderCancelled(ctx context.Context, arg HandleSellOrderCancelledParams) error
 	// Lock shares for sell
 	HandleSellOrderPlaced(ctx context.Context, arg HandleSellOrderPlacedParams) error
+	HandleSessionPhaseChanged(ctx context.Context, arg HandleSessionPhaseChangedParams) error
+	HandleTradingHalted(ctx context.Context, arg HandleTradingHaltedParams) error
+	HandleTradingResumed(ctx context.Context, ticker string) error
+	HandleTrailingStopMoved(ctx context.Context, arg HandleTrailingStopMovedParams) error
 }
 
 var _ Querier = (*Queries)(nil)
@@ -26,6 +26,12 @@ func orderTypeToString(t streamtypes.OrderType) string {
 		return "MARKET"
 	case streamtypes.LimitOrder:
 		return "LIMIT"
+	case streamtypes.StopMarketOrder:
+		return "STOP_MARKET"
+	case streamtypes.StopLimitOrder:
+		return "STOP_LIMIT"
+	case streamtypes.TrailingStopOrder:
+		return "TRAILING_STOP"
 	default:
 		return ""
 	}
@@ -42,6 +48,71 @@ func orderSideToString(s streamtypes.OrderSide) string {
 	}
 }
 
+func timeInForceToString(t streamtypes.TimeInForce) string {
+	switch t {
+	case streamtypes.ImmediateOrCancel:
+		return "IOC"
+	case streamtypes.FillOrKill:
+		return "FOK"
+	case streamtypes.Day:
+		return "DAY"
+	case streamtypes.GoodTillDate:
+		return "GTD"
+	default:
+		return "GTC"
+	}
+}
+
+func selfTradePreventionToString(m streamtypes.SelfTradePrevention) string {
+	switch m {
+	case streamtypes.SelfTradeAllow:
+		return "ALLOW"
+	case streamtypes.SelfTradeCancelOldest:
+		return "CANCEL_OLDEST"
+	case streamtypes.SelfTradeCancelBoth:
+		return "CANCEL_BOTH"
+	case streamtypes.SelfTradeDecrement:
+		return "DECREMENT"
+	default:
+		return "CANCEL_NEWEST"
+	}
+}
+
+// orderSelfTradePrevention returns an order's own self-trade prevention mode, or NULL when it
+// uses the engine's
+func orderSelfTradePrevention(m streamtypes.SelfTradePrevention) pgtype.Text {
+	if m == streamtypes.SelfTradeDefault {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: selfTradePreventionToString(m), Valid: true}
+}
+
+// expiresAt returns the expiry for DAY/GTD orders and NULL otherwise
+func expiresAt(t time.Time) pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: t, Valid: !t.IsZero()}
+}
+
+// hasLimitPrice reports whether the order type carries a limit price (and so a cash hold for buys)
+func hasLimitPrice(t streamtypes.OrderType) bool {
+	return t == streamtypes.LimitOrder || t == streamtypes.StopLimitOrder
+}
+
+// reservesCash reports whether a buy of the order type holds a cash reserve while it waits for its trigger
+func reservesCash(t streamtypes.OrderType) bool {
+	return t == streamtypes.StopMarketOrder || t == streamtypes.TrailingStopOrder
+}
+
+// triggerPrice returns the trigger price for stop orders and NULL otherwise
+func triggerPrice(t streamtypes.OrderType, cents int64) pgtype.Int8 {
+	isStop := t == streamtypes.StopMarketOrder || t == streamtypes.StopLimitOrder || t == streamtypes.TrailingStopOrder
+	return pgtype.Int8{Int64: cents, Valid: isStop}
+}
+
+// positive returns v, or NULL for optional order fields left at 0
+func positive(v int64) pgtype.Int8 {
+	return pgtype.Int8{Int64: v, Valid: v > 0}
+}
+
 func orderIDToUUID(orderID string) (pgtype.UUID, error) {
 	orderUUID, err := uuid.Parse(orderID)
 	if err != nil {
@@ -62,36 +133,68 @@ func (p *PostgresDB) InsertEvent(ctx context.Context, eventID string, timestamp
 			return err
 		}
 
-		// Route to appropriate handler based on order type and side
-		if ev.OrderType == streamtypes.LimitOrder && ev.OrderSide == streamtypes.Buy {
+		// Route to appropriate handler based on order type and side.
+		// Stop-limit buys hold cash like limit buys; stop-market and trailing-stop buys hold the cash reserved for them.
+		if hasLimitPrice(ev.OrderType) && ev.OrderSide == streamtypes.Buy {
 			params := db.HandleLimitBuyOrderPlacedParams{
-				ID:              orderUUID,
-				TraderID:        ev.TraderID,
-				StockTicker:     ev.StockTicker,
-				Quantity:        ev.Quantity,
-				LimitPriceCents: pgtype.Int8{Int64: ev.LimitPriceCents, Valid: true},
+				ID:                  orderUUID,
+				TraderID:            ev.TraderID,
+				StockTicker:         ev.StockTicker,
+				OrderType:           orderTypeToString(ev.OrderType),
+				Quantity:            ev.Quantity,
+				LimitPriceCents:     pgtype.Int8{Int64: ev.LimitPriceCents, Valid: true},
+				TriggerPriceCents:   triggerPrice(ev.OrderType, ev.TriggerPriceCents),
+				TimeInForce:         timeInForceToString(ev.TimeInForce),
+				ExpiresAt:           expiresAt(ev.ExpiresAt),
+				DisplayQuantity:     positive(ev.DisplayQuantity),
+				ClientOrderID:       pgtype.Text{String: ev.ClientOrderID, Valid: ev.ClientOrderID != ""},
+				SelfTradePrevention: orderSelfTradePrevention(ev.SelfTrade),
+				PostOnly:            ev.PostOnly,
+				PostOnlyReprice:     ev.PostOnlyReprice,
+				QueuedAt:            pgtype.Timestamptz{Time: timestamp, Valid: true},
 			}
 			if err = p.db.HandleLimitBuyOrderPlaced(ctx, params); err != nil {
 				return fmt.Errorf("failed to handle limit buy order placed: %w", err)
 			}
-		} else if ev.OrderType == streamtypes.MarketOrder && ev.OrderSide == streamtypes.Buy {
+		} else if ev.OrderSide == streamtypes.Buy {
 			params := db.HandleMarketBuyOrderPlacedParams{
-				ID:          orderUUID,
-				TraderID:    ev.TraderID,
-				StockTicker: ev.StockTicker,
-				Quantity:    ev.Quantity,
+				ID:                  orderUUID,
+				TraderID:            ev.TraderID,
+				StockTicker:         ev.StockTicker,
+				OrderType:           orderTypeToString(ev.OrderType),
+				Quantity:            ev.Quantity,
+				TriggerPriceCents:   triggerPrice(ev.OrderType, ev.TriggerPriceCents),
+				TimeInForce:         timeInForceToString(ev.TimeInForce),
+				ExpiresAt:           expiresAt(ev.ExpiresAt),
+				TrailingOffsetCents: positive(ev.TrailingOffset),
+				TrailingOffsetBps:   positive(ev.TrailingBps),
+				ClientOrderID:       pgtype.Text{String: ev.ClientOrderID, Valid: ev.ClientOrderID != ""},
+				CashReservedCents:   ev.ReservedCash,
+				SelfTradePrevention: orderSelfTradePrevention(ev.SelfTrade),
+				QueuedAt:            pgtype.Timestamptz{Time: timestamp, Valid: true},
 			}
 			if err = p.db.HandleMarketBuyOrderPlaced(ctx, params); err != nil {
 				return fmt.Errorf("failed to handle market buy order placed: %w", err)
 			}
 		} else if ev.OrderSide == streamtypes.Sell {
 			params := db.HandleSellOrderPlacedParams{
-				ID:              orderUUID,
-				TraderID:        ev.TraderID,
-				StockTicker:     ev.StockTicker,
-				OrderType:       orderTypeToString(ev.OrderType),
-				Quantity:        ev.Quantity,
-				LimitPriceCents: pgtype.Int8{Int64: ev.LimitPriceCents, Valid: ev.OrderType == streamtypes.LimitOrder},
+				ID:                  orderUUID,
+				TraderID:            ev.TraderID,
+				StockTicker:         ev.StockTicker,
+				OrderType:           orderTypeToString(ev.OrderType),
+				Quantity:            ev.Quantity,
+				LimitPriceCents:     pgtype.Int8{Int64: ev.LimitPriceCents, Valid: hasLimitPrice(ev.OrderType)},
+				TriggerPriceCents:   triggerPrice(ev.OrderType, ev.TriggerPriceCents),
+				TimeInForce:         timeInForceToString(ev.TimeInForce),
+				ExpiresAt:           expiresAt(ev.ExpiresAt),
+				DisplayQuantity:     positive(ev.DisplayQuantity),
+				TrailingOffsetCents: positive(ev.TrailingOffset),
+				TrailingOffsetBps:   positive(ev.TrailingBps),
+				ClientOrderID:       pgtype.Text{String: ev.ClientOrderID, Valid: ev.ClientOrderID != ""},
+				SelfTradePrevention: orderSelfTradePrevention(ev.SelfTrade),
+				PostOnly:            ev.PostOnly,
+				PostOnlyReprice:     ev.PostOnlyReprice,
+				QueuedAt:            pgtype.Timestamptz{Time: timestamp, Valid: true},
 			}
 			if err = p.db.HandleSellOrderPlaced(ctx, params); err != nil {
 				return fmt.Errorf("failed to handle sell order placed: %w", err)
@@ -109,22 +212,197 @@ func (p *PostgresDB) InsertEvent(ctx context.Context, eventID string, timestamp
 			return err
 		}
 
+		reason := pgtype.Text{String: string(ev.Reason), Valid: ev.Reason != ""}
+
 		// Route to appropriate handler based on order type and side
-		if ev.OrderType == streamtypes.LimitOrder && ev.OrderSide == streamtypes.Buy {
-			if err = p.db.HandleLimitBuyOrderCancelled(ctx, orderUUID); err != nil {
+		if hasLimitPrice(ev.OrderType) && ev.OrderSide == streamtypes.Buy {
+			params := db.HandleLimitBuyOrderCancelledParams{ID: orderUUID, CancelReason: reason}
+			if err = p.db.HandleLimitBuyOrderCancelled(ctx, params); err != nil {
 				return fmt.Errorf("failed to handle limit buy order cancelled: %w", err)
 			}
-		} else if ev.OrderType == streamtypes.MarketOrder && ev.OrderSide == streamtypes.Buy {
-			if err = p.db.HandleMarketBuyOrderCancelled(ctx, orderUUID); err != nil {
+		} else if ev.OrderSide == streamtypes.Buy {
+			params := db.HandleMarketBuyOrderCancelledParams{ID: orderUUID, CancelReason: reason}
+			if err = p.db.HandleMarketBuyOrderCancelled(ctx, params); err != nil {
 				return fmt.Errorf("failed to handle market buy order cancelled: %w", err)
 			}
 		} else if ev.OrderSide == streamtypes.Sell {
-			if err = p.db.HandleSellOrderCancelled(ctx, orderUUID); err != nil {
+			params := db.HandleSellOrderCancelledParams{ID: orderUUID, CancelReason: reason}
+			if err = p.db.HandleSellOrderCancelled(ctx, params); err != nil {
 				return fmt.Errorf("failed to handle sell order cancelled: %w", err)
 			}
 		}
 		return nil
 
+	case streamtypes.OrderTriggered:
+		ev, ok := payload.(*streamtypes.OrderTriggeredEvent)
+		if !ok {
+			return errors.New("invalid payload type for OrderTriggered event")
+		}
+		orderUUID, err := orderIDToUUID(ev.OrderID)
+		if err != nil {
+			return err
+		}
+		// A triggered stop joins the back of the queue now, whenever it was placed
+		params := db.HandleOrderTriggeredParams{
+			ID:                orderUUID,
+			TriggerPriceCents: pgtype.Int8{Int64: ev.TriggerPriceCents, Valid: true},
+			QueuedAt:          pgtype.Timestamptz{Time: timestamp, Valid: true},
+		}
+		if err = p.db.HandleOrderTriggered(ctx, params); err != nil {
+			return fmt.Errorf("failed to handle order triggered: %w", err)
+		}
+		return nil
+
+	case streamtypes.TrailingStopMoved:
+		ev, ok := payload.(*streamtypes.TrailingStopMovedEvent)
+		if !ok {
+			return errors.New("invalid payload type for TrailingStopMoved event")
+		}
+		orderUUID, err := orderIDToUUID(ev.OrderID)
+		if err != nil {
+			return err
+		}
+		params := db.HandleTrailingStopMovedParams{
+			ID:                orderUUID,
+			TriggerPriceCents: pgtype.Int8{Int64: ev.TriggerPriceCents, Valid: true},
+		}
+		if err = p.db.HandleTrailingStopMoved(ctx, params); err != nil {
+			return fmt.Errorf("failed to handle trailing stop moved: %w", err)
+		}
+		return nil
+
+	case streamtypes.OrderAmended:
+		ev, ok := payload.(*streamtypes.OrderAmendedEvent)
+		if !ok {
+			return errors.New("invalid payload type for OrderAmended event")
+		}
+		orderUUID, err := orderIDToUUID(ev.OrderID)
+		if err != nil {
+			return err
+		}
+
+		// Holds are adjusted by the difference between the old and new remaining order.
+		// A re-queued order loses its time priority; a reduced one keeps it.
+		queuedAt := pgtype.Timestamptz{Time: timestamp, Valid: ev.Requeued}
+		if ev.OrderSide == streamtypes.Buy && reservesCash(ev.OrderType) {
+			params := db.HandleReservedBuyOrderAmendedParams{
+				ID:                orderUUID,
+				RemainingQuantity: ev.NewQuantity,
+				CashReservedCents: ev.ReservedCash,
+			}
+			if err = p.db.HandleReservedBuyOrderAmended(ctx, params); err != nil {
+				return fmt.Errorf("failed to handle reserved buy order amended: %w", err)
+			}
+		} else if ev.OrderSide == streamtypes.Buy {
+			params := db.HandleLimitBuyOrderAmendedParams{
+				ID:                orderUUID,
+				RemainingQuantity: ev.NewQuantity,
+				LimitPriceCents:   pgtype.Int8{Int64: ev.NewLimitPriceCents, Valid: true},
+				QueuedAt:          queuedAt,
+			}
+			if err = p.db.HandleLimitBuyOrderAmended(ctx, params); err != nil {
+				return fmt.Errorf("failed to handle limit buy order amended: %w", err)
+			}
+		} else {
+			params := db.HandleSellOrderAmendedParams{
+				ID:                orderUUID,
+				RemainingQuantity: ev.NewQuantity,
+				LimitPriceCents:   pgtype.Int8{Int64: ev.NewLimitPriceCents, Valid: true},
+				QueuedAt:          queuedAt,
+			}
+			if err = p.db.HandleSellOrderAmended(ctx, params); err != nil {
+				return fmt.Errorf("failed to handle sell order amended: %w", err)
+			}
+		}
+		return nil
+
+	case streamtypes.SelfTradePrevented:
+		ev, ok := payload.(*streamtypes.SelfTradePreventedEvent)
+		if !ok {
+			return errors.New("invalid payload type for SelfTradePrevented event")
+		}
+		incomingOrderUUID, err := orderIDToUUID(ev.IncomingOrderID)
+		if err != nil {
+			return err
+		}
+		restingOrderUUID, err := orderIDToUUID(ev.RestingOrderID)
+		if err != nil {
+			return err
+		}
+
+		// Cancellations and decrements arrive as their own events; this only records the attempt
+		params := db.HandleSelfTradePreventedParams{
+			StockTicker:      ev.StockTicker,
+			IncomingOrderID:  incomingOrderUUID,
+			RestingOrderID:   restingOrderUUID,
+			IncomingTraderID: ev.IncomingTraderID,
+			RestingTraderID:  ev.RestingTraderID,
+			Mode:             selfTradePreventionToString(ev.Mode),
+			Quantity:         ev.Quantity,
+			PriceCents:       ev.PriceCents,
+		}
+		if err = p.db.HandleSelfTradePrevented(ctx, params); err != nil {
+			return fmt.Errorf("failed to handle self-trade prevented: %w", err)
+		}
+		return nil
+
+	case streamtypes.AuctionUncrossed:
+		ev, ok := payload.(*streamtypes.AuctionUncrossedEvent)
+		if !ok {
+			return errors.New("invalid payload type for AuctionUncrossed event")
+		}
+		// Auction executions arrive as trade events; only the close price is kept here
+		if !ev.Closing || ev.PriceCents <= 0 {
+			return nil
+		}
+		params := db.HandleClosingAuctionParams{
+			Ticker:             ev.StockTicker,
+			PreviousCloseCents: positive(ev.PriceCents),
+		}
+		if err := p.db.HandleClosingAuction(ctx, params); err != nil {
+			return fmt.Errorf("failed to handle closing auction: %w", err)
+		}
+		return nil
+
+	case streamtypes.TradingHalted:
+		ev, ok := payload.(*streamtypes.TradingHaltedEvent)
+		if !ok {
+			return errors.New("invalid payload type for TradingHalted event")
+		}
+		params := db.HandleTradingHaltedParams{
+			Ticker:      ev.StockTicker,
+			HaltedUntil: pgtype.Timestamptz{Time: ev.HaltedUntil, Valid: true},
+		}
+		if err := p.db.HandleTradingHalted(ctx, params); err != nil {
+			return fmt.Errorf("failed to handle trading halted: %w", err)
+		}
+		return nil
+
+	case streamtypes.TradingResumed:
+		ev, ok := payload.(*streamtypes.TradingResumedEvent)
+		if !ok {
+			return errors.New("invalid payload type for TradingResumed event")
+		}
+		if err := p.db.HandleTradingResumed(ctx, ev.StockTicker); err != nil {
+			return fmt.Errorf("failed to handle trading resumed: %w", err)
+		}
+		return nil
+
+	case streamtypes.SessionPhaseChanged:
+		ev, ok := payload.(*streamtypes.SessionPhaseChangedEvent)
+		if !ok {
+			return errors.New("invalid payload type for SessionPhaseChanged event")
+		}
+		params := db.HandleSessionPhaseChangedParams{
+			Phase:         string(ev.Phase),
+			PreviousPhase: string(ev.PreviousPhase),
+			ChangedAt:     pgtype.Timestamptz{Time: ev.ChangedAt, Valid: true},
+		}
+		if err := p.db.HandleSessionPhaseChanged(ctx, params); err != nil {
+			return fmt.Errorf("failed to handle session phase changed: %w", err)
+		}
+		return nil
+
 	case streamtypes.OrderFilled:
 		ev, ok := payload.(*streamtypes.OrderFilledEvent)
 		if !ok {
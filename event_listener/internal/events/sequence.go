@@ -0,0 +1,70 @@
+package events
+
+import streamtypes "github.com/Marwan051/tradding_platform_game/event_listener/internal/stream_types"
+
+// SequenceTracker follows the engine's event sequence numbers to spot events that were lost
+// or delivered twice. The first event seen sets the starting point. Every run of the engine
+// stamps its events with its own epoch, and a new epoch means the engine restarted, so the
+// counters start over from that event. Events from engines without an epoch are taken as a
+// restart when the engine-wide sequence is 1 or goes backwards.
+//
+// Sequence numbers only inform: a check never decides whether an event is applied.
+type SequenceTracker struct {
+	epoch     string
+	last      int64
+	lastStock map[string]int64
+}
+
+// SequenceCheck is the outcome of tracking one event
+type SequenceCheck struct {
+	Restarted    bool  // The engine restarted since the previous event; counting starts over here
+	Duplicate    bool  // Numbered at or below an event already seen from the same run
+	Missing      int64 // Engine-wide events skipped right before this one
+	StockMissing int64 // Events of the same stock skipped right before this one
+}
+
+func NewSequenceTracker() *SequenceTracker {
+	return &SequenceTracker{lastStock: make(map[string]int64)}
+}
+
+// Track records an event's sequence numbers and reports any restart, gap or repeat.
+// Events without a sequence number are never flagged.
+func (t *SequenceTracker) Track(event *streamtypes.Event) SequenceCheck {
+	var check SequenceCheck
+	if event.Sequence == 0 {
+		return check
+	}
+	if t.restarted(event) {
+		check.Restarted = t.last > 0
+		t.epoch = event.Epoch
+		t.last = 0
+		clear(t.lastStock)
+	}
+	if t.last > 0 && event.Sequence <= t.last {
+		check.Duplicate = true
+		return check
+	}
+
+	if t.last > 0 {
+		check.Missing = event.Sequence - t.last - 1
+	}
+	t.last = event.Sequence
+
+	if event.StockTicker != "" {
+		if last, ok := t.lastStock[event.StockTicker]; ok {
+			check.StockMissing = max(event.StockSequence-last-1, 0)
+		}
+		t.lastStock[event.StockTicker] = event.StockSequence
+	}
+	return check
+}
+
+// restarted reports whether an event comes from a different run of the engine than the last one
+func (t *SequenceTracker) restarted(event *streamtypes.Event) bool {
+	if event.Epoch != "" || t.epoch != "" {
+		return event.Epoch != t.epoch
+	}
+	// Without an epoch a restart whose first events never arrived still shows as the
+	// sequence going backwards
+	return event.Sequence == 1 || event.Sequence < t.last
+}
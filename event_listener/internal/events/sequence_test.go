@@ -0,0 +1,77 @@
+package events
+
+import (
+	"testing"
+
+	streamtypes "github.com/Marwan051/tradding_platform_game/event_listener/internal/stream_types"
+)
+
+func event(epoch string, sequence int64, stock string, stockSequence int64) *streamtypes.Event {
+	return &streamtypes.Event{Epoch: epoch, Sequence: sequence, StockTicker: stock, StockSequence: stockSequence}
+}
+
+func TestSequenceTracker(t *testing.T) {
+	t.Run("should report events missing engine-wide and per stock", func(t *testing.T) {
+		tracker := NewSequenceTracker()
+		tracker.Track(event("run1", 1, "AAPL", 1))
+		tracker.Track(event("run1", 2, "MSFT", 1))
+
+		check := tracker.Track(event("run1", 5, "AAPL", 3))
+		if check.Missing != 2 || check.StockMissing != 1 || check.Duplicate || check.Restarted {
+			t.Errorf("expected 2 missing engine-wide and 1 for AAPL, got %+v", check)
+		}
+	})
+
+	t.Run("should flag events repeated within a run", func(t *testing.T) {
+		tracker := NewSequenceTracker()
+		tracker.Track(event("run1", 1, "AAPL", 1))
+		tracker.Track(event("run1", 2, "AAPL", 2))
+
+		if check := tracker.Track(event("run1", 2, "AAPL", 2)); !check.Duplicate {
+			t.Errorf("expected a duplicate, got %+v", check)
+		}
+		if check := tracker.Track(event("run1", 3, "AAPL", 3)); check.Duplicate || check.Missing != 0 {
+			t.Errorf("expected the next event to follow on, got %+v", check)
+		}
+	})
+
+	t.Run("should start over when the epoch changes", func(t *testing.T) {
+		tracker := NewSequenceTracker()
+		for i := int64(1); i <= 10; i++ {
+			tracker.Track(event("run1", i, "AAPL", i))
+		}
+
+		check := tracker.Track(event("run2", 11, "AAPL", 11))
+		if !check.Restarted || check.Duplicate || check.Missing != 0 {
+			t.Errorf("expected a restart, got %+v", check)
+		}
+	})
+
+	t.Run("should not take a restart whose first event is lost for duplicates", func(t *testing.T) {
+		for _, epochs := range [][2]string{{"run1", "run2"}, {"", ""}} {
+			tracker := NewSequenceTracker()
+			for i := int64(1); i <= 10; i++ {
+				tracker.Track(event(epochs[0], i, "AAPL", i))
+			}
+
+			// The restarted engine's event 1 never reached the stream
+			check := tracker.Track(event(epochs[1], 2, "AAPL", 2))
+			if !check.Restarted || check.Duplicate {
+				t.Errorf("epoch %q: expected a restart, got %+v", epochs[1], check)
+			}
+			check = tracker.Track(event(epochs[1], 3, "MSFT", 1))
+			if check.Restarted || check.Duplicate || check.Missing != 0 {
+				t.Errorf("epoch %q: expected the run to continue, got %+v", epochs[1], check)
+			}
+		}
+	})
+
+	t.Run("should ignore events without a sequence number", func(t *testing.T) {
+		tracker := NewSequenceTracker()
+		tracker.Track(event("run1", 5, "", 0))
+
+		if check := tracker.Track(event("", 0, "", 0)); check != (SequenceCheck{}) {
+			t.Errorf("expected no findings, got %+v", check)
+		}
+	})
+}
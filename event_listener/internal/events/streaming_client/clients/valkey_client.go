@@ -21,6 +21,7 @@ type ValkeyClient struct {
 	blockTime  time.Duration
 	batchSize  int64
 	db         db.Database
+	sequences  *events.SequenceTracker
 }
 
 func NewValkeyClient(host string, port int, streamName string, db db.Database, logger *slog.Logger) (*ValkeyClient, error) {
@@ -42,6 +43,7 @@ func NewValkeyClient(host string, port int, streamName string, db db.Database, l
 		blockTime:  5 * time.Second, // Block for 5s waiting for new events
 		batchSize:  100,             // Read up to 100 events per batch
 		db:         db,
+		sequences:  events.NewSequenceTracker(),
 	}, nil
 }
 
@@ -139,6 +141,31 @@ func (vc *ValkeyClient) processEntry(ctx context.Context, entryID string, data s
 		slog.Int("event_type", int(baseEvent.Type)),
 	)
 
+	// Sequence numbers are only logged: an event is applied whatever its number says
+	check := vc.sequences.Track(baseEvent)
+	if check.Restarted {
+		vc.logger.Info("engine restarted, sequence tracking starts over",
+			slog.String("epoch", baseEvent.Epoch),
+			slog.Int64("sequence", baseEvent.Sequence),
+		)
+	}
+	if check.Duplicate {
+		vc.logger.Warn("event repeats a sequence number already seen",
+			slog.String("event_id", baseEvent.EventID),
+			slog.String("epoch", baseEvent.Epoch),
+			slog.Int64("sequence", baseEvent.Sequence),
+		)
+	}
+	if check.Missing > 0 || check.StockMissing > 0 {
+		vc.logger.Warn("events missing from stream",
+			slog.Int64("sequence", baseEvent.Sequence),
+			slog.Int64("missing", check.Missing),
+			slog.String("stock_ticker", baseEvent.StockTicker),
+			slog.Int64("stock_sequence", baseEvent.StockSequence),
+			slog.Int64("stock_missing", check.StockMissing),
+		)
+	}
+
 	if err := vc.db.InsertEvent(ctx, baseEvent.EventID, baseEvent.Timestamp, baseEvent.Type, payload); err != nil {
 		return fmt.Errorf("db insert failed: %w", err)
 	}
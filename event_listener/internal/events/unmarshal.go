@@ -28,6 +28,22 @@ func UnmarshalStreamEvent(data []byte) (*streamtypes.Event, streamtypes.EventPay
 		payload = &streamtypes.OrderRejectedEvent{}
 	case streamtypes.TradeExecuted:
 		payload = &streamtypes.TradeExecutedEvent{}
+	case streamtypes.OrderTriggered:
+		payload = &streamtypes.OrderTriggeredEvent{}
+	case streamtypes.OrderAmended:
+		payload = &streamtypes.OrderAmendedEvent{}
+	case streamtypes.SelfTradePrevented:
+		payload = &streamtypes.SelfTradePreventedEvent{}
+	case streamtypes.AuctionUncrossed:
+		payload = &streamtypes.AuctionUncrossedEvent{}
+	case streamtypes.TradingHalted:
+		payload = &streamtypes.TradingHaltedEvent{}
+	case streamtypes.TradingResumed:
+		payload = &streamtypes.TradingResumedEvent{}
+	case streamtypes.SessionPhaseChanged:
+		payload = &streamtypes.SessionPhaseChangedEvent{}
+	case streamtypes.TrailingStopMoved:
+		payload = &streamtypes.TrailingStopMovedEvent{}
 	default:
 		return &baseEvent, nil, fmt.Errorf("unknown event type: %d", baseEvent.Type)
 	}
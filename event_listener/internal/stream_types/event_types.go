@@ -14,32 +14,67 @@ const (
 	OrderPartiallyFilled
 	OrderRejected
 	TradeExecuted
+	OrderTriggered
+	OrderAmended
+	SelfTradePrevented
+	AuctionUncrossed
+	TradingHalted
+	TradingResumed
+	SessionPhaseChanged
+	TrailingStopMoved
+)
+
+// CancelReason - Why an order left the engine without being fully filled
+type CancelReason string
+
+const (
+	CancelReasonUserRequested     CancelReason = "USER_REQUESTED"
+	CancelReasonImmediateOrCancel CancelReason = "IMMEDIATE_OR_CANCEL"
+	CancelReasonFillOrKill        CancelReason = "FILL_OR_KILL"
+	CancelReasonExpired           CancelReason = "EXPIRED"
+	CancelReasonSelfTrade         CancelReason = "SELF_TRADE_PREVENTION"
 )
 
 type Event struct {
-	EventID   string          `json:"event_id"`
-	Timestamp time.Time       `json:"timestamp"`
-	Type      EventType       `json:"type"`
-	Data      json.RawMessage `json:"data"`
+	EventID       string          `json:"event_id"`
+	Timestamp     time.Time       `json:"timestamp"`
+	Type          EventType       `json:"type"`
+	Epoch         string          `json:"epoch"`          // Run of the engine that published the event
+	Sequence      int64           `json:"sequence"`       // Engine-wide, gap-free
+	StockTicker   string          `json:"stock_ticker"`   // Empty for events not tied to a book
+	StockSequence int64           `json:"stock_sequence"` // Per stock, gap-free
+	Data          json.RawMessage `json:"data"`
 }
 
 type OrderPlacedEvent struct {
-	OrderID         string    `json:"order_id"`
-	TraderID        int64     `json:"trader_id"`
-	StockTicker     string    `json:"stock_ticker"`
-	OrderType       OrderType `json:"order_type"`
-	OrderSide       OrderSide `json:"order_side"`
-	Quantity        int64     `json:"quantity"`
-	LimitPriceCents int64     `json:"limit_price_cents"`
+	OrderID           string              `json:"order_id"`
+	TraderID          int64               `json:"trader_id"`
+	StockTicker       string              `json:"stock_ticker"`
+	OrderType         OrderType           `json:"order_type"`
+	OrderSide         OrderSide           `json:"order_side"`
+	Quantity          int64               `json:"quantity"`
+	LimitPriceCents   int64               `json:"limit_price_cents"`
+	TriggerPriceCents int64               `json:"trigger_price_cents"`
+	TimeInForce       TimeInForce         `json:"time_in_force"`
+	ExpiresAt         time.Time           `json:"expires_at"`
+	DisplayQuantity   int64               `json:"display_quantity"`
+	TrailingOffset    int64               `json:"trailing_offset_cents"`
+	TrailingBps       int64               `json:"trailing_offset_bps"`
+	ClientOrderID     string              `json:"client_order_id,omitempty"`       // The trader's own ID for the order, if given
+	ReservedCash      int64               `json:"reserved_cash_cents,omitempty"`   // Cash a stop-market or trailing-stop buy may spend once triggered
+	SelfTrade         SelfTradePrevention `json:"self_trade_prevention,omitempty"` // The order's own mode, if it overrides the engine's
+	PostOnly          bool                `json:"post_only,omitempty"`
+	PostOnlyReprice   bool                `json:"post_only_reprice,omitempty"`
 }
 
 type OrderCancelledEvent struct {
-	OrderID           string    `json:"order_id"`
-	TraderID          int64     `json:"trader_id"`
-	OrderType         OrderType `json:"order_type"`
-	OrderSide         OrderSide `json:"order_side"`
-	StockTicker       string    `json:"stock_ticker"`
-	RemainingQuantity int64     `json:"remaining_quantity"`
+	OrderID           string       `json:"order_id"`
+	TraderID          int64        `json:"trader_id"`
+	OrderType         OrderType    `json:"order_type"`
+	OrderSide         OrderSide    `json:"order_side"`
+	StockTicker       string       `json:"stock_ticker"`
+	RemainingQuantity int64        `json:"remaining_quantity"`
+	Reason            CancelReason `json:"reason"`
 }
 
 type OrderFilledEvent struct {
@@ -62,6 +97,7 @@ type OrderRejectedEvent struct {
 	TraderID     int64  `json:"trader_id"`
 	Reason       string `json:"reason"`
 	ErrorMessage string `json:"error_message"`
+	ErrorCode    int    `json:"error_code"` // Matches common.types.ErrorCode
 }
 
 type TradeExecutedEvent struct {
@@ -76,6 +112,107 @@ type TradeExecutedEvent struct {
 	TotalValueCents int64     `json:"total_value_cents"`
 }
 
+type OrderTriggeredEvent struct {
+	OrderID           string    `json:"order_id"`
+	TraderID          int64     `json:"trader_id"`
+	StockTicker       string    `json:"stock_ticker"`
+	OrderType         OrderType `json:"order_type"`
+	OrderSide         OrderSide `json:"order_side"`
+	Quantity          int64     `json:"quantity"`
+	TriggerPriceCents int64     `json:"trigger_price_cents"`
+	TradePriceCents   int64     `json:"trade_price_cents"`
+}
+
+type TrailingStopMovedEvent struct {
+	OrderID           string    `json:"order_id"`
+	TraderID          int64     `json:"trader_id"`
+	StockTicker       string    `json:"stock_ticker"`
+	OrderSide         OrderSide `json:"order_side"`
+	TriggerPriceCents int64     `json:"trigger_price_cents"`
+}
+
+type OrderAmendedEvent struct {
+	OrderID            string    `json:"order_id"`
+	TraderID           int64     `json:"trader_id"`
+	StockTicker        string    `json:"stock_ticker"`
+	OrderType          OrderType `json:"order_type"`
+	OrderSide          OrderSide `json:"order_side"`
+	OldQuantity        int64     `json:"old_quantity"`
+	NewQuantity        int64     `json:"new_quantity"`
+	OldLimitPriceCents int64     `json:"old_limit_price_cents"`
+	NewLimitPriceCents int64     `json:"new_limit_price_cents"`
+	Requeued           bool      `json:"requeued"`
+	ReservedCash       int64     `json:"reserved_cash_cents,omitempty"` // Reserve of a stop-market or trailing-stop buy after the amend
+}
+
+// SelfTradePrevention - Mirrors the engine's self-trade prevention modes
+type SelfTradePrevention int
+
+const (
+	SelfTradeDefault SelfTradePrevention = iota
+	SelfTradeAllow
+	SelfTradeCancelNewest
+	SelfTradeCancelOldest
+	SelfTradeCancelBoth
+	SelfTradeDecrement
+)
+
+type SelfTradePreventedEvent struct {
+	StockTicker      string              `json:"stock_ticker"`
+	IncomingOrderID  string              `json:"incoming_order_id"`
+	RestingOrderID   string              `json:"resting_order_id"`
+	IncomingTraderID int64               `json:"incoming_trader_id"`
+	RestingTraderID  int64               `json:"resting_trader_id"`
+	Mode             SelfTradePrevention `json:"mode"`
+	Quantity         int64               `json:"quantity"`
+	PriceCents       int64               `json:"price_cents"`
+}
+
+type AuctionUncrossedEvent struct {
+	StockTicker string `json:"stock_ticker"`
+	PriceCents  int64  `json:"price_cents"`
+	Volume      int64  `json:"volume"`
+	Closing     bool   `json:"closing"`
+}
+
+// HaltReason - Why trading in a stock was halted
+type HaltReason string
+
+const (
+	HaltReasonStaticBand  HaltReason = "STATIC_BAND"
+	HaltReasonDynamicBand HaltReason = "DYNAMIC_BAND"
+)
+
+type TradingHaltedEvent struct {
+	StockTicker         string     `json:"stock_ticker"`
+	Reason              HaltReason `json:"reason"`
+	PriceCents          int64      `json:"price_cents"`
+	ReferencePriceCents int64      `json:"reference_price_cents"`
+	LastTradePriceCents int64      `json:"last_trade_price_cents"`
+	HaltedUntil         time.Time  `json:"halted_until"`
+}
+
+type TradingResumedEvent struct {
+	StockTicker string `json:"stock_ticker"`
+	PriceCents  int64  `json:"price_cents"`
+}
+
+// SessionPhase - Stage of the market's trading day
+type SessionPhase string
+
+const (
+	PhasePreOpen    SessionPhase = "PRE_OPEN"
+	PhaseContinuous SessionPhase = "CONTINUOUS"
+	PhaseClose      SessionPhase = "CLOSE"
+	PhaseClosed     SessionPhase = "CLOSED"
+)
+
+type SessionPhaseChangedEvent struct {
+	Phase         SessionPhase `json:"phase"`
+	PreviousPhase SessionPhase `json:"previous_phase"`
+	ChangedAt     time.Time    `json:"changed_at"`
+}
+
 // EventPayload is a marker interface that all event payload types implement
 type EventPayload interface {
 	eventPayload() // unexported method ensures only this package can implement it
@@ -88,3 +225,11 @@ func (*OrderFilledEvent) eventPayload()          {}
 func (*OrderPartiallyFilledEvent) eventPayload() {}
 func (*OrderRejectedEvent) eventPayload()        {}
 func (*TradeExecutedEvent) eventPayload()        {}
+func (*OrderTriggeredEvent) eventPayload()       {}
+func (*OrderAmendedEvent) eventPayload()         {}
+func (*SelfTradePreventedEvent) eventPayload()   {}
+func (*AuctionUncrossedEvent) eventPayload()     {}
+func (*TradingHaltedEvent) eventPayload()        {}
+func (*TradingResumedEvent) eventPayload()       {}
+func (*SessionPhaseChangedEvent) eventPayload()  {}
+func (*TrailingStopMovedEvent) eventPayload()    {}
@@ -6,6 +6,20 @@ type OrderType int
 const (
 	MarketOrder OrderType = iota
 	LimitOrder
+	StopMarketOrder
+	StopLimitOrder
+	TrailingStopOrder
+)
+
+// TimeInForce - How long an order keeps working before the unfilled part is cancelled
+type TimeInForce int
+
+const (
+	GoodTillCancel TimeInForce = iota
+	ImmediateOrCancel
+	FillOrKill
+	Day
+	GoodTillDate
 )
 
 // OrderSide - On What side of the order the request falls
@@ -0,0 +1,174 @@
+// Command replay rebuilds the engine's order books from its event stream, to reproduce what
+// happened when a fill is disputed. Events come from a Valkey stream range or an exported JSONL
+// file. It prints the books as they stood after a given event and can diff them against a
+// running engine.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/replay"
+	marketdata "github.com/Marwan051/tradding_platform_game/proto/gen/go/v1/market_data"
+	pb "github.com/Marwan051/tradding_platform_game/proto/gen/go/v1/matching_engine"
+	glide "github.com/valkey-io/valkey-glide/go/v2"
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+)
+
+// errStop ends reading once the requested event has been applied
+var errStop = errors.New("stop")
+
+func main() {
+	file := flag.String("file", "", "JSONL file of event envelopes, one per line")
+	valkeyAddr := flag.String("valkey", "", "Valkey address (host:port) to read the stream from")
+	stream := flag.String("stream", "matching_engine_stream", "Valkey stream name")
+	from := flag.String("from", "-", "first stream entry ID to read")
+	to := flag.String("to", "+", "last stream entry ID to read")
+	at := flag.String("at", "", "stop after the event with this event ID or global sequence number")
+	stock := flag.String("stock", "", "only print and diff this stock's book")
+	engineAddr := flag.String("engine", "", "gRPC address of a live engine to diff the books against")
+	depth := flag.Int("depth", 0, "price levels per side to diff, 0 for all")
+	strict := flag.Bool("strict", false, "stop at the first event that does not fit the books")
+	flag.Parse()
+
+	if (*file == "") == (*valkeyAddr == "") {
+		log.Fatal("set exactly one of -file or -valkey")
+	}
+
+	replayer := replay.New()
+	found := *at == ""
+	apply := func(evt types.Event) error {
+		if err := replayer.Apply(evt); err != nil {
+			if *strict {
+				return err
+			}
+			log.Printf("warning: %v", err)
+		}
+		if *at != "" && (evt.EventID == *at || strconv.FormatInt(evt.Sequence, 10) == *at) {
+			found = true
+			fmt.Printf("after event %s (sequence %d, %s)\n\n", evt.EventID, evt.Sequence, evt.Timestamp.Format(time.RFC3339Nano))
+			return errStop
+		}
+		return nil
+	}
+
+	ctx := context.Background()
+	var err error
+	if *file != "" {
+		err = readFile(*file, apply)
+	} else {
+		err = readStream(ctx, *valkeyAddr, *stream, *from, *to, apply)
+	}
+	if err != nil && !errors.Is(err, errStop) {
+		log.Fatal(err)
+	}
+	if !found {
+		log.Fatalf("event %s not found", *at)
+	}
+
+	stocks := replayer.Stocks()
+	if *stock != "" {
+		stocks = []string{*stock}
+	}
+	for _, s := range stocks {
+		book := replayer.Book(s)
+		if book == nil {
+			log.Fatalf("no events for %s", s)
+		}
+		replay.PrintBook(os.Stdout, book)
+		fmt.Println()
+	}
+	log.Printf("applied %d events", replayer.Applied())
+
+	if *engineAddr == "" {
+		return
+	}
+	if *at != "" {
+		log.Print("warning: diffing a book replayed up to an earlier event against the live engine")
+	}
+	if !diffLive(ctx, *engineAddr, replayer, stocks, *depth) {
+		os.Exit(1)
+	}
+}
+
+func readFile(path string, apply func(types.Event) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return replay.ReadJSONL(f, apply)
+}
+
+func readStream(ctx context.Context, addr, stream, from, to string, apply func(types.Event) error) error {
+	host, portText, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("invalid Valkey address: %w", err)
+	}
+	port, err := strconv.Atoi(portText)
+	if err != nil {
+		return fmt.Errorf("invalid Valkey port: %w", err)
+	}
+	client, err := glide.NewClient(config.NewClientConfiguration().WithAddress(&config.NodeAddress{Host: host, Port: port}))
+	if err != nil {
+		return fmt.Errorf("failed to connect to Valkey: %w", err)
+	}
+	defer client.Close()
+	return replay.ReadStream(ctx, client, stream, from, to, apply)
+}
+
+// diffLive compares each replayed book with the live engine's. Returns true if they all match.
+func diffLive(ctx context.Context, addr string, replayer *replay.Replayer, stocks []string, depth int) bool {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("failed to connect to the engine: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewMatchingEngineClient(conn)
+
+	match := true
+	for _, stock := range stocks {
+		rpcCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		live, err := client.GetOrderBook(rpcCtx, &marketdata.GetOrderBookRequest{StockTicker: stock, Depth: int32(depth)})
+		cancel()
+		if err != nil {
+			log.Fatalf("failed to get the live %s book: %v", stock, err)
+		}
+		diffs := replay.Diff(replayer.Book(stock).Snapshot(depth), fromProto(live))
+		if len(diffs) == 0 {
+			fmt.Printf("%s: replay matches the live book\n", stock)
+			continue
+		}
+		match = false
+		fmt.Printf("%s: %d differences\n", stock, len(diffs))
+		for _, diff := range diffs {
+			fmt.Printf("  %s\n", diff)
+		}
+	}
+	return match
+}
+
+func fromProto(book *marketdata.OrderBook) types.BookSnapshot {
+	snapshot := types.BookSnapshot{
+		StockTicker:         book.GetStockTicker(),
+		LastTradePriceCents: book.GetLastTradePriceCents(),
+	}
+	for _, level := range book.GetBids() {
+		snapshot.Bids = append(snapshot.Bids, types.LevelSnapshot{PriceCents: level.GetPriceCents(), Quantity: level.GetQuantity(), OrderCount: int(level.GetOrderCount())})
+	}
+	for _, level := range book.GetAsks() {
+		snapshot.Asks = append(snapshot.Asks, types.LevelSnapshot{PriceCents: level.GetPriceCents(), Quantity: level.GetQuantity(), OrderCount: int(level.GetOrderCount())})
+	}
+	return snapshot
+}
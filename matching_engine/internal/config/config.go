@@ -14,6 +14,24 @@ type Config struct {
 	ValkeyPort           int
 	ValkeyStreamName     string
 	ValkeyRequestTimeout int
+	SelfTradePrevention  string
+	SelfTradeOwnerGroups bool
+	MatchingPolicies     string
+	PriceBandStaticBps   int
+	PriceBandDynamicBps  int
+	HaltDuration         time.Duration
+	StopReserveSlippage  int
+	InstrumentsFile      string
+	DatabaseURL          string
+	SessionCalendarFile  string
+	JournalDir           string
+	JournalSegmentBytes  int
+	SnapshotDir          string
+	SnapshotInterval     time.Duration
+	SnapshotKeep         int
+	RebuildFromDatabase  bool
+	SequencerQueueSize   int
+	ClientOrderIDWindow  time.Duration
 }
 
 func Load() *Config {
@@ -25,6 +43,24 @@ func Load() *Config {
 		ValkeyPort:           getIntEnv("VALKEY_PORT", 6379),
 		ValkeyStreamName:     getEnv("VALKEY_STREAM_NAME", "matching_engine_stream"),
 		ValkeyRequestTimeout: getIntEnv("VALKEY_REQUEST_TIMEOUT_MS", 300),
+		SelfTradePrevention:  getEnv("SELF_TRADE_PREVENTION", "ALLOW"),
+		SelfTradeOwnerGroups: getBoolEnv("SELF_TRADE_OWNER_GROUPS", false),
+		MatchingPolicies:     getEnv("MATCHING_POLICIES", ""),
+		PriceBandStaticBps:   getIntEnv("PRICE_BAND_STATIC_BPS", 0),
+		PriceBandDynamicBps:  getIntEnv("PRICE_BAND_DYNAMIC_BPS", 0),
+		HaltDuration:         getDurationEnv("HALT_DURATION", 5*time.Minute),
+		StopReserveSlippage:  getIntEnv("STOP_RESERVE_SLIPPAGE_BPS", 500),
+		InstrumentsFile:      getEnv("INSTRUMENTS_FILE", ""),
+		DatabaseURL:          getEnv("DATABASE_URL", ""),
+		SessionCalendarFile:  getEnv("SESSION_CALENDAR_FILE", ""),
+		JournalDir:           getEnv("JOURNAL_DIR", ""),
+		JournalSegmentBytes:  getIntEnv("JOURNAL_SEGMENT_BYTES", 64<<20),
+		SnapshotDir:          getEnv("SNAPSHOT_DIR", ""),
+		SnapshotInterval:     getDurationEnv("SNAPSHOT_INTERVAL", 5*time.Minute),
+		SnapshotKeep:         getIntEnv("SNAPSHOT_KEEP", 3),
+		RebuildFromDatabase:  getBoolEnv("REBUILD_FROM_DATABASE", false),
+		SequencerQueueSize:   getIntEnv("SEQUENCER_QUEUE_SIZE", 0),
+		ClientOrderIDWindow:  getDurationEnv("CLIENT_ORDER_ID_WINDOW", 10*time.Minute),
 	}
 }
 
@@ -52,3 +88,12 @@ func getIntEnv(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getBoolEnv(key string, defaultValue bool) bool {
+	if value, exists := os.LookupEnv(key); exists {
+		if parsed, err := strconv.ParseBool(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
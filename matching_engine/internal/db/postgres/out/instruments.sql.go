@@ -0,0 +1,89 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: instruments.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const listInstruments = `-- name: ListInstruments :many
+SELECT ticker,
+    tick_size_cents,
+    lot_size,
+    min_quantity,
+    is_active
+FROM stocks
+ORDER BY ticker
+`
+
+type ListInstrumentsRow struct {
+	Ticker        string      `json:"ticker"`
+	TickSizeCents int64       `json:"tick_size_cents"`
+	LotSize       int64       `json:"lot_size"`
+	MinQuantity   int64       `json:"min_quantity"`
+	IsActive      pgtype.Bool `json:"is_active"`
+}
+
+func (q *Queries) ListInstruments(ctx context.Context) ([]ListInstrumentsRow, error) {
+	rows, err := q.db.Query(ctx, listInstruments)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListInstrumentsRow{}
+	for rows.Next() {
+		var i ListInstrumentsRow
+		if err := rows.Scan(
+			&i.Ticker,
+			&i.TickSizeCents,
+			&i.LotSize,
+			&i.MinQuantity,
+			&i.IsActive,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPreviousCloses = `-- name: ListPreviousCloses :many
+SELECT ticker,
+    previous_close_cents::BIGINT AS previous_close_cents
+FROM stocks
+WHERE previous_close_cents IS NOT NULL
+ORDER BY ticker
+`
+
+type ListPreviousClosesRow struct {
+	Ticker             string `json:"ticker"`
+	PreviousCloseCents int64  `json:"previous_close_cents"`
+}
+
+func (q *Queries) ListPreviousCloses(ctx context.Context) ([]ListPreviousClosesRow, error) {
+	rows, err := q.db.Query(ctx, listPreviousCloses)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListPreviousClosesRow{}
+	for rows.Next() {
+		var i ListPreviousClosesRow
+		if err := rows.Scan(&i.Ticker, &i.PreviousCloseCents); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
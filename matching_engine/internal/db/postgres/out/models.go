@@ -0,0 +1,150 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+
+package db
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type Leaderboard struct {
+	TraderID                 int64       `json:"trader_id"`
+	AuthUserID               pgtype.Text `json:"auth_user_id"`
+	DisplayName              string      `json:"display_name"`
+	CashBalanceCents         pgtype.Int8 `json:"cash_balance_cents"`
+	TotalPortfolioValueCents pgtype.Int8 `json:"total_portfolio_value_cents"`
+	Rank                     int64       `json:"rank"`
+	LastUpdated              interface{} `json:"last_updated"`
+}
+
+type MarketSessionPhase struct {
+	ID            int64              `json:"id"`
+	Phase         string             `json:"phase"`
+	PreviousPhase string             `json:"previous_phase"`
+	ChangedAt     pgtype.Timestamptz `json:"changed_at"`
+}
+
+type Order struct {
+	ID                  pgtype.UUID        `json:"id"`
+	TraderID            int64              `json:"trader_id"`
+	StockTicker         string             `json:"stock_ticker"`
+	OrderType           string             `json:"order_type"`
+	Side                string             `json:"side"`
+	Quantity            int64              `json:"quantity"`
+	FilledQuantity      pgtype.Int8        `json:"filled_quantity"`
+	RemainingQuantity   int64              `json:"remaining_quantity"`
+	LimitPriceCents     pgtype.Int8        `json:"limit_price_cents"`
+	Status              pgtype.Text        `json:"status"`
+	CreatedAt           pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt           pgtype.Timestamptz `json:"updated_at"`
+	FilledAt            pgtype.Timestamptz `json:"filled_at"`
+	CancelledAt         pgtype.Timestamptz `json:"cancelled_at"`
+	TriggerPriceCents   pgtype.Int8        `json:"trigger_price_cents"`
+	TriggeredAt         pgtype.Timestamptz `json:"triggered_at"`
+	TimeInForce         string             `json:"time_in_force"`
+	ExpiresAt           pgtype.Timestamptz `json:"expires_at"`
+	CancelReason        pgtype.Text        `json:"cancel_reason"`
+	DisplayQuantity     pgtype.Int8        `json:"display_quantity"`
+	TrailingOffsetCents pgtype.Int8        `json:"trailing_offset_cents"`
+	TrailingOffsetBps   pgtype.Int8        `json:"trailing_offset_bps"`
+	ClientOrderID       pgtype.Text        `json:"client_order_id"`
+	CashReservedCents   int64              `json:"cash_reserved_cents"`
+	SelfTradePrevention pgtype.Text        `json:"self_trade_prevention"`
+	PostOnly            bool               `json:"post_only"`
+	PostOnlyReprice     bool               `json:"post_only_reprice"`
+	QueuedAt            pgtype.Timestamptz `json:"queued_at"`
+}
+
+type Position struct {
+	ID             int64              `json:"id"`
+	TraderID       int64              `json:"trader_id"`
+	StockTicker    string             `json:"stock_ticker"`
+	Quantity       int64              `json:"quantity"`
+	QuantityHold   pgtype.Int8        `json:"quantity_hold"`
+	TotalCostCents int64              `json:"total_cost_cents"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+}
+
+type Price1hour struct {
+	StockTicker string      `json:"stock_ticker"`
+	Bucket      interface{} `json:"bucket"`
+	OpenCents   interface{} `json:"open_cents"`
+	HighCents   interface{} `json:"high_cents"`
+	LowCents    interface{} `json:"low_cents"`
+	CloseCents  interface{} `json:"close_cents"`
+	Volume      int64       `json:"volume"`
+	TradeCount  int64       `json:"trade_count"`
+}
+
+type Price1min struct {
+	StockTicker string      `json:"stock_ticker"`
+	Bucket      interface{} `json:"bucket"`
+	OpenCents   interface{} `json:"open_cents"`
+	HighCents   interface{} `json:"high_cents"`
+	LowCents    interface{} `json:"low_cents"`
+	CloseCents  interface{} `json:"close_cents"`
+	Volume      int64       `json:"volume"`
+	TradeCount  int64       `json:"trade_count"`
+}
+
+type SelfTradePrevention struct {
+	ID               int64              `json:"id"`
+	StockTicker      string             `json:"stock_ticker"`
+	IncomingOrderID  pgtype.UUID        `json:"incoming_order_id"`
+	RestingOrderID   pgtype.UUID        `json:"resting_order_id"`
+	IncomingTraderID int64              `json:"incoming_trader_id"`
+	RestingTraderID  int64              `json:"resting_trader_id"`
+	Mode             string             `json:"mode"`
+	Quantity         int64              `json:"quantity"`
+	PriceCents       int64              `json:"price_cents"`
+	PreventedAt      pgtype.Timestamptz `json:"prevented_at"`
+}
+
+type Stock struct {
+	Ticker             string             `json:"ticker"`
+	CompanyName        string             `json:"company_name"`
+	Sector             pgtype.Text        `json:"sector"`
+	Description        pgtype.Text        `json:"description"`
+	CurrentPriceCents  int64              `json:"current_price_cents"`
+	PreviousCloseCents pgtype.Int8        `json:"previous_close_cents"`
+	TotalShares        pgtype.Int8        `json:"total_shares"`
+	IsActive           pgtype.Bool        `json:"is_active"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+	HaltedUntil        pgtype.Timestamptz `json:"halted_until"`
+	TickSizeCents      int64              `json:"tick_size_cents"`
+	LotSize            int64              `json:"lot_size"`
+	MinQuantity        int64              `json:"min_quantity"`
+}
+
+type Trade struct {
+	ID              int64              `json:"id"`
+	StockTicker     string             `json:"stock_ticker"`
+	BuyerOrderID    pgtype.UUID        `json:"buyer_order_id"`
+	SellerOrderID   pgtype.UUID        `json:"seller_order_id"`
+	BuyerTraderID   int64              `json:"buyer_trader_id"`
+	SellerTraderID  int64              `json:"seller_trader_id"`
+	Quantity        int64              `json:"quantity"`
+	PriceCents      int64              `json:"price_cents"`
+	TotalValueCents int64              `json:"total_value_cents"`
+	ExecutedAt      pgtype.Timestamptz `json:"executed_at"`
+}
+
+type Trader struct {
+	ID                       int64              `json:"id"`
+	TraderType               string             `json:"trader_type"`
+	AuthUserID               pgtype.Text        `json:"auth_user_id"`
+	OwnerTraderID            pgtype.Int8        `json:"owner_trader_id"`
+	DisplayName              string             `json:"display_name"`
+	CashBalanceCents         pgtype.Int8        `json:"cash_balance_cents"`
+	CashHoldCents            pgtype.Int8        `json:"cash_hold_cents"`
+	TotalPortfolioValueCents pgtype.Int8        `json:"total_portfolio_value_cents"`
+	IsActive                 pgtype.Bool        `json:"is_active"`
+	LastActiveAt             pgtype.Timestamptz `json:"last_active_at"`
+	LastTradeAt              pgtype.Timestamptz `json:"last_trade_at"`
+	TotalTradesCount         pgtype.Int4        `json:"total_trades_count"`
+	CreatedAt                pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt                pgtype.Timestamptz `json:"updated_at"`
+}
@@ -0,0 +1,247 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: orders.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const listCashHolds = `-- name: ListCashHolds :many
+SELECT id,
+    cash_hold_cents
+FROM traders
+WHERE cash_hold_cents <> 0
+`
+
+type ListCashHoldsRow struct {
+	ID            int64       `json:"id"`
+	CashHoldCents pgtype.Int8 `json:"cash_hold_cents"`
+}
+
+func (q *Queries) ListCashHolds(ctx context.Context) ([]ListCashHoldsRow, error) {
+	rows, err := q.db.Query(ctx, listCashHolds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListCashHoldsRow{}
+	for rows.Next() {
+		var i ListCashHoldsRow
+		if err := rows.Scan(&i.ID, &i.CashHoldCents); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOpenOrders = `-- name: ListOpenOrders :many
+SELECT o.id,
+    o.trader_id,
+    t.owner_trader_id,
+    o.stock_ticker,
+    o.order_type,
+    o.side,
+    o.remaining_quantity,
+    o.limit_price_cents,
+    o.trigger_price_cents,
+    o.triggered_at,
+    o.trailing_offset_cents,
+    o.trailing_offset_bps,
+    o.cash_reserved_cents,
+    o.time_in_force,
+    o.expires_at,
+    o.display_quantity,
+    o.client_order_id,
+    o.self_trade_prevention,
+    o.post_only,
+    o.post_only_reprice,
+    o.created_at,
+    COALESCE(o.queued_at, o.created_at)::TIMESTAMPTZ AS queued_at
+FROM orders o
+    JOIN traders t ON t.id = o.trader_id
+WHERE o.status IN ('PENDING', 'PARTIAL')
+ORDER BY COALESCE(o.queued_at, o.created_at),
+    o.created_at,
+    o.id
+`
+
+type ListOpenOrdersRow struct {
+	ID                  pgtype.UUID        `json:"id"`
+	TraderID            int64              `json:"trader_id"`
+	OwnerTraderID       pgtype.Int8        `json:"owner_trader_id"`
+	StockTicker         string             `json:"stock_ticker"`
+	OrderType           string             `json:"order_type"`
+	Side                string             `json:"side"`
+	RemainingQuantity   int64              `json:"remaining_quantity"`
+	LimitPriceCents     pgtype.Int8        `json:"limit_price_cents"`
+	TriggerPriceCents   pgtype.Int8        `json:"trigger_price_cents"`
+	TriggeredAt         pgtype.Timestamptz `json:"triggered_at"`
+	TrailingOffsetCents pgtype.Int8        `json:"trailing_offset_cents"`
+	TrailingOffsetBps   pgtype.Int8        `json:"trailing_offset_bps"`
+	CashReservedCents   int64              `json:"cash_reserved_cents"`
+	TimeInForce         string             `json:"time_in_force"`
+	ExpiresAt           pgtype.Timestamptz `json:"expires_at"`
+	DisplayQuantity     pgtype.Int8        `json:"display_quantity"`
+	ClientOrderID       pgtype.Text        `json:"client_order_id"`
+	SelfTradePrevention pgtype.Text        `json:"self_trade_prevention"`
+	PostOnly            bool               `json:"post_only"`
+	PostOnlyReprice     bool               `json:"post_only_reprice"`
+	CreatedAt           pgtype.Timestamptz `json:"created_at"`
+	QueuedAt            pgtype.Timestamptz `json:"queued_at"`
+}
+
+func (q *Queries) ListOpenOrders(ctx context.Context) ([]ListOpenOrdersRow, error) {
+	rows, err := q.db.Query(ctx, listOpenOrders)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListOpenOrdersRow{}
+	for rows.Next() {
+		var i ListOpenOrdersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TraderID,
+			&i.OwnerTraderID,
+			&i.StockTicker,
+			&i.OrderType,
+			&i.Side,
+			&i.RemainingQuantity,
+			&i.LimitPriceCents,
+			&i.TriggerPriceCents,
+			&i.TriggeredAt,
+			&i.TrailingOffsetCents,
+			&i.TrailingOffsetBps,
+			&i.CashReservedCents,
+			&i.TimeInForce,
+			&i.ExpiresAt,
+			&i.DisplayQuantity,
+			&i.ClientOrderID,
+			&i.SelfTradePrevention,
+			&i.PostOnly,
+			&i.PostOnlyReprice,
+			&i.CreatedAt,
+			&i.QueuedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listShareHolds = `-- name: ListShareHolds :many
+SELECT trader_id,
+    stock_ticker,
+    quantity_hold
+FROM positions
+WHERE quantity_hold <> 0
+`
+
+type ListShareHoldsRow struct {
+	TraderID     int64       `json:"trader_id"`
+	StockTicker  string      `json:"stock_ticker"`
+	QuantityHold pgtype.Int8 `json:"quantity_hold"`
+}
+
+func (q *Queries) ListShareHolds(ctx context.Context) ([]ListShareHoldsRow, error) {
+	rows, err := q.db.Query(ctx, listShareHolds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListShareHoldsRow{}
+	for rows.Next() {
+		var i ListShareHoldsRow
+		if err := rows.Scan(&i.TraderID, &i.StockTicker, &i.QuantityHold); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStockPrices = `-- name: ListStockPrices :many
+SELECT ticker,
+    current_price_cents,
+    previous_close_cents
+FROM stocks
+ORDER BY ticker
+`
+
+type ListStockPricesRow struct {
+	Ticker             string      `json:"ticker"`
+	CurrentPriceCents  int64       `json:"current_price_cents"`
+	PreviousCloseCents pgtype.Int8 `json:"previous_close_cents"`
+}
+
+func (q *Queries) ListStockPrices(ctx context.Context) ([]ListStockPricesRow, error) {
+	rows, err := q.db.Query(ctx, listStockPrices)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListStockPricesRow{}
+	for rows.Next() {
+		var i ListStockPricesRow
+		if err := rows.Scan(&i.Ticker, &i.CurrentPriceCents, &i.PreviousCloseCents); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const sumOpenOrderQuantities = `-- name: SumOpenOrderQuantities :many
+SELECT stock_ticker,
+    side,
+    SUM(remaining_quantity)::BIGINT AS remaining_quantity
+FROM orders
+WHERE status IN ('PENDING', 'PARTIAL')
+GROUP BY stock_ticker,
+    side
+`
+
+type SumOpenOrderQuantitiesRow struct {
+	StockTicker       string `json:"stock_ticker"`
+	Side              string `json:"side"`
+	RemainingQuantity int64  `json:"remaining_quantity"`
+}
+
+func (q *Queries) SumOpenOrderQuantities(ctx context.Context) ([]SumOpenOrderQuantitiesRow, error) {
+	rows, err := q.db.Query(ctx, sumOpenOrderQuantities)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SumOpenOrderQuantitiesRow{}
+	for rows.Next() {
+		var i SumOpenOrderQuantitiesRow
+		if err := rows.Scan(&i.StockTicker, &i.Side, &i.RemainingQuantity); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,21 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+
+package db
+
+import (
+	"context"
+)
+
+type Querier interface {
+	ListCashHolds(ctx context.Context) ([]ListCashHoldsRow, error)
+	ListInstruments(ctx context.Context) ([]ListInstrumentsRow, error)
+	ListOpenOrders(ctx context.Context) ([]ListOpenOrdersRow, error)
+	ListPreviousCloses(ctx context.Context) ([]ListPreviousClosesRow, error)
+	ListShareHolds(ctx context.Context) ([]ListShareHoldsRow, error)
+	ListStockPrices(ctx context.Context) ([]ListStockPricesRow, error)
+	SumOpenOrderQuantities(ctx context.Context) ([]SumOpenOrderQuantitiesRow, error)
+}
+
+var _ Querier = (*Queries)(nil)
@@ -0,0 +1,122 @@
+// Package dedup makes order entry idempotent: the result of placing an order is remembered
+// under the trader's own client order ID for a window, so a retried request gets the original
+// result instead of placing a second order.
+//
+// Results are only kept in memory; a restart forgets them.
+package dedup
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Key identifies an order by the trader placing it and the ID the trader chose for it
+type Key struct {
+	TraderID      int64
+	ClientOrderID string
+}
+
+// Options tunes a cache
+type Options struct {
+	Clock func() time.Time // Current time, time.Now if nil
+}
+
+// entry is one placement, finished once done is closed
+type entry[T any] struct {
+	key      Key
+	done     chan struct{}
+	result   T
+	placed   bool // The placement returned without error, so result is kept
+	placedAt time.Time
+}
+
+// Cache remembers placement results for a window after each placement started
+type Cache[T any] struct {
+	window  time.Duration
+	clock   func() time.Time
+	mu      sync.Mutex
+	entries map[Key]*entry[T]
+	queue   []*entry[T] // Oldest first, for expiry
+}
+
+// New creates a cache that remembers results for window
+func New[T any](window time.Duration, opts Options) *Cache[T] {
+	if opts.Clock == nil {
+		opts.Clock = time.Now
+	}
+	return &Cache[T]{
+		window:  window,
+		clock:   opts.Clock,
+		entries: make(map[Key]*entry[T]),
+	}
+}
+
+// Do calls place unless an earlier call with the same key is remembered, in which case that
+// call's result is returned and duplicate is true. A duplicate arriving while the first call
+// is still placing waits for it. Failed placements are not remembered: the next call with the
+// key places the order again.
+func (c *Cache[T]) Do(ctx context.Context, key Key, place func() (T, error)) (result T, duplicate bool, err error) {
+	for {
+		c.mu.Lock()
+		now := c.clock()
+		c.expire(now)
+		e, found := c.entries[key]
+		if !found {
+			e = &entry[T]{key: key, done: make(chan struct{}), placedAt: now}
+			c.entries[key] = e
+			c.queue = append(c.queue, e)
+			c.mu.Unlock()
+			return c.place(e, place)
+		}
+		c.mu.Unlock()
+
+		select {
+		case <-e.done:
+		case <-ctx.Done():
+			return result, false, ctx.Err()
+		}
+		if e.placed {
+			return e.result, true, nil
+		}
+		// The first call failed without placing anything, so this one may try
+	}
+}
+
+// place runs a placement for its entry and records the result
+func (c *Cache[T]) place(e *entry[T], place func() (T, error)) (T, bool, error) {
+	result, err := place()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err != nil {
+		if c.entries[e.key] == e {
+			delete(c.entries, e.key)
+		}
+	} else {
+		e.result, e.placed = result, true
+	}
+	close(e.done)
+	return result, false, err
+}
+
+// expire forgets results placed more than the window ago. Must be called with mu held.
+func (c *Cache[T]) expire(now time.Time) {
+	n := 0
+	for n < len(c.queue) && now.Sub(c.queue[n].placedAt) >= c.window {
+		e := c.queue[n]
+		if c.entries[e.key] == e {
+			delete(c.entries, e.key)
+		}
+		c.queue[n] = nil
+		n++
+	}
+	c.queue = c.queue[n:]
+}
+
+// Len returns the number of results remembered
+func (c *Cache[T]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.expire(c.clock())
+	return len(c.entries)
+}
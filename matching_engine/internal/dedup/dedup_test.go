@@ -0,0 +1,117 @@
+package dedup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testClock is a clock that only moves when told to
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// counter returns a placement that numbers its results, and the number of placements made
+func counter() (func() (string, error), *atomic.Int64) {
+	var placed atomic.Int64
+	return func() (string, error) {
+		return fmt.Sprintf("order-%d", placed.Add(1)), nil
+	}, &placed
+}
+
+func TestCache(t *testing.T) {
+	t.Run("should return the original result for a repeated key", func(t *testing.T) {
+		cache := New[string](time.Minute, Options{})
+		place, placed := counter()
+		ctx := context.Background()
+
+		first, duplicate, err := cache.Do(ctx, Key{TraderID: 1, ClientOrderID: "a"}, place)
+		if err != nil || duplicate {
+			t.Fatalf("expected the first request to place, got duplicate=%v, error %v", duplicate, err)
+		}
+		again, duplicate, _ := cache.Do(ctx, Key{TraderID: 1, ClientOrderID: "a"}, place)
+		if !duplicate || again != first {
+			t.Errorf("expected the original result %s back, got %s (duplicate=%v)", first, again, duplicate)
+		}
+		// Client order IDs belong to their trader
+		if _, duplicate, _ := cache.Do(ctx, Key{TraderID: 2, ClientOrderID: "a"}, place); duplicate {
+			t.Error("expected another trader's request with the same client order ID to place")
+		}
+		if placed.Load() != 2 {
+			t.Errorf("expected 2 placements, got %d", placed.Load())
+		}
+	})
+
+	t.Run("should place concurrent duplicates once", func(t *testing.T) {
+		cache := New[string](time.Minute, Options{})
+		place, placed := counter()
+		results := make([]string, 20)
+		var wg sync.WaitGroup
+		for i := range results {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i], _, _ = cache.Do(context.Background(), Key{TraderID: 1, ClientOrderID: "a"}, place)
+			}()
+		}
+		wg.Wait()
+
+		for _, result := range results {
+			if result != results[0] {
+				t.Fatalf("expected every request to get %s, got %s", results[0], result)
+			}
+		}
+		if placed.Load() != 1 {
+			t.Errorf("expected 1 placement, got %d", placed.Load())
+		}
+	})
+
+	t.Run("should let a failed request be retried", func(t *testing.T) {
+		cache := New[string](time.Minute, Options{})
+		key := Key{TraderID: 1, ClientOrderID: "a"}
+		cache.Do(context.Background(), key, func() (string, error) { return "", errors.New("unavailable") })
+		result, duplicate, err := cache.Do(context.Background(), key, func() (string, error) { return "order-1", nil })
+		if err != nil || duplicate || result != "order-1" {
+			t.Errorf("expected the retry to place order-1, got %q, duplicate=%v, error %v", result, duplicate, err)
+		}
+	})
+
+	t.Run("should forget results after the window", func(t *testing.T) {
+		clock := &testClock{now: time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC)}
+		cache := New[string](time.Minute, Options{Clock: clock.Now})
+		place, _ := counter()
+		key := Key{TraderID: 1, ClientOrderID: "a"}
+		cache.Do(context.Background(), key, place)
+
+		clock.Advance(59 * time.Second)
+		if _, duplicate, _ := cache.Do(context.Background(), key, place); !duplicate {
+			t.Error("expected the result to be remembered within the window")
+		}
+
+		clock.Advance(time.Second)
+		result, duplicate, _ := cache.Do(context.Background(), key, place)
+		if duplicate || result != "order-2" {
+			t.Errorf("expected a new placement once the window passed, got %q", result)
+		}
+		if cache.Len() != 1 {
+			t.Errorf("expected only the new result remembered, got %d", cache.Len())
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package instruments
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	db "github.com/Marwan051/tradding_platform_game/matching_engine/internal/db/postgres/out"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+	"github.com/jackc/pgx/v5"
+)
+
+// fileInstrument is one stock's entry in an instruments file
+type fileInstrument struct {
+	Ticker        string `json:"ticker"`
+	TickSizeCents int64  `json:"tick_size_cents"`
+	LotSize       int64  `json:"lot_size"`
+	MinQuantity   int64  `json:"min_quantity"`
+	IsActive      *bool  `json:"is_active"` // Defaults to true
+}
+
+// LoadFile reads instruments from a JSON array file
+func LoadFile(path string) ([]types.Instrument, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read instruments file: %w", err)
+	}
+	var entries []fileInstrument
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse instruments file: %w", err)
+	}
+
+	instruments := make([]types.Instrument, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Ticker == "" {
+			return nil, errors.New("instruments file has an entry without a ticker")
+		}
+		active := entry.IsActive == nil || *entry.IsActive
+		instruments = append(instruments, types.Instrument{
+			Ticker: entry.Ticker,
+			Spec:   types.NewInstrumentSpec(entry.TickSizeCents, entry.LotSize, entry.MinQuantity),
+			Active: active,
+		})
+	}
+	return instruments, nil
+}
+
+// LoadPostgres reads instruments from the stocks table
+func LoadPostgres(ctx context.Context, databaseURL string) ([]types.Instrument, error) {
+	conn, err := pgx.Connect(ctx, databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer conn.Close(ctx)
+
+	rows, err := db.New(conn).ListInstruments(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list instruments: %w", err)
+	}
+	instruments := make([]types.Instrument, 0, len(rows))
+	for _, row := range rows {
+		active := !row.IsActive.Valid || row.IsActive.Bool // is_active defaults to TRUE
+		instruments = append(instruments, types.Instrument{
+			Ticker: row.Ticker,
+			Spec:   types.NewInstrumentSpec(row.TickSizeCents, row.LotSize, row.MinQuantity),
+			Active: active,
+		})
+	}
+	return instruments, nil
+}
+
+// LoadPreviousCloses reads each stock's previous close from the stocks table, by ticker.
+// Stocks that have never closed are left out.
+func LoadPreviousCloses(ctx context.Context, databaseURL string) (map[string]int64, error) {
+	conn, err := pgx.Connect(ctx, databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer conn.Close(ctx)
+
+	rows, err := db.New(conn).ListPreviousCloses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list previous closes: %w", err)
+	}
+	closes := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		closes[row.Ticker] = row.PreviousCloseCents
+	}
+	return closes, nil
+}
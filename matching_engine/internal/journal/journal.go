@@ -0,0 +1,382 @@
+// Package journal is the matching engine's write-ahead log: every command that changes a book
+// is appended and fsynced before it is applied, so the books can be rebuilt by replaying it.
+//
+// The journal is a directory of segment files named after the sequence number of their first
+// record. Each record is a little-endian uint32 payload length, a uint32 CRC-32C of the
+// payload and the command as JSON.
+package journal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+const (
+	segmentSuffix      = ".wal"
+	headerSize         = 8
+	maxRecordSize      = 16 << 20
+	DefaultSegmentSize = 64 << 20
+)
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// ErrClosed is returned by Append after Close
+var ErrClosed = errors.New("journal is closed")
+
+// Options tunes a journal
+type Options struct {
+	SegmentSize int64 // Size after which a new segment is started, DefaultSegmentSize if 0
+}
+
+// segment is one journal file
+type segment struct {
+	path  string
+	first uint64 // Sequence number of the first record
+}
+
+// Journal appends commands to the newest segment. Concurrent appends share fsyncs: one
+// caller syncs everything written so far while the others wait for it.
+type Journal struct {
+	dir         string
+	segmentSize int64
+
+	mu       sync.Mutex
+	synced   *sync.Cond
+	file     *os.File
+	size     int64  // Bytes in the current segment
+	last     uint64 // Sequence number of the last record written
+	durable  uint64 // Sequence number of the last record fsynced
+	syncing  bool
+	syncErr  error // Set once a write or fsync fails; the journal then refuses appends
+	closed   bool
+	segments []segment
+}
+
+// Open opens the journal in dir, creating the directory if needed. A torn or corrupt record at
+// the end of the newest segment, left by a crash mid-write, is truncated away; corruption
+// anywhere else is an error.
+func Open(dir string, opts Options) (*Journal, error) {
+	if opts.SegmentSize <= 0 {
+		opts.SegmentSize = DefaultSegmentSize
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create journal directory: %w", err)
+	}
+	segments, err := listSegments(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	j := &Journal{dir: dir, segmentSize: opts.SegmentSize, segments: segments}
+	j.synced = sync.NewCond(&j.mu)
+
+	next := uint64(1)
+	var validSize int64
+	for i, seg := range segments {
+		if seg.first != next {
+			return nil, fmt.Errorf("journal segment %s starts at %d, expected %d", filepath.Base(seg.path), seg.first, next)
+		}
+		count, size, scanErr := scanSegment(seg.path, seg.first, nil)
+		if scanErr != nil && i < len(segments)-1 {
+			return nil, fmt.Errorf("journal segment %s is corrupt: %w", filepath.Base(seg.path), scanErr)
+		}
+		next += count
+		validSize = size
+	}
+	j.last = next - 1
+	j.durable = j.last
+
+	if len(segments) == 0 {
+		if err := j.startSegment(1); err != nil {
+			return nil, err
+		}
+		return j, nil
+	}
+	file, err := os.OpenFile(segments[len(segments)-1].path, os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal segment: %w", err)
+	}
+	// Drop whatever follows the last complete record
+	if err := file.Truncate(validSize); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to truncate journal segment: %w", err)
+	}
+	if _, err := file.Seek(validSize, io.SeekStart); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to seek journal segment: %w", err)
+	}
+	j.file = file
+	j.size = validSize
+	return j, nil
+}
+
+// Append writes a command, assigns it the next sequence number and returns once it is on disk
+func (j *Journal) Append(cmd types.Command) (uint64, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	var payload []byte
+	for {
+		if j.closed {
+			return 0, ErrClosed
+		}
+		if j.syncErr != nil {
+			return 0, j.syncErr
+		}
+		cmd.Sequence = j.last + 1
+		var err error
+		payload, err = json.Marshal(cmd)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode command: %w", err)
+		}
+		if j.size == 0 || j.size+headerSize+int64(len(payload)) <= j.segmentSize {
+			break
+		}
+		// The segment can't be sealed under a running fsync. Waiting releases mu, so other
+		// appends may take this sequence number meanwhile; start over once it is done.
+		if j.syncing {
+			j.synced.Wait()
+			continue
+		}
+		if err := j.rotate(cmd.Sequence); err != nil {
+			j.syncErr = err
+			return 0, err
+		}
+		break
+	}
+
+	record := make([]byte, headerSize+len(payload))
+	binary.LittleEndian.PutUint32(record[0:4], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(record[4:8], crc32.Checksum(payload, crcTable))
+	copy(record[headerSize:], payload)
+	if _, err := j.file.Write(record); err != nil {
+		j.syncErr = fmt.Errorf("failed to write journal record: %w", err)
+		return 0, j.syncErr
+	}
+	j.size += int64(len(record))
+	j.last = cmd.Sequence
+
+	if err := j.waitDurable(cmd.Sequence); err != nil {
+		return 0, err
+	}
+	return cmd.Sequence, nil
+}
+
+// waitDurable blocks until seq has been fsynced. If no fsync is running this caller runs one
+// for every record written so far. Must be called with mu held.
+func (j *Journal) waitDurable(seq uint64) error {
+	for j.durable < seq {
+		if j.syncErr != nil {
+			return j.syncErr
+		}
+		if j.syncing {
+			j.synced.Wait()
+			continue
+		}
+		j.syncing = true
+		target, file := j.last, j.file
+		j.mu.Unlock()
+		err := file.Sync()
+		j.mu.Lock()
+		j.syncing = false
+		if err != nil {
+			j.syncErr = fmt.Errorf("failed to sync journal: %w", err)
+		} else if target > j.durable {
+			j.durable = target
+		}
+		j.synced.Broadcast()
+	}
+	return j.syncErr
+}
+
+// rotate seals the current segment and starts a new one whose first record is first.
+// Must be called with mu held and no fsync running.
+func (j *Journal) rotate(first uint64) error {
+	if err := j.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync journal segment: %w", err)
+	}
+	if err := j.file.Close(); err != nil {
+		return fmt.Errorf("failed to close journal segment: %w", err)
+	}
+	j.durable = j.last
+	return j.startSegment(first)
+}
+
+// startSegment creates a segment file whose first record is first and makes it current.
+// Must be called with mu held.
+func (j *Journal) startSegment(first uint64) error {
+	path := filepath.Join(j.dir, segmentName(first))
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to create journal segment: %w", err)
+	}
+	// The new file name has to survive a crash too
+	if err := syncDir(j.dir); err != nil {
+		file.Close()
+		return err
+	}
+	j.file = file
+	j.size = 0
+	j.segments = append(j.segments, segment{path: path, first: first})
+	return nil
+}
+
+// Replay calls apply for every record after the given sequence number, in order. Records
+// appended while replaying are not seen.
+func (j *Journal) Replay(after uint64, apply func(types.Command) error) error {
+	j.mu.Lock()
+	segments := append([]segment(nil), j.segments...)
+	last := j.last
+	j.mu.Unlock()
+
+	for i, seg := range segments {
+		// Skip segments that end before the first record wanted
+		if i+1 < len(segments) && segments[i+1].first <= after+1 {
+			continue
+		}
+		stopped := false
+		_, _, err := scanSegment(seg.path, seg.first, func(cmd types.Command) error {
+			if cmd.Sequence > last {
+				stopped = true
+				return io.EOF
+			}
+			if cmd.Sequence <= after {
+				return nil
+			}
+			return apply(cmd)
+		})
+		if stopped {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LastSequence returns the sequence number of the last record appended
+func (j *Journal) LastSequence() uint64 {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.last
+}
+
+// Close waits for pending fsyncs and closes the current segment
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.closed {
+		return nil
+	}
+	for j.syncing {
+		j.synced.Wait()
+	}
+	j.closed = true
+	if err := j.file.Sync(); err != nil {
+		j.file.Close()
+		return fmt.Errorf("failed to sync journal: %w", err)
+	}
+	return j.file.Close()
+}
+
+// scanSegment reads a segment's records, calling fn for each if it is set. Returns the number of
+// valid records and the offset just past the last of them. The error reports a torn or corrupt
+// record, or the first error returned by fn.
+func scanSegment(path string, first uint64, fn func(types.Command) error) (uint64, int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to open journal segment: %w", err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	header := make([]byte, headerSize)
+	var count uint64
+	var offset int64
+	for {
+		if _, err := io.ReadFull(reader, header); err != nil {
+			if err == io.EOF {
+				return count, offset, nil
+			}
+			return count, offset, fmt.Errorf("torn record header at offset %d", offset)
+		}
+		length := binary.LittleEndian.Uint32(header[0:4])
+		if length == 0 || length > maxRecordSize {
+			return count, offset, fmt.Errorf("invalid record length %d at offset %d", length, offset)
+		}
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(reader, payload); err != nil {
+			return count, offset, fmt.Errorf("torn record at offset %d", offset)
+		}
+		if crc32.Checksum(payload, crcTable) != binary.LittleEndian.Uint32(header[4:8]) {
+			return count, offset, fmt.Errorf("checksum mismatch at offset %d", offset)
+		}
+		var cmd types.Command
+		if err := json.Unmarshal(payload, &cmd); err != nil {
+			return count, offset, fmt.Errorf("undecodable record at offset %d: %w", offset, err)
+		}
+		if cmd.Sequence != first+count {
+			return count, offset, fmt.Errorf("record at offset %d has sequence %d, expected %d", offset, cmd.Sequence, first+count)
+		}
+		if fn != nil {
+			if err := fn(cmd); err != nil {
+				return count, offset, err
+			}
+		}
+		count++
+		offset += headerSize + int64(length)
+	}
+}
+
+// listSegments returns the journal's segments ordered by their first sequence number
+func listSegments(dir string) ([]segment, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal directory: %w", err)
+	}
+	var segments []segment
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, segmentSuffix) {
+			continue
+		}
+		first, err := strconv.ParseUint(strings.TrimSuffix(name, segmentSuffix), 10, 64)
+		if err != nil {
+			continue // Not a segment
+		}
+		segments = append(segments, segment{path: filepath.Join(dir, name), first: first})
+	}
+	sort.Slice(segments, func(a, b int) bool { return segments[a].first < segments[b].first })
+	return segments, nil
+}
+
+func segmentName(first uint64) string {
+	return fmt.Sprintf("%020d%s", first, segmentSuffix)
+}
+
+// syncDir fsyncs a directory so files created in it survive a crash
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("failed to open journal directory: %w", err)
+	}
+	defer d.Close()
+	if err := d.Sync(); err != nil {
+		return fmt.Errorf("failed to sync journal directory: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package journal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+func TestJournal(t *testing.T) {
+	t.Run("should truncate a torn final record", func(t *testing.T) {
+		dir := t.TempDir()
+		j, err := Open(dir, Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 3; i++ {
+			if _, err := j.Append(types.Command{Type: types.CommandReferencePrice, Stock: "AAPL", PriceCents: int64(15000 + i)}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		j.Close()
+
+		// A crash mid-write leaves half a record behind
+		segments, _ := filepath.Glob(filepath.Join(dir, "*.wal"))
+		file, err := os.OpenFile(segments[0], os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		file.Write([]byte{40, 0, 0, 0, 1, 2, 3, 4, '{', '"'})
+		file.Close()
+
+		reopened, err := Open(dir, Options{})
+		if err != nil {
+			t.Fatalf("expected the torn record to be dropped, got %v", err)
+		}
+		defer reopened.Close()
+		if reopened.LastSequence() != 3 {
+			t.Errorf("expected 3 records, got %d", reopened.LastSequence())
+		}
+		seq, err := reopened.Append(types.Command{Type: types.CommandReferencePrice, Stock: "AAPL", PriceCents: 16000})
+		if err != nil || seq != 4 {
+			t.Fatalf("expected sequence 4, got %d (%v)", seq, err)
+		}
+		var prices []int64
+		reopened.Replay(0, func(cmd types.Command) error {
+			prices = append(prices, cmd.PriceCents)
+			return nil
+		})
+		if fmt.Sprint(prices) != "[15000 15001 15002 16000]" {
+			t.Errorf("unexpected replayed prices %v", prices)
+		}
+	})
+
+	t.Run("should reject a corrupt record before the end", func(t *testing.T) {
+		dir := t.TempDir()
+		j, _ := Open(dir, Options{SegmentSize: 1})
+		for i := 0; i < 3; i++ {
+			j.Append(types.Command{Type: types.CommandStartAuction, Stock: "AAPL"})
+		}
+		j.Close()
+
+		segments, _ := filepath.Glob(filepath.Join(dir, "*.wal"))
+		data, _ := os.ReadFile(segments[0])
+		data[len(data)-2] ^= 0xff
+		os.WriteFile(segments[0], data, 0o644)
+
+		if _, err := Open(dir, Options{}); err == nil {
+			t.Error("expected corruption in a sealed segment to fail opening")
+		}
+	})
+
+	t.Run("should rotate segments and replay across them", func(t *testing.T) {
+		dir := t.TempDir()
+		j, err := Open(dir, Options{SegmentSize: 512})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var wg sync.WaitGroup
+		for i := 0; i < 40; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				j.Append(types.Command{Type: types.CommandReferencePrice, Stock: "AAPL", PriceCents: 15000})
+			}()
+		}
+		wg.Wait()
+		j.Close()
+
+		segments, _ := filepath.Glob(filepath.Join(dir, "*.wal"))
+		if len(segments) < 2 {
+			t.Fatalf("expected several segments, got %d", len(segments))
+		}
+		reopened, err := Open(dir, Options{SegmentSize: 512})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer reopened.Close()
+		var last uint64
+		err = reopened.Replay(10, func(cmd types.Command) error {
+			if cmd.Sequence != last+1 && last != 0 {
+				return fmt.Errorf("sequence %d after %d", cmd.Sequence, last)
+			}
+			last = cmd.Sequence
+			return nil
+		})
+		if err != nil || last != 40 {
+			t.Errorf("expected replay up to 40, got %d (%v)", last, err)
+		}
+	})
+
+	t.Run("should refuse appends after close", func(t *testing.T) {
+		j, err := Open(t.TempDir(), Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		j.Close()
+		if _, err := j.Append(types.Command{Type: types.CommandStartAuction, Stock: "AAPL"}); err != ErrClosed {
+			t.Errorf("expected ErrClosed, got %v", err)
+		}
+	})
+}
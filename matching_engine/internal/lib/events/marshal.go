@@ -10,7 +10,7 @@ import (
 
 // MarshalEvent wraps the event data in an envelope with metadata and serializes to JSON.
 // This function is safe to call from a background goroutine.
-func MarshalEvent(eventData any, eventType types.EventType) ([]byte, error) {
+func MarshalEvent(eventData any, eventType types.EventType, seq types.EventSequence) ([]byte, error) {
 	// Marshal the specific event data
 	dataBytes, err := json.Marshal(eventData)
 	if err != nil {
@@ -19,10 +19,11 @@ func MarshalEvent(eventData any, eventType types.EventType) ([]byte, error) {
 
 	// Wrap in envelope with metadata
 	envelope := types.Event{
-		EventID:   uuid.NewString(),
-		Timestamp: time.Now(),
-		Type:      eventType,
-		Data:      dataBytes,
+		EventID:       uuid.NewString(),
+		Timestamp:     time.Now(),
+		Type:          eventType,
+		EventSequence: seq,
+		Data:          dataBytes,
 	}
 
 	// Marshal the entire envelope
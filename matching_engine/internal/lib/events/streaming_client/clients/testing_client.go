@@ -12,7 +12,7 @@ type TestStreamingClient struct {
 func (*TestStreamingClient) IsHealthy(ctx context.Context) (bool, error) {
 	return true, nil
 }
-func (*TestStreamingClient) Publish(ctx context.Context, eventData any, eventType types.EventType) error {
+func (*TestStreamingClient) Publish(ctx context.Context, eventData any, eventType types.EventType, seq types.EventSequence) error {
 	return nil
 }
 
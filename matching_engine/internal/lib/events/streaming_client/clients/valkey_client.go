@@ -104,8 +104,8 @@ func (vc *ValkeyClient) IsHealthy(ctx context.Context) (bool, error) {
 }
 
 // Pushes events to the event chan and waits for the worker to fulfil the requests
-func (vc *ValkeyClient) Publish(ctx context.Context, eventData any, eventType types.EventType) error {
-	data, err := events.MarshalEvent(eventData, eventType)
+func (vc *ValkeyClient) Publish(ctx context.Context, eventData any, eventType types.EventType, seq types.EventSequence) error {
+	data, err := events.MarshalEvent(eventData, eventType, seq)
 	if err != nil {
 		return err
 	}
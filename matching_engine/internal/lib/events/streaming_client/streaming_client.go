@@ -14,7 +14,8 @@ type StreamingClient interface {
 
 	// Publish sends an event asynchronously. Returns immediately without blocking.
 	// The event will be serialized and sent to the stream by a background worker.
-	Publish(ctx context.Context, eventData any, eventType types.EventType) error
+	// Events must reach the stream in the order Publish is called.
+	Publish(ctx context.Context, eventData any, eventType types.EventType, seq types.EventSequence) error
 
 	// Close gracefully shuts down the client, flushing any pending events.
 	// Blocks until all buffered events are published or ctx is cancelled.
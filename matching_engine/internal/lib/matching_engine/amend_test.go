@@ -0,0 +1,234 @@
+package matchingengine
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/events/streaming_client/clients"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+func TestAmendOrder(t *testing.T) {
+	t.Run("should keep queue priority when reducing quantity", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 15000))
+		engine.SubmitOrder(newOrder("sell2", "AAPL", types.Sell, types.LimitOrder, 10, 15000))
+
+		_, found, err := engine.AmendOrder("sell1", 0, 4, 15000, 0, 0)
+		if err != nil || !found {
+			t.Fatalf("expected amend to succeed, got found=%v err=%v", found, err)
+		}
+
+		level := engine.getOrCreateOrderBook("AAPL").SellSide.GetBestLevel()
+		if level.Volume() != 14 {
+			t.Errorf("expected level volume 14, got %d", level.Volume())
+		}
+
+		matches, _, _ := engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 4, 15000))
+		if len(matches) != 1 || matches[0].SellerOrderId != "sell1" {
+			t.Errorf("expected reduced order to keep priority, got %+v", matches)
+		}
+	})
+
+	t.Run("should re-queue when increasing quantity", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 15000))
+		engine.SubmitOrder(newOrder("sell2", "AAPL", types.Sell, types.LimitOrder, 10, 15000))
+
+		engine.AmendOrder("sell1", 0, 20, 15000, 10, 0)
+
+		matches, _, _ := engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 5, 15000))
+		if len(matches) != 1 || matches[0].SellerOrderId != "sell2" {
+			t.Errorf("expected increased order to lose priority, got %+v", matches)
+		}
+	})
+
+	t.Run("should match when new price crosses", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 15000))
+		engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 10, 14900))
+
+		matches, found, err := engine.AmendOrder("buy1", 0, 10, 15000, 0, 1000)
+		if err != nil || !found {
+			t.Fatalf("expected amend to succeed, got found=%v err=%v", found, err)
+		}
+		if len(matches) != 1 || matches[0].Quantity != 10 || matches[0].PricePerStockCents != 15000 {
+			t.Errorf("expected amended order to trade 10 @ 15000, got %+v", matches)
+		}
+		book := engine.getOrCreateOrderBook("AAPL")
+		if !book.BuySide.IsEmpty() || !book.SellSide.IsEmpty() {
+			t.Error("expected book to be empty after amended order filled")
+		}
+	})
+
+	t.Run("should report missing orders", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 15000))
+
+		_, found, err := engine.AmendOrder("missing", 0, 5, 15000, 0, 0)
+		if err != nil || found {
+			t.Errorf("expected order not found, got found=%v err=%v", found, err)
+		}
+	})
+
+	t.Run("should reject invalid amendments without touching the order", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 15000))
+
+		_, _, err := engine.AmendOrder("sell1", 0, 0, 15000, 0, 0)
+		var rejection *RejectionError
+		if !errors.As(err, &rejection) || rejection.Code != types.ErrorCodeInvalidQuantity {
+			t.Errorf("expected invalid quantity rejection, got %v", err)
+		}
+
+		engine.SubmitOrder(newPostOnlyOrder("buy1", types.Buy, 10, 14000, false))
+		_, _, err = engine.AmendOrder("buy1", 0, 10, 15000, 0, 0)
+		if !errors.As(err, &rejection) || rejection.Code != types.ErrorCodePostOnlyWouldCross {
+			t.Errorf("expected post-only rejection, got %v", err)
+		}
+		if price, _ := engine.getOrCreateOrderBook("AAPL").BuySide.GetBestPrice(); price != 14000 {
+			t.Errorf("expected rejected amend to leave bid at 14000, got %d", price)
+		}
+	})
+
+	t.Run("should find orders by ID and refuse amendments by another trader", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+		msft := newOrder("sell1", "MSFT", types.Sell, types.LimitOrder, 10, 30000)
+		msft.TraderId = 1
+		engine.SubmitOrder(msft)
+
+		_, found, err := engine.AmendOrder("sell1", 2, 5, 30000, 0, 0)
+		var rejection *RejectionError
+		if !found || !errors.As(err, &rejection) || rejection.Code != types.ErrorCodeUnauthorized {
+			t.Fatalf("expected an unauthorized rejection, got found=%v err=%v", found, err)
+		}
+		if order, _ := engine.getOrCreateOrderBook("MSFT").SellSide.GetOrder("sell1"); order.Quantity != 10 {
+			t.Errorf("expected the order untouched, got quantity %d", order.Quantity)
+		}
+
+		if _, found, err := engine.AmendOrder("sell1", 1, 5, 30000, 0, 0); !found || err != nil {
+			t.Fatalf("expected the owner's amendment to succeed, got found=%v err=%v", found, err)
+		}
+		if order, _ := engine.getOrCreateOrderBook("MSFT").SellSide.GetOrder("sell1"); order.Quantity != 5 {
+			t.Errorf("expected quantity 5, got %d", order.Quantity)
+		}
+	})
+
+	t.Run("should reject buy increases the available balance doesn't cover", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+		engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 10, 15000))
+
+		// 12 @ 15000 costs 30000 more than 10 @ 15000
+		_, _, err := engine.AmendOrder("buy1", 0, 12, 15000, 0, 29999)
+		var rejection *RejectionError
+		if !errors.As(err, &rejection) || rejection.Code != types.ErrorCodeInsufficientFunds {
+			t.Fatalf("expected insufficient funds rejection, got %v", err)
+		}
+		if _, _, err := engine.AmendOrder("buy1", 0, 10, 15100, 0, 0); !errors.As(err, &rejection) || rejection.Code != types.ErrorCodeInsufficientFunds {
+			t.Fatalf("expected insufficient funds rejection for a higher price, got %v", err)
+		}
+		if _, _, err := engine.AmendOrder("buy1", 0, 12, 15000, 0, 30000); err != nil {
+			t.Fatalf("expected a covered increase to succeed, got %v", err)
+		}
+		// Cheaper amendments need no balance
+		if _, _, err := engine.AmendOrder("buy1", 0, 13, 13000, 0, 0); err != nil {
+			t.Fatalf("expected a cheaper amendment to succeed, got %v", err)
+		}
+	})
+
+	t.Run("should amend held stop orders", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+		tradeAt(engine, "t1", 1, 15000)
+		stopLimit := newStopOrder("stop1", "AAPL", types.Buy, types.StopLimitOrder, 10, 16000, 15500)
+		engine.SubmitOrder(stopLimit)
+		stopMarket := newStopOrder("stop2", "AAPL", types.Sell, types.StopMarketOrder, 10, 0, 14000)
+		engine.SubmitOrder(stopMarket)
+
+		if _, found, err := engine.AmendOrder("stop1", 0, 5, 15800, 0, 0); !found || err != nil {
+			t.Fatalf("expected the held stop-limit to be amended, got found=%v err=%v", found, err)
+		}
+		if stopLimit.Quantity != 5 || stopLimit.LimitPrice != 15800 || stopLimit.TriggerPrice != 15500 {
+			t.Errorf("expected 5 @ 15800 triggered at 15500, got %d @ %d triggered at %d", stopLimit.Quantity, stopLimit.LimitPrice, stopLimit.TriggerPrice)
+		}
+		_, _, err := engine.AmendOrder("stop2", 0, 5, 13000, 0, 0)
+		var rejection *RejectionError
+		if !errors.As(err, &rejection) || rejection.Code != types.ErrorCodeInvalidPrice {
+			t.Fatalf("expected a limit price on a stop-market to be rejected, got %v", err)
+		}
+		if _, found, err := engine.AmendOrder("stop2", 0, 5, 0, 0, 0); !found || err != nil || stopMarket.Quantity != 5 {
+			t.Fatalf("expected the held stop-market to be amended to 5, got found=%v err=%v quantity %d", found, err, stopMarket.Quantity)
+		}
+		book := engine.getOrCreateOrderBook("AAPL")
+		if book.Stops.Len() != 2 || !book.BuySide.IsEmpty() {
+			t.Error("expected both stops to still be held")
+		}
+
+		// The amended stop is released with its new quantity and limit price
+		engine.SubmitOrder(newOrder("ask", "AAPL", types.Sell, types.LimitOrder, 20, 15500))
+		tradeAt(engine, "t2", 1, 15500)
+		if book.Stops.Len() != 1 || !book.BuySide.IsEmpty() {
+			t.Fatal("expected the stop-limit to be released and filled")
+		}
+		// 20 asked and 1 more from the trade, less the trade and the released stop's 5
+		if volume := book.SellSide.GetBestLevel().Volume(); volume != 15 {
+			t.Errorf("expected 15 left on the ask, got %d", volume)
+		}
+	})
+
+	t.Run("should move the reserve of a held stop-market buy with its quantity", func(t *testing.T) {
+		streamer := &envelopeStreamer{}
+		engine := NewMatchingEngine(streamer)
+		stop := newStopOrder("stop1", "AAPL", types.Buy, types.StopMarketOrder, 10, 0, 15000)
+		engine.SubmitOrder(stop)
+
+		_, _, err := engine.AmendOrder("stop1", 0, 12, 0, 0, 20000)
+		var rejection *RejectionError
+		if !errors.As(err, &rejection) || rejection.Code != types.ErrorCodeInsufficientFunds {
+			t.Fatalf("expected an increase beyond the balance to be rejected, got %v", err)
+		}
+		if _, _, err := engine.AmendOrder("stop1", 0, 12, 0, 0, 30000); err != nil {
+			t.Fatalf("expected a covered increase to succeed, got %v", err)
+		}
+		if stop.AvailableBalance != 180000 {
+			t.Errorf("expected 180000 reserved for 12, got %d", stop.AvailableBalance)
+		}
+		if _, _, err := engine.AmendOrder("stop1", 0, 4, 0, 0, 0); err != nil {
+			t.Fatalf("expected a decrease to succeed, got %v", err)
+		}
+
+		var amended []types.OrderAmendedEvent
+		for _, evt := range streamer.events {
+			if evt.Type != types.OrderAmended {
+				continue
+			}
+			var e types.OrderAmendedEvent
+			if err := json.Unmarshal(evt.Data, &e); err != nil {
+				t.Fatal(err)
+			}
+			amended = append(amended, e)
+		}
+		if len(amended) != 2 || amended[0].ReservedCash != 180000 || amended[1].ReservedCash != 60000 {
+			t.Errorf("expected reserves of 180000 then 60000, got %+v", amended)
+		}
+	})
+
+	t.Run("should not raise a reserve held to the balance when the quantity falls", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+		stop := newStopOrder("stop1", "AAPL", types.Buy, types.StopMarketOrder, 10, 0, 15000)
+		stop.AvailableBalance = 50000
+		engine.SubmitOrder(stop)
+
+		if _, _, err := engine.AmendOrder("stop1", 0, 8, 0, 0, 0); err != nil {
+			t.Fatalf("expected a decrease to succeed, got %v", err)
+		}
+		if stop.AvailableBalance != 50000 {
+			t.Errorf("expected the reserve to stay at 50000, got %d", stop.AvailableBalance)
+		}
+	})
+}
@@ -0,0 +1,160 @@
+package matchingengine
+
+import (
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+// StartAuction puts a stock's book into auction mode: orders are collected without matching
+// until Uncross is called
+func (me *MatchingEngine) StartAuction(stock string) {
+	me.stateMu.RLock()
+	defer me.stateMu.RUnlock()
+	book := me.getOrCreateOrderBook(stock)
+	me.onBook(book, func() {
+		if err := me.record(types.Command{Type: types.CommandStartAuction, Stock: stock}); err != nil {
+			return
+		}
+		book.InAuction = true
+	})
+}
+
+// Uncross ends a stock's auction by executing every crossing order at the single price that
+// maximises volume, then returns the book to continuous matching. Returns the auction price
+// and the executions; the price is 0 if nothing crossed.
+func (me *MatchingEngine) Uncross(stock string, closing bool) (int64, []types.MatchedEvent) {
+	me.stateMu.RLock()
+	defer me.stateMu.RUnlock()
+	book := me.getOrCreateOrderBook(stock)
+	var price int64
+	var matches []types.MatchedEvent
+	me.onBook(book, func() {
+		if err := me.record(types.Command{Type: types.CommandUncross, Stock: stock, Closing: closing}); err != nil {
+			return
+		}
+		price, matches = me.uncross(book, stock, closing)
+	})
+	return price, matches
+}
+
+// uncross executes the book's auction. Must be called with the book lock held.
+func (me *MatchingEngine) uncross(book *types.StockOrderBook, stock string, closing bool) (int64, []types.MatchedEvent) {
+	price, _ := book.EquilibriumPrice()
+	var matches []types.MatchedEvent
+	var volume int64
+	now := me.clock()
+
+	for price > 0 {
+		bidLevel := book.BuySide.GetBestLevel()
+		askLevel := book.SellSide.GetBestLevel()
+		if bidLevel == nil || askLevel == nil || bidLevel.Price() < price || askLevel.Price() > price {
+			break
+		}
+		buyOrder := bidLevel.Front()
+		sellOrder := askLevel.Front()
+
+		if me.preventAuctionSelfTrade(book, buyOrder, sellOrder, price) {
+			continue
+		}
+
+		matchQty := min(buyOrder.VisibleQuantity(), sellOrder.VisibleQuantity())
+		originalBuyQty, originalSellQty := buyOrder.Quantity, sellOrder.Quantity
+		matches = append(matches, types.MatchedEvent{
+			BuyerOrderId:       buyOrder.OrderId,
+			SellerOrderId:      sellOrder.OrderId,
+			PricePerStockCents: price,
+			Quantity:           matchQty,
+			Timestamp:          now,
+		})
+		if me.eventStreamer != nil {
+			me.safePublish(book, &types.TradeExecutedEvent{
+				StockTicker:     stock,
+				BuyerOrderID:    buyOrder.OrderId,
+				SellerOrderID:   sellOrder.OrderId,
+				BuyerOrderType:  buyOrder.OrderType,
+				BuyerTraderID:   buyOrder.TraderId,
+				SellerTraderID:  sellOrder.TraderId,
+				Quantity:        matchQty,
+				PriceCents:      price,
+				TotalValueCents: price * matchQty,
+			}, types.TradeExecuted)
+		}
+		if book.BuySide.FillOrder(buyOrder, matchQty) {
+			me.orders.remove(buyOrder.OrderId)
+		}
+		if book.SellSide.FillOrder(sellOrder, matchQty) {
+			me.orders.remove(sellOrder.OrderId)
+		}
+		me.publishAuctionFill(book, buyOrder, originalBuyQty, matchQty, price)
+		me.publishAuctionFill(book, sellOrder, originalSellQty, matchQty, price)
+		volume += matchQty
+	}
+
+	me.publishTrailed(book, book.EndAuction(price, volume))
+	if volume == 0 {
+		price = 0 // Nothing traded, so there is no auction price to report
+	}
+
+	if me.eventStreamer != nil {
+		me.safePublish(book, &types.AuctionUncrossedEvent{
+			StockTicker: stock,
+			PriceCents:  price,
+			Volume:      volume,
+			Closing:     closing,
+		}, types.AuctionUncrossed)
+	}
+
+	// The auction price may have crossed the trigger of held stop orders
+	me.releaseTriggeredStops(book)
+
+	return price, matches
+}
+
+// publishAuctionFill emits the fill event for one side of an auction execution
+func (me *MatchingEngine) publishAuctionFill(book *types.StockOrderBook, order *types.Order, originalQty, matchQty, price int64) {
+	if me.eventStreamer == nil {
+		return
+	}
+	if order.Quantity == 0 {
+		me.safePublish(book, &types.OrderFilledEvent{
+			OrderID:        order.OrderId,
+			TraderID:       order.TraderId,
+			Quantity:       originalQty,
+			FillPriceCents: price,
+		}, types.OrderFilled)
+		return
+	}
+	me.safePublish(book, &types.OrderPartiallyFilledEvent{
+		OrderID:           order.OrderId,
+		TraderID:          order.TraderId,
+		FilledQuantity:    matchQty,
+		RemainingQuantity: order.Quantity,
+		FillPriceCents:    price,
+	}, types.OrderPartiallyFilled)
+}
+
+// preventAuctionSelfTrade applies self-trade prevention when both orders at the front of the
+// crossing levels belong to the same trader or owner group. The later of the two is treated
+// as the incoming order. Returns false if the orders may trade.
+// Must be called with the book lock held.
+func (me *MatchingEngine) preventAuctionSelfTrade(book *types.StockOrderBook, buyOrder, sellOrder *types.Order, price int64) bool {
+	incoming, resting := buyOrder, sellOrder
+	if sellOrder.Timestamp.After(buyOrder.Timestamp) {
+		incoming, resting = sellOrder, buyOrder
+	}
+	incomingSide, restingSide := book.Side(incoming.OrderSide), book.Side(resting.OrderSide)
+
+	mode := me.selfTradeMode(incoming, resting)
+	if mode == types.SelfTradeAllow {
+		return false
+	}
+
+	decrement, stop := me.preventSelfTrade(book, restingSide, incoming, resting, incoming.Quantity, price, mode)
+	if stop {
+		me.cancelResting(book, incomingSide, incoming)
+	} else if decrement > 0 {
+		oldQuantity := incoming.Quantity
+		incomingSide.ReduceOrder(incoming.OrderId, oldQuantity-decrement)
+		me.publishAmended(book, incoming, oldQuantity, incoming.LimitPrice, false)
+	}
+	return true
+}
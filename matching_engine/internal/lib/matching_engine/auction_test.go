@@ -0,0 +1,149 @@
+package matchingengine
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/events/streaming_client/clients"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+func TestCallAuctions(t *testing.T) {
+	t.Run("should collect crossing orders without matching", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+		engine.StartAuction("AAPL")
+
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 14000))
+		matches, remaining, err := engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 10, 15000))
+		if err != nil || len(matches) != 0 || remaining != 10 {
+			t.Errorf("expected order to rest without trading, got %d matches, remaining %d, err %v", len(matches), remaining, err)
+		}
+
+		book := engine.getOrCreateOrderBook("AAPL")
+		if book.BuySide.GetBestLevel().Volume() != 10 || book.SellSide.GetBestLevel().Volume() != 10 {
+			t.Error("expected both orders on the book")
+		}
+	})
+
+	t.Run("should reject orders that can't rest during an auction", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+		engine.StartAuction("AAPL")
+
+		_, _, err := engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.MarketOrder, 10, 0))
+		var rejection *RejectionError
+		if !errors.As(err, &rejection) || rejection.Code != types.ErrorCodeInvalidOrderType {
+			t.Errorf("expected invalid order type rejection, got %v", err)
+		}
+	})
+
+	t.Run("should uncross at the price maximising volume", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+		engine.StartAuction("AAPL")
+
+		engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 10, 10300))
+		engine.SubmitOrder(newOrder("buy2", "AAPL", types.Buy, types.LimitOrder, 20, 10100))
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 15, 10000))
+		engine.SubmitOrder(newOrder("sell2", "AAPL", types.Sell, types.LimitOrder, 15, 10100))
+		engine.SubmitOrder(newOrder("sell3", "AAPL", types.Sell, types.LimitOrder, 15, 10200))
+
+		// 15 can trade at 10000, 30 at 10100 and 10 at 10200 or above
+		price, matches := engine.Uncross("AAPL", false)
+		if price != 10100 {
+			t.Errorf("expected auction price 10100, got %d", price)
+		}
+
+		var volume int64
+		for _, match := range matches {
+			volume += match.Quantity
+			if match.PricePerStockCents != price {
+				t.Errorf("expected every execution at %d, got %d", price, match.PricePerStockCents)
+			}
+		}
+		if volume != 30 {
+			t.Errorf("expected volume 30, got %d", volume)
+		}
+
+		book := engine.getOrCreateOrderBook("AAPL")
+		if book.InAuction || book.LastTradePrice != 10100 {
+			t.Errorf("expected continuous trading at last price 10100, got auction %v, price %d", book.InAuction, book.LastTradePrice)
+		}
+		if !book.BuySide.IsEmpty() || book.SellSide.GetBestLevel().Volume() != 15 {
+			t.Error("expected only the unmatched sell to stay on the book")
+		}
+	})
+
+	t.Run("should break volume ties by the smallest imbalance", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+		engine.StartAuction("AAPL")
+
+		engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 10, 10200))
+		engine.SubmitOrder(newOrder("buy2", "AAPL", types.Buy, types.LimitOrder, 5, 10000))
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 10000))
+
+		// Volume is 10 at both prices; 10200 leaves no imbalance
+		if price, _ := engine.Uncross("AAPL", false); price != 10200 {
+			t.Errorf("expected auction price 10200, got %d", price)
+		}
+	})
+
+	t.Run("should resume continuous matching when nothing crosses", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+		engine.StartAuction("AAPL")
+
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 15000))
+		if price, matches := engine.Uncross("AAPL", true); price != 0 || len(matches) != 0 {
+			t.Errorf("expected no executions, got price %d with %d matches", price, len(matches))
+		}
+
+		matches, _, _ := engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 10, 15000))
+		if len(matches) != 1 {
+			t.Errorf("expected continuous match after the auction, got %d", len(matches))
+		}
+	})
+
+	t.Run("should report no price for an auction that doesn't trade", func(t *testing.T) {
+		streamer := &envelopeStreamer{}
+		engine := NewMatchingEngine(streamer)
+		tradeAt(engine, "open", 1, 10000)
+		engine.StartAuction("AAPL")
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 15000))
+		streamer.events = nil
+
+		engine.Uncross("AAPL", true)
+
+		var uncrossed types.AuctionUncrossedEvent
+		if len(streamer.events) != 1 || json.Unmarshal(streamer.events[0].Data, &uncrossed) != nil {
+			t.Fatalf("expected one AuctionUncrossedEvent, got %d events", len(streamer.events))
+		}
+		if uncrossed.PriceCents != 0 || uncrossed.Volume != 0 {
+			t.Errorf("expected no price or volume, got price %d, volume %d", uncrossed.PriceCents, uncrossed.Volume)
+		}
+		// The stock's last trade is kept for the next auction's tie-break
+		if book := engine.getOrCreateOrderBook("AAPL"); book.LastTradePrice != 10000 {
+			t.Errorf("expected last trade price 10000, got %d", book.LastTradePrice)
+		}
+	})
+
+	t.Run("should release held stops at the auction price", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+		tradeAt(engine, "open", 1, 10000)
+		engine.StartAuction("AAPL")
+
+		stop := newOrder("stop1", "AAPL", types.Buy, types.StopLimitOrder, 5, 11000)
+		stop.TriggerPrice = 10500
+		engine.SubmitOrder(stop)
+		engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 5, 11000))
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 11000))
+
+		engine.Uncross("AAPL", false)
+
+		book := engine.getOrCreateOrderBook("AAPL")
+		if book.Stops.Len() != 0 {
+			t.Error("expected the stop to be released by the auction price")
+		}
+		if !book.SellSide.IsEmpty() {
+			t.Error("expected the released stop to take the remaining sell quantity")
+		}
+	})
+}
@@ -0,0 +1,14 @@
+package matchingengine
+
+import "github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+
+// RejectionError is returned when the engine rejects an order for a business reason.
+// An OrderRejectedEvent with the same code has already been published.
+type RejectionError struct {
+	Code    types.ErrorCode
+	Message string
+}
+
+func (e *RejectionError) Error() string {
+	return e.Message
+}
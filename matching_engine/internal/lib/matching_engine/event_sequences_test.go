@@ -0,0 +1,143 @@
+package matchingengine
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/journal"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/events/streaming_client/clients"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/snapshots"
+)
+
+// recordingStreamer keeps the sequence numbers of every published event
+type recordingStreamer struct {
+	clients.TestStreamingClient
+	mu        sync.Mutex
+	sequences []types.EventSequence
+}
+
+func (r *recordingStreamer) Publish(ctx context.Context, eventData any, eventType types.EventType, seq types.EventSequence) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sequences = append(r.sequences, seq)
+	return nil
+}
+
+func TestEventSequences(t *testing.T) {
+	t.Run("should number events without gaps globally and per stock", func(t *testing.T) {
+		streamer := &recordingStreamer{}
+		engine := NewMatchingEngine(streamer)
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 15000))
+		engine.SubmitOrder(newOrder("sell2", "MSFT", types.Sell, types.LimitOrder, 10, 30000))
+		engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 10, 15000))
+		engine.CancelOrder("sell2", 0)
+
+		stockSequences := make(map[string]int64)
+		for i, seq := range streamer.sequences {
+			if seq.Sequence != int64(i+1) {
+				t.Fatalf("expected global sequence %d, got %d", i+1, seq.Sequence)
+			}
+			stockSequences[seq.StockTicker]++
+			if seq.StockSequence != stockSequences[seq.StockTicker] {
+				t.Fatalf("expected %s sequence %d, got %d", seq.StockTicker, stockSequences[seq.StockTicker], seq.StockSequence)
+			}
+		}
+		if stockSequences["MSFT"] != 2 {
+			t.Errorf("expected 2 MSFT events, got %d", stockSequences["MSFT"])
+		}
+		if engine.getOrCreateOrderBook("AAPL").Sequence != stockSequences["AAPL"] {
+			t.Error("expected the book to remember its last sequence number")
+		}
+	})
+
+	t.Run("should stamp every event with the epoch of the engine's run", func(t *testing.T) {
+		streamer := &recordingStreamer{}
+		engine := NewMatchingEngine(streamer)
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 15000))
+		engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 10, 15000))
+
+		for _, seq := range streamer.sequences {
+			if seq.Epoch == "" || seq.Epoch != engine.epoch {
+				t.Fatalf("expected epoch %q, got %q", engine.epoch, seq.Epoch)
+			}
+		}
+		if NewMatchingEngine(nil).epoch == engine.epoch {
+			t.Error("expected every engine run to get its own epoch")
+		}
+	})
+
+	t.Run("should leave the stock sequence out for orders that never reach a book", func(t *testing.T) {
+		streamer := &recordingStreamer{}
+		engine := NewMatchingEngine(streamer)
+		engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 0, 15000))
+
+		if len(streamer.sequences) != 1 {
+			t.Fatalf("expected one rejection, got %d events", len(streamer.sequences))
+		}
+		if seq := streamer.sequences[0]; seq.Sequence != 1 || seq.StockTicker != "" || seq.StockSequence != 0 {
+			t.Errorf("expected only a global sequence, got %+v", seq)
+		}
+	})
+
+	t.Run("should carry on numbering after recovering from a snapshot and the journal", func(t *testing.T) {
+		journalDir, snapshotDir := t.TempDir(), t.TempDir()
+		j, _ := journal.Open(journalDir, journal.Options{})
+		store, _ := snapshots.Open(snapshotDir, snapshots.Options{})
+		streamer := &recordingStreamer{}
+		engine := NewMatchingEngine(streamer, WithJournal(j), WithSnapshots(store), WithSequencers(8))
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 15000))
+		engine.SubmitOrder(newOrder("sell2", "MSFT", types.Sell, types.LimitOrder, 10, 30000))
+		if _, err := engine.SaveSnapshot(); err != nil {
+			t.Fatal(err)
+		}
+		engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 4, 15000))
+		engine.CancelOrder("sell2", 0)
+		engine.Close()
+		j.Close()
+		last := streamer.sequences[len(streamer.sequences)-1]
+
+		reopened, _ := journal.Open(journalDir, journal.Options{})
+		defer reopened.Close()
+		after := &recordingStreamer{}
+		recovered := NewMatchingEngine(after, WithJournal(reopened), WithSnapshots(store), WithSequencers(8))
+		if _, err := recovered.Recover(); err != nil {
+			t.Fatal(err)
+		}
+		if len(after.sequences) != 0 {
+			t.Fatalf("expected replayed events to stay off the stream, got %d", len(after.sequences))
+		}
+		recovered.SubmitOrder(newOrder("sell3", "MSFT", types.Sell, types.LimitOrder, 1, 30000))
+		recovered.Close()
+		if len(after.sequences) != 1 {
+			t.Fatalf("expected one event after recovery, got %d", len(after.sequences))
+		}
+		if seq := after.sequences[0]; seq.Sequence != last.Sequence+1 || seq.StockSequence != 3 {
+			t.Errorf("expected sequence %d and MSFT sequence 3, got %+v", last.Sequence+1, seq)
+		}
+	})
+
+	t.Run("should keep global sequence numbers unique under concurrency", func(t *testing.T) {
+		streamer := &recordingStreamer{}
+		engine := NewMatchingEngine(streamer)
+		var wg sync.WaitGroup
+		for _, stock := range []string{"AAPL", "MSFT", "GOOG", "AMZN"} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range 50 {
+					engine.SubmitOrder(newOrder(fmt.Sprintf("%s-%d", stock, i), stock, types.Buy, types.LimitOrder, 1, 10000))
+				}
+			}()
+		}
+		wg.Wait()
+
+		for i, seq := range streamer.sequences {
+			if seq.Sequence != int64(i+1) {
+				t.Fatalf("expected events to reach the stream in sequence order, got %d at %d", seq.Sequence, i+1)
+			}
+		}
+	})
+}
@@ -0,0 +1,82 @@
+package matchingengine
+
+import (
+	"time"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+// halt stops trading in a stock after a trade at price would have broken a price band.
+// The book collects orders as in an auction until ResumeHalted reopens it.
+// Must be called with the book lock held.
+func (me *MatchingEngine) halt(book *types.StockOrderBook, stock string, reason types.HaltReason, price int64) {
+	book.Halt(me.clock().Add(book.Bands.HaltDuration))
+
+	if me.eventStreamer != nil {
+		me.safePublish(book, &types.TradingHaltedEvent{
+			StockTicker:         stock,
+			Reason:              reason,
+			PriceCents:          price,
+			ReferencePriceCents: book.ReferencePrice,
+			LastTradePriceCents: book.LastTradePrice,
+			HaltedUntil:         book.HaltedUntil,
+		}, types.TradingHalted)
+	}
+}
+
+// ResumeHalted reopens every stock whose halt has ended with an auction of the orders left
+// on its book. Returns the number of stocks resumed.
+func (me *MatchingEngine) ResumeHalted(now time.Time) int {
+	me.stateMu.RLock()
+	defer me.stateMu.RUnlock()
+	resumed := 0
+	auction := me.session.current().CollectsOrders()
+	me.orderBooks.Range(func(key, value any) bool {
+		book, ok := value.(*types.StockOrderBook)
+		if !ok {
+			return true
+		}
+		stock := key.(string)
+
+		me.onBook(book, func() {
+			if !book.IsHalted() || now.Before(book.HaltedUntil) {
+				return
+			}
+			if err := me.record(types.Command{Type: types.CommandResume, Stock: stock, Time: now}); err != nil {
+				return // Retried on the next sweep
+			}
+			me.resume(book, stock, auction)
+			resumed++
+		})
+		return true
+	})
+	return resumed
+}
+
+// resume ends a book's halt with an auction of the orders left on it. With auction set the
+// book goes on collecting orders for the session's auction. Must be called with the book lock held.
+func (me *MatchingEngine) resume(book *types.StockOrderBook, stock string, auction bool) {
+	book.HaltedUntil = time.Time{}
+	me.uncross(book, stock, false)
+	book.InAuction = auction
+	if me.eventStreamer != nil {
+		me.safePublish(book, &types.TradingResumedEvent{
+			StockTicker: stock,
+			PriceCents:  book.LastTradePrice,
+		}, types.TradingResumed)
+	}
+}
+
+// SetReferencePrice sets the price a stock's static price band is measured from,
+// usually the previous close. Auctions replace it with their uncrossing price.
+func (me *MatchingEngine) SetReferencePrice(stock string, price int64) {
+	me.stateMu.RLock()
+	defer me.stateMu.RUnlock()
+	book := me.getOrCreateOrderBook(stock)
+	me.onBook(book, func() {
+		if err := me.record(types.Command{Type: types.CommandReferencePrice, Stock: stock, PriceCents: price}); err != nil {
+			return
+		}
+		book.ReferencePrice = price
+	})
+}
@@ -0,0 +1,139 @@
+package matchingengine
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/events/streaming_client/clients"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+func TestPriceBands(t *testing.T) {
+	bands := types.PriceBands{StaticBps: 1000, DynamicBps: 500, HaltDuration: time.Minute}
+
+	t.Run("should trade within the bands", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithPriceBands(bands))
+		engine.SetReferencePrice("AAPL", 10000)
+		tradeAt(engine, "t1", 1, 10000)
+
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 10500))
+		matches, _, err := engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 10, 10500))
+		if err != nil || len(matches) != 1 {
+			t.Errorf("expected a trade at the edge of the band, got %d matches, err %v", len(matches), err)
+		}
+	})
+
+	t.Run("should halt instead of trading outside the dynamic band", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithPriceBands(bands))
+		tradeAt(engine, "t1", 1, 10000)
+
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 10600))
+		matches, remaining, _ := engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 10, 10600))
+		if len(matches) != 0 || remaining != 10 {
+			t.Errorf("expected no trade, got %d matches, remaining %d", len(matches), remaining)
+		}
+
+		book := engine.getOrCreateOrderBook("AAPL")
+		if !book.IsHalted() {
+			t.Fatal("expected the stock to be halted")
+		}
+		if book.BuySide.GetBestLevel().Volume() != 10 {
+			t.Error("expected the limit buy to rest for the reopening auction")
+		}
+	})
+
+	t.Run("should halt outside the static band", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithPriceBands(types.PriceBands{StaticBps: 1000, HaltDuration: time.Minute}))
+		engine.SetReferencePrice("AAPL", 10000)
+
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 11100))
+		engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 10, 11100))
+
+		if !engine.getOrCreateOrderBook("AAPL").IsHalted() {
+			t.Error("expected the stock to be halted")
+		}
+	})
+
+	t.Run("should stop a sweeping market order at the band", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithPriceBands(bands))
+		tradeAt(engine, "t1", 1, 10000)
+
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 5, 10100))
+		engine.SubmitOrder(newOrder("sell2", "AAPL", types.Sell, types.LimitOrder, 5, 20000))
+
+		buy := newOrder("buy1", "AAPL", types.Buy, types.MarketOrder, 10, 0)
+		buy.AvailableBalance = 1000000
+		matches, remaining, _ := engine.SubmitOrder(buy)
+		if len(matches) != 1 || remaining != 5 {
+			t.Errorf("expected to fill only within the band, got %d matches, remaining %d", len(matches), remaining)
+		}
+		if !engine.getOrCreateOrderBook("AAPL").IsHalted() {
+			t.Error("expected the stock to be halted")
+		}
+	})
+
+	t.Run("should reject orders and amends during a halt", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithPriceBands(bands))
+		tradeAt(engine, "t1", 1, 10000)
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 20000))
+		engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 10, 20000))
+
+		_, _, err := engine.SubmitOrder(newOrder("buy2", "AAPL", types.Buy, types.LimitOrder, 10, 10000))
+		var rejection *RejectionError
+		if !errors.As(err, &rejection) || rejection.Code != types.ErrorCodeStockNotTrading {
+			t.Errorf("expected stock not trading rejection, got %v", err)
+		}
+
+		_, _, err = engine.AmendOrder("buy1", 0, 5, 20000, 0, 0)
+		if !errors.As(err, &rejection) || rejection.Code != types.ErrorCodeStockNotTrading {
+			t.Errorf("expected stock not trading rejection for the amend, got %v", err)
+		}
+
+		if found, _ := engine.CancelOrder("sell1", 0); !found {
+			t.Error("expected cancels to be allowed during a halt")
+		}
+	})
+
+	t.Run("should reopen with an auction when the halt ends", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithPriceBands(bands))
+		tradeAt(engine, "t1", 1, 10000)
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 12000))
+		engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 10, 12000))
+
+		if resumed := engine.ResumeHalted(time.Now()); resumed != 0 {
+			t.Errorf("expected nothing to resume before the halt ends, got %d", resumed)
+		}
+		if resumed := engine.ResumeHalted(time.Now().Add(time.Minute)); resumed != 1 {
+			t.Errorf("expected 1 resumed stock, got %d", resumed)
+		}
+
+		book := engine.getOrCreateOrderBook("AAPL")
+		if book.IsHalted() || book.InAuction {
+			t.Error("expected continuous trading after the halt")
+		}
+		if book.LastTradePrice != 12000 || book.ReferencePrice != 12000 {
+			t.Errorf("expected the reopening auction to trade at 12000, got last %d, reference %d", book.LastTradePrice, book.ReferencePrice)
+		}
+		if !book.BuySide.IsEmpty() || !book.SellSide.IsEmpty() {
+			t.Error("expected the crossed orders to trade in the reopening auction")
+		}
+	})
+
+	t.Run("should cancel fill-or-kill orders that would reach outside the band", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithPriceBands(bands))
+		tradeAt(engine, "t1", 1, 10000)
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 5, 10100))
+		engine.SubmitOrder(newOrder("sell2", "AAPL", types.Sell, types.LimitOrder, 5, 20000))
+
+		buy := newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 10, 20000)
+		buy.TimeInForce = types.FillOrKill
+		matches, _, _ := engine.SubmitOrder(buy)
+		if len(matches) != 0 {
+			t.Errorf("expected no trades, got %d", len(matches))
+		}
+		if engine.getOrCreateOrderBook("AAPL").IsHalted() {
+			t.Error("expected the cancelled order not to halt the stock")
+		}
+	})
+}
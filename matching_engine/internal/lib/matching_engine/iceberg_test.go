@@ -0,0 +1,98 @@
+package matchingengine
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/events/streaming_client/clients"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+// Helper to create an iceberg limit order
+func newIcebergOrder(id string, side types.OrderSide, qty, price, display int64) *types.Order {
+	order := newOrder(id, "AAPL", side, types.LimitOrder, qty, price)
+	order.DisplayQuantity = display
+	return order
+}
+
+func TestIcebergOrders(t *testing.T) {
+	t.Run("should show only the display quantity", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+
+		engine.SubmitOrder(newIcebergOrder("sell1", types.Sell, 100, 15000, 10))
+
+		level := engine.getOrCreateOrderBook("AAPL").SellSide.GetBestLevel()
+		if level.Volume() != 10 {
+			t.Errorf("expected visible volume 10, got %d", level.Volume())
+		}
+		if level.TotalVolume() != 100 {
+			t.Errorf("expected total volume 100, got %d", level.TotalVolume())
+		}
+	})
+
+	t.Run("should refill slice and lose time priority", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+
+		engine.SubmitOrder(newIcebergOrder("sell1", types.Sell, 30, 15000, 10))
+		engine.SubmitOrder(newOrder("sell2", "AAPL", types.Sell, types.LimitOrder, 5, 15000))
+
+		matches, _, _ := engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 12, 15000))
+
+		if len(matches) != 2 {
+			t.Fatalf("expected 2 matches, got %d", len(matches))
+		}
+		if matches[0].SellerOrderId != "sell1" || matches[0].Quantity != 10 {
+			t.Errorf("expected first fill of 10 from iceberg slice, got %+v", matches[0])
+		}
+		if matches[1].SellerOrderId != "sell2" || matches[1].Quantity != 2 {
+			t.Errorf("expected refilled iceberg to queue behind sell2, got %+v", matches[1])
+		}
+
+		level := engine.getOrCreateOrderBook("AAPL").SellSide.GetBestLevel()
+		if level.Volume() != 13 || level.TotalVolume() != 23 {
+			t.Errorf("expected visible 13 and total 23, got %d and %d", level.Volume(), level.TotalVolume())
+		}
+	})
+
+	t.Run("should sweep hidden quantity when no other orders rest", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+
+		engine.SubmitOrder(newIcebergOrder("sell1", types.Sell, 25, 15000, 10))
+
+		_, remaining, _ := engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 25, 15000))
+		if remaining != 0 {
+			t.Errorf("expected buy fully filled, got %d remaining", remaining)
+		}
+		if !engine.getOrCreateOrderBook("AAPL").SellSide.IsEmpty() {
+			t.Error("expected iceberg fully consumed")
+		}
+	})
+
+	t.Run("should count hidden quantity for FOK", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+
+		engine.SubmitOrder(newIcebergOrder("sell1", types.Sell, 50, 15000, 10))
+
+		_, remaining, _ := engine.SubmitOrder(newTIFOrder("buy1", types.Buy, 40, 15000, types.FillOrKill))
+		if remaining != 0 {
+			t.Errorf("expected FOK to fill against hidden quantity, got %d remaining", remaining)
+		}
+	})
+
+	t.Run("should reject invalid display quantity", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+
+		_, _, err := engine.SubmitOrder(newIcebergOrder("sell1", types.Sell, 10, 15000, 20))
+		var rejection *RejectionError
+		if !errors.As(err, &rejection) || rejection.Code != types.ErrorCodeInvalidQuantity {
+			t.Errorf("expected invalid quantity rejection, got %v", err)
+		}
+
+		order := newMarketBuyOrder("buy1", "AAPL", 10, 1000000)
+		order.DisplayQuantity = 5
+		_, _, err = engine.SubmitOrder(order)
+		if !errors.As(err, &rejection) || rejection.Code != types.ErrorCodeInvalidOrderType {
+			t.Errorf("expected invalid order type rejection, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package matchingengine
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+// instrumentRegistry holds the stocks the engine trades. Until it is seeded with
+// WithInstruments every ticker is accepted, with the default spec unless one was set.
+type instrumentRegistry struct {
+	mu          sync.RWMutex
+	instruments map[string]types.Instrument
+	enforced    bool // Reject tickers that aren't registered
+}
+
+// lookup returns the instrument for a ticker, or false if orders for it must be rejected as unknown
+func (r *instrumentRegistry) lookup(stock string) (types.Instrument, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if instrument, ok := r.instruments[stock]; ok {
+		return instrument, true
+	}
+	if r.enforced {
+		return types.Instrument{}, false
+	}
+	return types.Instrument{Ticker: stock, Spec: types.DefaultInstrumentSpec, Active: true}, true
+}
+
+func (r *instrumentRegistry) put(instrument types.Instrument) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.instruments == nil {
+		r.instruments = make(map[string]types.Instrument)
+	}
+	r.instruments[instrument.Ticker] = instrument
+}
+
+// registered reports whether a ticker was added to the registry
+func (r *instrumentRegistry) registered(stock string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.instruments[stock]
+	return ok
+}
+
+// deactivate marks a registered instrument inactive
+func (r *instrumentRegistry) deactivate(stock string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if instrument, ok := r.instruments[stock]; ok {
+		instrument.Active = false
+		r.instruments[stock] = instrument
+	}
+}
+
+// Instruments lists the registered instruments by ticker
+func (me *MatchingEngine) Instruments() []types.Instrument {
+	me.registry.mu.RLock()
+	defer me.registry.mu.RUnlock()
+	instruments := make([]types.Instrument, 0, len(me.registry.instruments))
+	for _, instrument := range me.registry.instruments {
+		instruments = append(instruments, instrument)
+	}
+	sort.Slice(instruments, func(i, j int) bool {
+		return instruments[i].Ticker < instruments[j].Ticker
+	})
+	return instruments
+}
+
+// LookupInstrument returns the instrument orders for a ticker are checked against,
+// or false if the ticker is unknown
+func (me *MatchingEngine) LookupInstrument(stock string) (types.Instrument, bool) {
+	return me.registry.lookup(stock)
+}
+
+// AddInstrument registers a stock, or replaces its spec and status if already registered.
+// The stock's book picks up the new spec for its next order. Returns an
+// ErrorCodeInternalError rejection if the change can't be journaled.
+func (me *MatchingEngine) AddInstrument(instrument types.Instrument) error {
+	me.stateMu.RLock()
+	defer me.stateMu.RUnlock()
+
+	book := me.getOrCreateOrderBook(instrument.Ticker)
+	var err error
+	me.onBook(book, func() {
+		if err = me.record(types.Command{Type: types.CommandAddInstrument, Stock: instrument.Ticker, Instrument: &instrument}); err != nil {
+			return
+		}
+		me.registry.put(instrument)
+		book.Spec = instrument.Spec
+	})
+	if err != nil {
+		return &RejectionError{Code: types.ErrorCodeInternalError, Message: "The instrument could not be recorded"}
+	}
+	return nil
+}
+
+// DeactivateInstrument stops a stock from accepting new orders. Resting orders stay on the
+// book and can still be cancelled. Returns false if the ticker is not registered, and an
+// ErrorCodeInternalError rejection if the change can't be journaled.
+func (me *MatchingEngine) DeactivateInstrument(stock string) (bool, error) {
+	me.stateMu.RLock()
+	defer me.stateMu.RUnlock()
+	if !me.registry.registered(stock) {
+		return false, nil
+	}
+
+	book := me.getOrCreateOrderBook(stock)
+	var err error
+	me.onBook(book, func() {
+		if err = me.record(types.Command{Type: types.CommandDeactivate, Stock: stock}); err != nil {
+			return
+		}
+		me.registry.deactivate(stock)
+	})
+	if err != nil {
+		return true, &RejectionError{Code: types.ErrorCodeInternalError, Message: "The deactivation could not be recorded"}
+	}
+	return true, nil
+}
+
+// checkInstrument rejects orders for unknown or inactive stocks
+func (me *MatchingEngine) checkInstrument(order *types.Order) error {
+	instrument, ok := me.registry.lookup(order.StockTicker)
+	if !ok {
+		return me.reject(nil, order, types.ErrorCodeStockNotFound, "Unknown stock", "Stock "+order.StockTicker+" does not exist")
+	}
+	if !instrument.Active {
+		return me.reject(nil, order, types.ErrorCodeStockNotTrading, "Stock not trading", "Stock "+order.StockTicker+" is not trading")
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package matchingengine
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/events/streaming_client/clients"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+func TestInstrumentSpecs(t *testing.T) {
+	spec := types.InstrumentSpec{TickSize: 5, LotSize: 10, MinQuantity: 20}
+
+	t.Run("should accept orders on the tick and lot grid", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithInstrumentSpec("AAPL", spec))
+
+		if _, _, err := engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 30, 15005)); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("should reject prices off the tick grid", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithInstrumentSpec("AAPL", spec))
+
+		_, _, err := engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 30, 15003))
+		var rejection *RejectionError
+		if !errors.As(err, &rejection) || rejection.Code != types.ErrorCodeInvalidPrice {
+			t.Errorf("expected invalid price rejection, got %v", err)
+		}
+
+		stop := newOrder("stop1", "AAPL", types.Buy, types.StopMarketOrder, 30, 0)
+		stop.TriggerPrice = 15002
+		stop.AvailableBalance = 1_000_000
+		_, _, err = engine.SubmitOrder(stop)
+		if !errors.As(err, &rejection) || rejection.Code != types.ErrorCodeInvalidPrice {
+			t.Errorf("expected invalid price rejection for the trigger, got %v", err)
+		}
+	})
+
+	t.Run("should reject quantities off the lot grid or below the minimum", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithInstrumentSpec("AAPL", spec))
+
+		var rejection *RejectionError
+		for _, qty := range []int64{25, 10} {
+			_, _, err := engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, qty, 15000))
+			if !errors.As(err, &rejection) || rejection.Code != types.ErrorCodeInvalidQuantity {
+				t.Errorf("expected invalid quantity rejection for %d, got %v", qty, err)
+			}
+		}
+
+		iceberg := newIcebergOrder("buy2", types.Buy, 30, 15000, 15)
+		_, _, err := engine.SubmitOrder(iceberg)
+		if !errors.As(err, &rejection) || rejection.Code != types.ErrorCodeInvalidQuantity {
+			t.Errorf("expected invalid quantity rejection for the display quantity, got %v", err)
+		}
+	})
+
+	t.Run("should only apply the spec to its stock", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithInstrumentSpec("MSFT", spec))
+
+		if _, _, err := engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 3, 15003)); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("should reject amends off the grid", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithInstrumentSpec("AAPL", spec))
+		engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 30, 15000))
+
+		var rejection *RejectionError
+		_, _, err := engine.AmendOrder("buy1", 0, 30, 15001, 0, 0)
+		if !errors.As(err, &rejection) || rejection.Code != types.ErrorCodeInvalidPrice {
+			t.Errorf("expected invalid price rejection, got %v", err)
+		}
+		_, _, err = engine.AmendOrder("buy1", 0, 25, 15000, 0, 0)
+		if !errors.As(err, &rejection) || rejection.Code != types.ErrorCodeInvalidQuantity {
+			t.Errorf("expected invalid quantity rejection, got %v", err)
+		}
+	})
+
+	t.Run("should reprice post-only orders by the tick size", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithInstrumentSpec("AAPL", spec))
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 30, 15000))
+
+		buy := newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 30, 15000)
+		buy.PostOnly = true
+		buy.PostOnlyReprice = true
+		engine.SubmitOrder(buy)
+		if buy.LimitPrice != 14995 {
+			t.Errorf("expected reprice to 14995, got %d", buy.LimitPrice)
+		}
+	})
+}
+
+func TestInstrumentRegistry(t *testing.T) {
+	registered := []types.Instrument{
+		{Ticker: "AAPL", Spec: types.DefaultInstrumentSpec, Active: true},
+		{Ticker: "TECH", Spec: types.DefaultInstrumentSpec, Active: false},
+	}
+
+	t.Run("should accept any ticker until the registry is seeded", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+
+		if _, _, err := engine.SubmitOrder(newOrder("buy1", "TECHH", types.Buy, types.LimitOrder, 10, 15000)); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("should reject unknown tickers without opening a book", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithInstruments(registered))
+
+		_, _, err := engine.SubmitOrder(newOrder("buy1", "TECHH", types.Buy, types.LimitOrder, 10, 15000))
+		var rejection *RejectionError
+		if !errors.As(err, &rejection) || rejection.Code != types.ErrorCodeStockNotFound {
+			t.Errorf("expected stock not found rejection, got %v", err)
+		}
+		if _, exists := engine.orderBooks.Load("TECHH"); exists {
+			t.Error("expected no book for an unknown ticker")
+		}
+	})
+
+	t.Run("should reject inactive tickers", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithInstruments(registered))
+
+		_, _, err := engine.SubmitOrder(newOrder("buy1", "TECH", types.Buy, types.LimitOrder, 10, 15000))
+		var rejection *RejectionError
+		if !errors.As(err, &rejection) || rejection.Code != types.ErrorCodeStockNotTrading {
+			t.Errorf("expected stock not trading rejection, got %v", err)
+		}
+	})
+
+	t.Run("should add and deactivate instruments at runtime", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithInstruments(registered))
+
+		engine.AddInstrument(types.Instrument{Ticker: "MSFT", Spec: types.NewInstrumentSpec(5, 0, 0), Active: true})
+		if _, _, err := engine.SubmitOrder(newOrder("buy1", "MSFT", types.Buy, types.LimitOrder, 10, 15005)); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 15000))
+		if found, err := engine.DeactivateInstrument("AAPL"); !found || err != nil {
+			t.Fatalf("expected AAPL to be deactivated, got %v", err)
+		}
+		_, _, err := engine.SubmitOrder(newOrder("buy2", "AAPL", types.Buy, types.LimitOrder, 10, 15000))
+		var rejection *RejectionError
+		if !errors.As(err, &rejection) || rejection.Code != types.ErrorCodeStockNotTrading {
+			t.Errorf("expected stock not trading rejection, got %v", err)
+		}
+		if found, _ := engine.CancelOrder("sell1", 0); !found {
+			t.Error("expected resting orders of a deactivated stock to be cancellable")
+		}
+
+		if found, _ := engine.DeactivateInstrument("TECHH"); found {
+			t.Error("expected unknown tickers not to be deactivated")
+		}
+
+		instruments := engine.Instruments()
+		if len(instruments) != 3 || instruments[0].Ticker != "AAPL" || instruments[0].Active || instruments[1].Ticker != "MSFT" {
+			t.Errorf("unexpected instrument list: %+v", instruments)
+		}
+	})
+
+	t.Run("should apply a new spec to an existing book", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithInstruments(registered))
+		engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 10, 15001))
+
+		engine.AddInstrument(types.Instrument{Ticker: "AAPL", Spec: types.NewInstrumentSpec(5, 0, 0), Active: true})
+		_, _, err := engine.SubmitOrder(newOrder("buy2", "AAPL", types.Buy, types.LimitOrder, 10, 15001))
+		var rejection *RejectionError
+		if !errors.As(err, &rejection) || rejection.Code != types.ErrorCodeInvalidPrice {
+			t.Errorf("expected invalid price rejection, got %v", err)
+		}
+	})
+}
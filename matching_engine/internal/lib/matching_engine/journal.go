@@ -0,0 +1,160 @@
+package matchingengine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+// CommandJournal durably records the commands that change the engine's books, so the books
+// can be rebuilt after a crash
+type CommandJournal interface {
+	// Append records a command and returns once it is on disk, with its sequence number
+	Append(cmd types.Command) (uint64, error)
+	// Replay calls apply for every command after the given sequence number, in order
+	Replay(after uint64, apply func(types.Command) error) error
+	// LastSequence returns the sequence number of the last command appended
+	LastSequence() uint64
+}
+
+// record journals a command before it changes a book, stamping it with the current time
+// unless it carries its own. Commands on a book must be recorded under its lock so the
+// journal holds them in the order they were applied.
+func (me *MatchingEngine) record(cmd types.Command) error {
+	if me.journal == nil {
+		return nil
+	}
+	if cmd.Time.IsZero() {
+		cmd.Time = me.clock()
+	}
+	if _, err := me.journal.Append(cmd); err != nil {
+		log.Printf("journal append failed: %v, type=%d", err, int(cmd.Type))
+		return err
+	}
+	return nil
+}
+
+// Recover rebuilds the books from the latest snapshot, if any, and the journal commands after
+// it. Nothing is published or journaled again, but replayed events use up their sequence
+// numbers as they did the first time, so numbering carries on from where the journal ends.
+// Must be called before the engine takes any traffic. Returns the number of commands replayed.
+func (me *MatchingEngine) Recover() (int, error) {
+	var after uint64
+	if me.snapshots != nil {
+		state, err := me.snapshots.Latest()
+		if err != nil {
+			return 0, fmt.Errorf("failed to load snapshot: %w", err)
+		}
+		if state != nil {
+			if me.journal != nil && state.JournalSequence > me.journal.LastSequence() {
+				return 0, fmt.Errorf("snapshot at journal sequence %d is ahead of the journal at %d", state.JournalSequence, me.journal.LastSequence())
+			}
+			me.restore(*state)
+			after = state.JournalSequence
+		}
+	}
+	if me.journal == nil {
+		return 0, nil
+	}
+
+	// Books are locked directly while replaying, so events are numbered as they are raised
+	journal, streamer, queueSize := me.journal, me.eventStreamer, me.queueSize
+	me.journal, me.eventStreamer, me.queueSize = nil, discardStreamer{}, 0
+	defer func() {
+		me.journal, me.eventStreamer, me.queueSize = journal, streamer, queueSize
+		me.clock = time.Now
+	}()
+
+	replayed := 0
+	err := journal.Replay(after, func(cmd types.Command) error {
+		// Without a snapshot the market was in the calendar's phase for the first command
+		// until a phase change says otherwise
+		if replayed == 0 && after == 0 && me.session.calendar != nil {
+			me.session.phase = me.session.calendar.PhaseAt(cmd.Time)
+		}
+		me.clock = func() time.Time { return cmd.Time }
+		if err := me.apply(cmd); err != nil {
+			return fmt.Errorf("failed to replay command %d: %w", cmd.Sequence, err)
+		}
+		replayed++
+		return nil
+	})
+	return replayed, err
+}
+
+// apply redoes a journaled command without validating it again
+func (me *MatchingEngine) apply(cmd types.Command) error {
+	if cmd.Type == types.CommandSessionPhase {
+		me.session.mu.Lock()
+		previous := me.session.phase
+		me.session.phase = cmd.Phase
+		me.session.mu.Unlock()
+		if previous != cmd.Phase {
+			me.enterPhase(previous, cmd.Phase, cmd.Time)
+		}
+		return nil
+	}
+	if cmd.Stock == "" {
+		return errors.New("command has no stock")
+	}
+
+	book := me.getOrCreateOrderBook(cmd.Stock)
+	book.Mu.Lock()
+	defer book.Mu.Unlock()
+
+	switch cmd.Type {
+	case types.CommandSubmit:
+		if cmd.Order == nil {
+			return errors.New("submit command has no order")
+		}
+		me.placeOrder(book, cmd.Order)
+	case types.CommandCancel:
+		me.cancelOrder(book, cmd.OrderId, cmd.Side)
+	case types.CommandAmend:
+		order, found := findOrder(book, cmd.OrderId, cmd.Side)
+		if !found {
+			return fmt.Errorf("amended order %s is not on the book", cmd.OrderId)
+		}
+		me.amendOrder(book, order, cmd.Quantity, cmd.PriceCents)
+	case types.CommandExpire:
+		me.expireOrders(book, cmd.Time)
+	case types.CommandStartAuction:
+		book.InAuction = true
+	case types.CommandUncross:
+		me.uncross(book, cmd.Stock, cmd.Closing)
+	case types.CommandResume:
+		me.resume(book, cmd.Stock, me.session.current().CollectsOrders())
+	case types.CommandReferencePrice:
+		book.ReferencePrice = cmd.PriceCents
+	case types.CommandAddInstrument:
+		if cmd.Instrument == nil {
+			return errors.New("add instrument command has no instrument")
+		}
+		me.registry.put(*cmd.Instrument)
+		book.Spec = cmd.Instrument.Spec
+	case types.CommandDeactivate:
+		me.registry.deactivate(cmd.Stock)
+	default:
+		return fmt.Errorf("unknown command type %d", cmd.Type)
+	}
+	return nil
+}
+
+// discardStreamer drops replayed events, which reached the stream before the restart
+type discardStreamer struct{}
+
+func (discardStreamer) IsHealthy(ctx context.Context) (bool, error) {
+	return true, nil
+}
+
+func (discardStreamer) Publish(ctx context.Context, eventData any, eventType types.EventType, seq types.EventSequence) error {
+	return nil
+}
+
+func (discardStreamer) Close(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,102 @@
+package matchingengine
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/journal"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/events/streaming_client/clients"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+func TestJournal(t *testing.T) {
+	t.Run("should rebuild the books by replaying the journal", func(t *testing.T) {
+		dir := t.TempDir()
+		j, err := journal.Open(dir, journal.Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithJournal(j))
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 15000))
+		engine.SubmitOrder(newOrder("sell2", "AAPL", types.Sell, types.LimitOrder, 10, 15000))
+		engine.SubmitOrder(newOrder("sell3", "AAPL", types.Sell, types.LimitOrder, 5, 15100))
+		engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 12, 15000))
+		engine.SubmitOrder(newOrder("buy2", "AAPL", types.Buy, types.LimitOrder, 8, 14900))
+		engine.SubmitOrder(newOrder("buy3", "MSFT", types.Buy, types.LimitOrder, 3, 30000))
+		engine.CancelOrder("sell3", 0)
+		if _, _, err := engine.AmendOrder("buy2", 0, 4, 14950, 0, 0); err != nil {
+			t.Fatal(err)
+		}
+		if err := j.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		reopened, err := journal.Open(dir, journal.Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer reopened.Close()
+		recovered := NewMatchingEngine(&clients.TestStreamingClient{}, WithJournal(reopened))
+		replayed, err := recovered.Recover()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if replayed != 8 {
+			t.Errorf("expected 8 commands replayed, got %d", replayed)
+		}
+		for _, stock := range []string{"AAPL", "MSFT"} {
+			want, _ := engine.Snapshot(stock, 0)
+			got, _ := recovered.Snapshot(stock, 0)
+			want.Timestamp, got.Timestamp = time.Time{}, time.Time{}
+			if fmt.Sprintf("%+v", want) != fmt.Sprintf("%+v", got) {
+				t.Errorf("expected %s book %+v, got %+v", stock, want, got)
+			}
+		}
+		if _, found := recovered.getOrCreateOrderBook("AAPL").SellSide.GetOrder("sell2"); !found {
+			t.Error("expected the partly filled order to rest after replay")
+		}
+
+		// New commands continue the journal's sequence
+		if _, _, err := recovered.SubmitOrder(newOrder("sell4", "AAPL", types.Sell, types.LimitOrder, 1, 15200)); err != nil {
+			t.Fatal(err)
+		}
+		if reopened.LastSequence() != 9 {
+			t.Errorf("expected sequence 9, got %d", reopened.LastSequence())
+		}
+	})
+
+	t.Run("should replay instrument changes", func(t *testing.T) {
+		dir := t.TempDir()
+		j, err := journal.Open(dir, journal.Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		registered := []types.Instrument{{Ticker: "AAPL", Spec: types.DefaultInstrumentSpec, Active: true}}
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithJournal(j), WithInstruments(registered))
+		if err := engine.AddInstrument(types.Instrument{Ticker: "MSFT", Spec: types.NewInstrumentSpec(5, 0, 0), Active: true}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := engine.DeactivateInstrument("AAPL"); err != nil {
+			t.Fatal(err)
+		}
+		j.Close()
+
+		reopened, err := journal.Open(dir, journal.Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer reopened.Close()
+		recovered := NewMatchingEngine(&clients.TestStreamingClient{}, WithJournal(reopened), WithInstruments(registered))
+		if replayed, err := recovered.Recover(); err != nil || replayed != 2 {
+			t.Fatalf("expected 2 commands replayed, got %d (%v)", replayed, err)
+		}
+		if instrument, _ := recovered.LookupInstrument("AAPL"); instrument.Active {
+			t.Error("expected AAPL to stay deactivated")
+		}
+		msft, ok := recovered.LookupInstrument("MSFT")
+		if !ok || msft.Spec.TickSize != 5 {
+			t.Errorf("expected MSFT with a tick size of 5, got %+v", msft)
+		}
+	})
+}
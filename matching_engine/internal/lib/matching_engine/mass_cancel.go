@@ -0,0 +1,83 @@
+package matchingengine
+
+import (
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+// MassCancelFilter selects the open orders of one trader that MassCancel pulls
+type MassCancelFilter struct {
+	TraderId int64
+	Stock    string           // Every stock if empty
+	Side     *types.OrderSide // Both sides if nil
+}
+
+// matches reports whether the filter covers orders on the given side
+func (f MassCancelFilter) matches(side types.OrderSide) bool {
+	return f.Side == nil || *f.Side == side
+}
+
+// MassCancel cancels every resting and held stop order the filter selects, one book at a time,
+// publishing an OrderCancelledEvent for each as CancelOrder does. Each book's orders are
+// cancelled in one command, so no order of the trader's is matched in between.
+// Returns the IDs of the cancelled orders; if a cancellation can't be journaled the orders
+// cancelled until then are returned with an ErrorCodeInternalError rejection.
+func (me *MatchingEngine) MassCancel(filter MassCancelFilter) ([]string, error) {
+	me.stateMu.RLock()
+	defer me.stateMu.RUnlock()
+	// Validate inputs
+	if filter.TraderId <= 0 {
+		return nil, errors.New("trader ID must be positive")
+	}
+
+	var books []*types.StockOrderBook
+	me.orderBooks.Range(func(key, value any) bool {
+		book, ok := value.(*types.StockOrderBook)
+		if ok && (filter.Stock == "" || key.(string) == filter.Stock) {
+			books = append(books, book)
+		}
+		return true
+	})
+	sort.Slice(books, func(i, j int) bool { return books[i].Stock() < books[j].Stock() })
+
+	var cancelled []string
+	var err error
+	for _, book := range books {
+		me.onBook(book, func() {
+			for _, order := range ordersToCancel(book, filter) {
+				if err = me.record(types.Command{Type: types.CommandCancel, Stock: book.Stock(), OrderId: order.OrderId, Side: order.OrderSide}); err != nil {
+					return
+				}
+				me.cancelOrder(book, order.OrderId, order.OrderSide)
+				cancelled = append(cancelled, order.OrderId)
+			}
+		})
+		if err != nil {
+			return cancelled, &RejectionError{Code: types.ErrorCodeInternalError, Message: "The cancellation could not be recorded"}
+		}
+	}
+	return cancelled, nil
+}
+
+// ordersToCancel returns the book's orders the filter selects, ordered by ID so a mass cancel
+// always publishes and journals them in the same order. Must be called with the book lock held.
+func ordersToCancel(book *types.StockOrderBook, filter MassCancelFilter) []*types.Order {
+	var orders []*types.Order
+	if filter.matches(types.Buy) {
+		orders = append(orders, book.BuySide.OrdersOf(filter.TraderId)...)
+	}
+	if filter.matches(types.Sell) {
+		orders = append(orders, book.SellSide.OrdersOf(filter.TraderId)...)
+	}
+	for _, order := range book.Stops.OrdersOf(filter.TraderId) {
+		if filter.matches(order.OrderSide) {
+			orders = append(orders, order)
+		}
+	}
+	slices.SortFunc(orders, func(a, b *types.Order) int { return strings.Compare(a.OrderId, b.OrderId) })
+	return orders
+}
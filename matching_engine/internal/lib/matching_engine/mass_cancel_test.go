@@ -0,0 +1,124 @@
+package matchingengine
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/journal"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/events/streaming_client/clients"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+func TestMassCancel(t *testing.T) {
+	// submitQuotes rests a bid and an ask for trader 1 on AAPL and MSFT and one bid for trader 2
+	submitQuotes := func(engine *MatchingEngine) {
+		for _, stock := range []string{"AAPL", "MSFT"} {
+			bid := newTraderOrder(stock+"-bid", 1, types.Buy, 10, 15000)
+			bid.StockTicker = stock
+			ask := newTraderOrder(stock+"-ask", 1, types.Sell, 10, 16000)
+			ask.StockTicker = stock
+			engine.SubmitOrder(bid)
+			engine.SubmitOrder(ask)
+		}
+		engine.SubmitOrder(newTraderOrder("other-bid", 2, types.Buy, 10, 15000))
+	}
+
+	t.Run("should cancel every open order of the trader", func(t *testing.T) {
+		streamer := &envelopeStreamer{}
+		engine := NewMatchingEngine(streamer)
+		submitQuotes(engine)
+		stop := newTraderOrder("stop1", 1, types.Sell, 10, 0)
+		stop.OrderType = types.StopMarketOrder
+		stop.TriggerPrice = 14000
+		engine.SubmitOrder(stop)
+		streamer.events = nil
+
+		cancelled, err := engine.MassCancel(MassCancelFilter{TraderId: 1})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []string{"AAPL-ask", "AAPL-bid", "stop1", "MSFT-ask", "MSFT-bid"}
+		if !slices.Equal(cancelled, want) {
+			t.Errorf("expected %v cancelled, got %v", want, cancelled)
+		}
+		if len(streamer.events) != len(want) {
+			t.Fatalf("expected %d events, got %d", len(want), len(streamer.events))
+		}
+		for i, evt := range streamer.events {
+			var event types.OrderCancelledEvent
+			if evt.Type != types.OrderCancelled || json.Unmarshal(evt.Data, &event) != nil || event.OrderID != want[i] {
+				t.Errorf("expected an OrderCancelledEvent for %s, got type %d", want[i], evt.Type)
+			}
+		}
+		if engine.orders.len() != 1 {
+			t.Errorf("expected only the other trader's order open, got %d orders", engine.orders.len())
+		}
+	})
+
+	t.Run("should only cancel orders on the given stock and side", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+		submitQuotes(engine)
+
+		side := types.Buy
+		cancelled, err := engine.MassCancel(MassCancelFilter{TraderId: 1, Stock: "MSFT", Side: &side})
+		if err != nil || !slices.Equal(cancelled, []string{"MSFT-bid"}) {
+			t.Fatalf("expected MSFT-bid cancelled, got %v err=%v", cancelled, err)
+		}
+		if engine.orders.len() != 4 {
+			t.Errorf("expected 4 orders left open, got %d", engine.orders.len())
+		}
+	})
+
+	t.Run("should cancel nothing for a trader without open orders", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+		submitQuotes(engine)
+
+		cancelled, err := engine.MassCancel(MassCancelFilter{TraderId: 3})
+		if err != nil || len(cancelled) != 0 {
+			t.Errorf("expected nothing cancelled, got %v err=%v", cancelled, err)
+		}
+	})
+
+	t.Run("should refuse a filter without a trader", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+		engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 10, 15000))
+
+		cancelled, err := engine.MassCancel(MassCancelFilter{Stock: "AAPL"})
+		var rejection *RejectionError
+		if err == nil || errors.As(err, &rejection) || len(cancelled) != 0 {
+			t.Errorf("expected an invalid argument error, got %v err=%v", cancelled, err)
+		}
+		if engine.orders.len() != 1 {
+			t.Error("expected the order to stay open")
+		}
+	})
+
+	t.Run("should journal each cancellation", func(t *testing.T) {
+		dir := t.TempDir()
+		j, err := journal.Open(dir, journal.Options{})
+		if err != nil {
+			t.Fatalf("failed to open journal: %v", err)
+		}
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithJournal(j))
+		submitQuotes(engine)
+		engine.MassCancel(MassCancelFilter{TraderId: 1})
+		j.Close()
+
+		reopened, err := journal.Open(dir, journal.Options{})
+		if err != nil {
+			t.Fatalf("failed to reopen journal: %v", err)
+		}
+		defer reopened.Close()
+		recovered := NewMatchingEngine(&clients.TestStreamingClient{}, WithJournal(reopened))
+		if _, err := recovered.Recover(); err != nil {
+			t.Fatalf("failed to recover: %v", err)
+		}
+		want, _ := json.Marshal(engine.State().Books)
+		got, _ := json.Marshal(recovered.State().Books)
+		if string(got) != string(want) {
+			t.Errorf("recovered books differ:\n got %s\nwant %s", got, want)
+		}
+	})
+}
@@ -3,23 +3,64 @@ package matchingengine
 import (
 	"context"
 	"errors"
-	"log"
+	"fmt"
 	"sync"
 	"time"
 
 	streamingclient "github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/events/streaming_client"
 	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+	"github.com/google/uuid"
 )
 
 // MatchingEngine handles order matching for all stocks
 type MatchingEngine struct {
-	orderBooks    sync.Map // stock symbol -> *types.StockOrderBook
-	eventStreamer streamingclient.StreamingClient
+	orderBooks       sync.Map // stock symbol -> *types.StockOrderBook
+	eventStreamer    streamingclient.StreamingClient
+	dayClose         func(time.Time) time.Time       // Expiry of DAY orders submitted at the given time
+	selfTrade        types.SelfTradePrevention       // Mode for orders that don't choose their own
+	selfTradeByOwner bool                            // Also prevent trades between a trader and the bots they own
+	policies         map[string]types.MatchingPolicy // stock symbol -> matching policy, FIFO if absent
+	bands            types.PriceBands                // Price bands for stocks without their own
+	stopSlippageBps  int64                           // Buffer a stop-market or trailing-stop buy reserves over its trigger price
+	stockBands       map[string]types.PriceBands     // stock symbol -> price bands
+	registry         instrumentRegistry              // Stocks orders are accepted for, with their tick and lot sizes
+	orders           orderIndex                      // Book and side of every open order, by order ID
+	session          session                         // Phase of the trading day
+	journal          CommandJournal                  // Records commands before they change a book, nil for none
+	snapshots        SnapshotStore                   // Saved copies of every book, nil for none
+	stateMu          sync.RWMutex                    // Held shared by commands that change the books, exclusively to save them
+	clock            func() time.Time                // Current time; the command's time while replaying
+	sequenceMu       sync.Mutex                      // Guards sequence, outbox and wake
+	sequence         int64                           // Sequence number of the last event published
+	outbox           []pendingEvent                  // Numbered events waiting for the streamer
+	wake             chan struct{}                   // Tells the publisher goroutine events are waiting, nil without one
+	publishMu        sync.Mutex                      // Held while handing events to the streamer, so they go in order
+	epoch            string                          // Identifies this run of the engine on every event
+	newLadder        func(bool) types.PriceLadder    // Keeps each side's price levels in order, nil for the default
+	queueSize        int                             // Commands queued per book; 0 locks books instead of using sequencers
+	sequencers       sync.Map                        // stock symbol -> *sequencer
+	running          sync.WaitGroup                  // Sequencer goroutines, waited for by Close
+	publisher        sync.WaitGroup                  // Publisher goroutine, waited for by Close
 }
 
 // NewMatchingEngine creates a new matching engine
-func NewMatchingEngine(streamer streamingclient.StreamingClient) *MatchingEngine {
-	return &MatchingEngine{eventStreamer: streamer}
+func NewMatchingEngine(streamer streamingclient.StreamingClient, opts ...Option) *MatchingEngine {
+	me := &MatchingEngine{
+		eventStreamer: streamer,
+		dayClose:      endOfDayUTC,
+		selfTrade:     types.SelfTradeAllow,
+		clock:         time.Now,
+		epoch:         uuid.NewString(),
+	}
+	for _, opt := range opts {
+		opt(me)
+	}
+	if me.queueSize > 0 {
+		me.wake = make(chan struct{}, 1)
+		me.publisher.Add(1)
+		go me.runPublisher(me.wake)
+	}
+	return me
 }
 
 func (me *MatchingEngine) IsEventStreamerHealthy(ctx context.Context) (bool, error) {
@@ -32,15 +73,25 @@ func (me *MatchingEngine) IsEventStreamerHealthy(ctx context.Context) (bool, err
 
 // safePublish sends events with a bounded timeout and logs failures.
 // Keeps matching logic from blocking indefinitely on I/O.
-func (me *MatchingEngine) safePublish(evt any, et types.EventType) {
+// Events about a stock pass its book, which must be locked, and get the next stock sequence
+// number; book is nil for events not tied to a book. With sequencers a book's events wait
+// until its command finishes. A failed publish still uses up its sequence numbers, so
+// consumers see the loss as a gap.
+func (me *MatchingEngine) safePublish(book *types.StockOrderBook, evt any, et types.EventType) {
 	if me.eventStreamer == nil {
 		return
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
-	defer cancel()
-	if err := me.eventStreamer.Publish(ctx, evt, et); err != nil {
-		log.Printf("event publish failed: %v, type=%d", err, int(et))
+	e := pendingEvent{data: evt, eventType: et}
+	if book != nil {
+		book.Sequence++
+		e.seq.StockTicker = book.Stock()
+		e.seq.StockSequence = book.Sequence
+		if s := me.sequencerOf(book); s != nil {
+			s.pending = append(s.pending, e)
+			return
+		}
 	}
+	me.publish(e)
 }
 
 // getOrCreateOrderBook gets or creates an order book for a stock
@@ -55,6 +106,21 @@ func (me *MatchingEngine) getOrCreateOrderBook(stock string) *types.StockOrderBo
 
 	// Create new book and try to store it
 	newBook := types.NewStockOrderBook(stock)
+	if me.newLadder != nil {
+		newBook.BuySide = types.NewLadderSide(me.newLadder(true))
+		newBook.SellSide = types.NewLadderSide(me.newLadder(false))
+	}
+	newBook.Bands = me.bands
+	if bands, ok := me.stockBands[stock]; ok {
+		newBook.Bands = bands
+	}
+	if instrument, ok := me.registry.lookup(stock); ok {
+		newBook.Spec = instrument.Spec
+	}
+	if policy, ok := me.policies[stock]; ok {
+		newBook.Policy = policy
+	}
+	newBook.InAuction = me.session.current().CollectsOrders()
 	actual, _ := me.orderBooks.LoadOrStore(stock, newBook)
 	if orderBook, ok := actual.(*types.StockOrderBook); ok {
 		return orderBook
@@ -66,10 +132,12 @@ func (me *MatchingEngine) getOrCreateOrderBook(stock string) *types.StockOrderBo
 // SubmitOrder submits an order and attempts to match it
 // Returns a slice of matched events, any remaining unmatched quantity, and an error
 func (me *MatchingEngine) SubmitOrder(order *types.Order) ([]types.MatchedEvent, int64, error) {
+	me.stateMu.RLock()
+	defer me.stateMu.RUnlock()
 	// Minimal defensive checks to prevent panics
 	if order == nil {
 		if me.eventStreamer != nil {
-			me.safePublish(&types.OrderRejectedEvent{
+			me.safePublish(nil, &types.OrderRejectedEvent{
 				OrderID:      "",
 				TraderID:     0,
 				Reason:       "Order is empty",
@@ -80,7 +148,7 @@ func (me *MatchingEngine) SubmitOrder(order *types.Order) ([]types.MatchedEvent,
 	}
 	if order.StockTicker == "" {
 		if me.eventStreamer != nil {
-			me.safePublish(&types.OrderRejectedEvent{
+			me.safePublish(nil, &types.OrderRejectedEvent{
 				OrderID:      "",
 				TraderID:     0,
 				Reason:       "Ticker is empty",
@@ -91,7 +159,7 @@ func (me *MatchingEngine) SubmitOrder(order *types.Order) ([]types.MatchedEvent,
 	}
 	if order.OrderId == "" {
 		if me.eventStreamer != nil {
-			me.safePublish(&types.OrderRejectedEvent{
+			me.safePublish(nil, &types.OrderRejectedEvent{
 				OrderID:      "",
 				TraderID:     0,
 				Reason:       "OrderId is empty",
@@ -101,53 +169,432 @@ func (me *MatchingEngine) SubmitOrder(order *types.Order) ([]types.MatchedEvent,
 		return nil, 0, errors.New("order ID cannot be empty")
 	}
 	if order.Quantity <= 0 {
-		if me.eventStreamer != nil {
-			me.safePublish(&types.OrderRejectedEvent{
-				OrderID:      order.OrderId,
-				TraderID:     order.TraderId,
-				Reason:       "Invalid quantity",
-				ErrorMessage: "Quantity must be greater than 0",
-			}, types.OrderRejected)
+		return nil, 0, me.reject(nil, order, types.ErrorCodeInvalidQuantity, "Invalid quantity", "Quantity must be greater than 0")
+	}
+	if order.OrderSide == types.Sell && order.AvailableShares != nil && order.Quantity > *order.AvailableShares {
+		return nil, 0, me.reject(nil, order, types.ErrorCodeInsufficientShares, "Insufficient shares", "Sell quantity exceeds available shares")
+	}
+	if (order.OrderType == types.LimitOrder || order.OrderType == types.StopLimitOrder) && order.LimitPrice <= 0 {
+		return nil, 0, me.reject(nil, order, types.ErrorCodeInvalidPrice, "Invalid limit price", "Limit price must be greater than 0")
+	}
+	if reservesCash(order) && order.AvailableBalance <= 0 {
+		return nil, 0, me.reject(nil, order, types.ErrorCodeInsufficientFunds, "Insufficient funds", "Stop-market and trailing-stop buys need an available balance to reserve")
+	}
+	if order.OrderType.IsStop() && order.OrderType != types.TrailingStopOrder && order.TriggerPrice <= 0 {
+		return nil, 0, me.reject(nil, order, types.ErrorCodeInvalidPrice, "Invalid trigger price", "Trigger price must be greater than 0")
+	}
+	if order.OrderType == types.TrailingStopOrder &&
+		(order.TrailingOffset < 0 || order.TrailingBps < 0 || order.TrailingBps >= 10000 ||
+			(order.TrailingOffset > 0) == (order.TrailingBps > 0)) {
+		return nil, 0, me.reject(nil, order, types.ErrorCodeInvalidPrice, "Invalid trailing offset", "Trailing stops need either an offset in cents or in basis points below 10000")
+	}
+	if order.OrderType != types.TrailingStopOrder && (order.TrailingOffset != 0 || order.TrailingBps != 0) {
+		return nil, 0, me.reject(nil, order, types.ErrorCodeInvalidOrderType, "Invalid trailing offset", "Trailing offsets require a trailing stop order")
+	}
+	if order.TimeInForce == types.Day && order.ExpireAt.IsZero() {
+		order.ExpireAt = me.dayClose(me.clock())
+	}
+	if order.TimeInForce == types.GoodTillDate && !order.ExpireAt.After(me.clock()) {
+		return nil, 0, me.reject(nil, order, types.ErrorCodeUnspecified, "Invalid expiry", "Expiry must be in the future for GTD orders")
+	}
+	if order.PostOnly && (order.OrderType != types.LimitOrder ||
+		order.TimeInForce == types.ImmediateOrCancel || order.TimeInForce == types.FillOrKill) {
+		return nil, 0, me.reject(nil, order, types.ErrorCodeInvalidOrderType, "Invalid post-only order", "Post-only requires a resting limit order")
+	}
+	if order.DisplayQuantity < 0 || order.DisplayQuantity > order.Quantity {
+		return nil, 0, me.reject(nil, order, types.ErrorCodeInvalidQuantity, "Invalid display quantity", "Display quantity must be between 0 and the order quantity")
+	}
+	if order.DisplayQuantity > 0 && order.OrderType != types.LimitOrder && order.OrderType != types.StopLimitOrder {
+		return nil, 0, me.reject(nil, order, types.ErrorCodeInvalidOrderType, "Invalid iceberg order", "Display quantity requires a limit or stop-limit order")
+	}
+
+	// Checked before the book is looked up so an unknown ticker never gets one
+	if err := me.checkInstrument(order); err != nil {
+		return nil, 0, err
+	}
+
+	orderBook := me.getOrCreateOrderBook(order.StockTicker)
+
+	// Only this stock's order book is held while the order is checked against it and matched
+	var matches []types.MatchedEvent
+	var remaining int64
+	var err error
+	me.onBook(orderBook, func() {
+		matches, remaining, err = me.acceptOrder(orderBook, order)
+	})
+	return matches, remaining, err
+}
+
+// acceptOrder checks an order against the state of its book, journals it and places it.
+// Must be called with the book lock held.
+func (me *MatchingEngine) acceptOrder(orderBook *types.StockOrderBook, order *types.Order) ([]types.MatchedEvent, int64, error) {
+	if err := me.enforceInstrumentSpec(orderBook, order); err != nil {
+		return nil, 0, err
+	}
+
+	if me.session.current() == types.PhaseClosed {
+		return nil, 0, me.reject(orderBook, order, types.ErrorCodeMarketClosed, "Market closed", "The market is closed")
+	}
+
+	if orderBook.IsHalted() {
+		return nil, 0, me.reject(orderBook, order, types.ErrorCodeStockNotTrading, "Trading halted", "Trading in this stock is halted")
+	}
+
+	// Auctions only collect orders that can rest until the book is uncrossed
+	if orderBook.InAuction && (order.OrderType == types.MarketOrder || order.PostOnly ||
+		order.TimeInForce == types.ImmediateOrCancel || order.TimeInForce == types.FillOrKill) {
+		return nil, 0, me.reject(orderBook, order, types.ErrorCodeInvalidOrderType, "Invalid auction order", "Only resting limit and stop orders are accepted during an auction")
+	}
+
+	// Post-only orders must not cross the opposite best price; checked before acceptance
+	// so a repriced order is announced (and held for) at its final price
+	if order.PostOnly {
+		if err := me.enforcePostOnly(orderBook, order); err != nil {
+			return nil, 0, err
 		}
-		return nil, 0, errors.New("quantity must be greater than 0")
 	}
-	if order.OrderType == types.LimitOrder && order.LimitPrice <= 0 {
-		if me.eventStreamer != nil {
-			me.safePublish(&types.OrderRejectedEvent{
-				OrderID:      order.OrderId,
-				TraderID:     order.TraderId,
-				Reason:       "Invalid limit price",
-				ErrorMessage: "Limit price must be greater than 0",
-			}, types.OrderRejected)
+
+	// Trailing stops start trailing from the last trade price
+	if order.OrderType == types.TrailingStopOrder {
+		if orderBook.LastTradePrice <= 0 {
+			return nil, 0, me.reject(orderBook, order, types.ErrorCodeInvalidPrice, "No price to trail", "Trailing stops need a last trade price to trail")
 		}
-		return nil, 0, errors.New("limit price must be greater than 0")
+		order.TriggerPrice = types.TrailingTrigger(order, orderBook.LastTradePrice, orderBook.Spec.TickSize)
 	}
 
-	orderBook := me.getOrCreateOrderBook(order.StockTicker)
+	// Stop buys that trigger into market orders reserve what they may spend, up to the balance
+	if reservesCash(order) {
+		order.AvailableBalance = min(order.AvailableBalance, me.stopReserve(order, order.Quantity))
+	}
+
+	// The accepted order is journaled before it changes the book
+	if err := me.record(types.Command{Type: types.CommandSubmit, Stock: order.StockTicker, Order: order}); err != nil {
+		return nil, 0, me.reject(orderBook, order, types.ErrorCodeInternalError, "Journal unavailable", "The order could not be recorded")
+	}
 
-	// Lock only this stock's order book
-	orderBook.Mu.Lock()
-	defer orderBook.Mu.Unlock()
+	matches, remaining := me.placeOrder(orderBook, order)
+	return matches, remaining, nil
+}
 
+// placeOrder announces an accepted order and matches it, or holds it if it is a stop order
+// waiting for its trigger. Returns the executions and the unmatched quantity.
+// Must be called with the book lock held.
+func (me *MatchingEngine) placeOrder(book *types.StockOrderBook, order *types.Order) ([]types.MatchedEvent, int64) {
 	// Emit OrderPlacedEvent - order has been accepted
 	if me.eventStreamer != nil {
-		me.safePublish(&types.OrderPlacedEvent{
-			OrderID:         order.OrderId,
-			TraderID:        order.TraderId,
-			StockTicker:     order.StockTicker,
-			OrderType:       order.OrderType,
-			OrderSide:       order.OrderSide,
-			Quantity:        order.Quantity,
-			LimitPriceCents: order.LimitPrice,
+		me.safePublish(book, &types.OrderPlacedEvent{
+			OrderID:           order.OrderId,
+			TraderID:          order.TraderId,
+			StockTicker:       order.StockTicker,
+			OrderType:         order.OrderType,
+			OrderSide:         order.OrderSide,
+			Quantity:          order.Quantity,
+			LimitPriceCents:   order.LimitPrice,
+			TriggerPriceCents: order.TriggerPrice,
+			TimeInForce:       order.TimeInForce,
+			ExpiresAt:         order.ExpireAt,
+			DisplayQuantity:   order.DisplayQuantity,
+			TrailingOffset:    order.TrailingOffset,
+			TrailingBps:       order.TrailingBps,
+			ClientOrderID:     order.ClientOrderId,
+			ReservedCash:      reservedCash(order),
+			SelfTrade:         order.SelfTrade,
+			PostOnly:          order.PostOnly,
+			PostOnlyReprice:   order.PostOnlyReprice,
 		}, types.OrderPlaced)
 	}
+	me.orders.add(order)
+
+	// Stop orders wait off-book until the last trade price crosses their trigger
+	if order.OrderType.IsStop() {
+		if book.InAuction || !types.IsTriggeredBy(order, book.LastTradePrice) {
+			book.Stops.AddOrder(order)
+			book.Expiries.Schedule(order)
+			return nil, order.Quantity
+		}
+		me.triggerStopOrder(book, order)
+	}
+
+	matches, remaining := me.matchOrder(book, order)
+	me.forgetIfGone(book, order)
+
+	// Trades from this order may have crossed the trigger of held stop orders
+	me.releaseTriggeredStops(book)
+
+	return matches, remaining
+}
+
+// reservesCash reports whether an order is a buy that becomes a market order when triggered.
+// It can only spend the cash reserved for it when it was placed, so the listener holds that cash
+// until the order closes: by then the trader may have spent the rest elsewhere.
+func reservesCash(order *types.Order) bool {
+	return order.OrderSide == types.Buy &&
+		(order.OrderType == types.StopMarketOrder || order.OrderType == types.TrailingStopOrder)
+}
+
+// stopReserve returns the cash a stop-market or trailing-stop buy of quantity reserves: the
+// quantity at its trigger price plus the slippage buffer. A buy trailing stop's trigger only
+// falls, so the reserve made at placement covers it wherever it triggers.
+func (me *MatchingEngine) stopReserve(order *types.Order, quantity int64) int64 {
+	cost := quantity * order.TriggerPrice
+	return cost + cost*me.stopSlippageBps/10000
+}
+
+// amendedReserve returns the cash a held stop-market or trailing-stop buy reserves once its
+// quantity is amended. A smaller order never reserves more than it did, so one that was held to
+// the trader's balance stays within it.
+func (me *MatchingEngine) amendedReserve(order *types.Order, newQuantity int64) int64 {
+	reserve := me.stopReserve(order, newQuantity)
+	if newQuantity <= order.Quantity {
+		return min(order.AvailableBalance, reserve)
+	}
+	return reserve
+}
+
+// reservedCash returns the cash the listener holds for an order, 0 unless it reserves cash
+func reservedCash(order *types.Order) int64 {
+	if !reservesCash(order) {
+		return 0
+	}
+	return order.AvailableBalance
+}
+
+// enforceInstrumentSpec rejects orders whose prices are off the stock's tick grid
+// or whose quantities are off its lot grid. Must be called with the book lock held.
+func (me *MatchingEngine) enforceInstrumentSpec(book *types.StockOrderBook, order *types.Order) error {
+	spec := book.Spec
+	if !spec.OnTick(order.LimitPrice) || !spec.OnTick(order.TriggerPrice) || !spec.OnTick(order.TrailingOffset) {
+		return me.reject(book, order, types.ErrorCodeInvalidPrice, "Price off tick", fmt.Sprintf("Prices must be a multiple of the %d cent tick size", spec.TickSize))
+	}
+	if order.Quantity < spec.MinQuantity {
+		return me.reject(book, order, types.ErrorCodeInvalidQuantity, "Quantity below minimum", fmt.Sprintf("Quantity must be at least %d", spec.MinQuantity))
+	}
+	if !spec.OnLot(order.Quantity) || !spec.OnLot(order.DisplayQuantity) {
+		return me.reject(book, order, types.ErrorCodeInvalidQuantity, "Quantity off lot", fmt.Sprintf("Quantities must be a multiple of the %d share lot size", spec.LotSize))
+	}
+	return nil
+}
+
+// reject publishes an OrderRejectedEvent for the order and returns the matching RejectionError.
+// book is the locked book the order was checked against, nil if it never reached one.
+func (me *MatchingEngine) reject(book *types.StockOrderBook, order *types.Order, code types.ErrorCode, reason, message string) error {
+	if me.eventStreamer != nil {
+		me.safePublish(book, &types.OrderRejectedEvent{
+			OrderID:      order.OrderId,
+			TraderID:     order.TraderId,
+			Reason:       reason,
+			ErrorMessage: message,
+			ErrorCode:    code,
+		}, types.OrderRejected)
+	}
+	return &RejectionError{Code: code, Message: message}
+}
+
+// enforcePostOnly rejects a post-only order that would cross the opposite best price,
+// or reprices it one tick behind that price when the order asks for it.
+// Must be called with the book lock held.
+func (me *MatchingEngine) enforcePostOnly(book *types.StockOrderBook, order *types.Order) error {
+	price, ok := postOnlyPrice(book, order, order.LimitPrice)
+	if !ok {
+		return me.reject(book, order, types.ErrorCodePostOnlyWouldCross, "Post-only order would cross", "Post-only order would take liquidity")
+	}
+	order.LimitPrice = price
+	return nil
+}
 
+// postOnlyPrice returns the price a post-only order may rest at instead of price,
+// or false if it would cross and cannot be repriced.
+func postOnlyPrice(book *types.StockOrderBook, order *types.Order, price int64) (int64, bool) {
 	if order.OrderSide == types.Buy {
-		matches, remaining := me.matchBuyOrder(orderBook, order)
-		return matches, remaining, nil
+		bestAsk, ok := book.SellSide.GetBestPrice()
+		if !ok || price < bestAsk {
+			return price, true
+		}
+		if order.PostOnlyReprice && bestAsk-book.Spec.TickSize > 0 {
+			return bestAsk - book.Spec.TickSize, true
+		}
+		return 0, false
+	}
+	bestBid, ok := book.BuySide.GetBestPrice()
+	if !ok || price > bestBid {
+		return price, true
+	}
+	if order.PostOnlyReprice {
+		return bestBid + book.Spec.TickSize, true
+	}
+	return 0, false
+}
+
+// matchOrder routes an order to the matcher for its side, or straight onto the book during an auction.
+// Fill-or-kill orders that cannot be filled completely are cancelled without trading.
+func (me *MatchingEngine) matchOrder(book *types.StockOrderBook, order *types.Order) ([]types.MatchedEvent, int64) {
+	if book.InAuction {
+		// Orders are only collected until the auction is uncrossed
+		book.Rest(order)
+		return nil, order.Quantity
+	}
+	if order.TimeInForce == types.FillOrKill && !me.canFillCompletely(book, order) {
+		me.publishCancelled(book, order, order.Quantity, types.CancelReasonFillOrKill)
+		return nil, order.Quantity
+	}
+	if order.OrderSide == types.Buy {
+		return me.matchBuyOrder(book, order)
+	}
+	return me.matchSellOrder(book, order)
+}
+
+// canFillCompletely checks the opposite side for enough crossing depth to fill the whole order.
+// Market buys are also limited by what the buyer can afford, as in matchBuyOrder. Orders of the
+// same trader or owner group only count where self-trade prevention would let them trade.
+func (me *MatchingEngine) canFillCompletely(book *types.StockOrderBook, order *types.Order) bool {
+	opposite := book.Side(order.OrderSide.Opposite())
+	isLimit := order.OrderType == types.LimitOrder
+	isMarketBuy := order.OrderType == types.MarketOrder && order.OrderSide == types.Buy
+
+	needed := order.Quantity
+	balance := order.AvailableBalance
+	for _, level := range opposite.SortedLevels() {
+		price := level.Price()
+		if isLimit && order.OrderSide == types.Buy && price > order.LimitPrice {
+			break
+		}
+		if isLimit && order.OrderSide == types.Sell && price < order.LimitPrice {
+			break
+		}
+
+		if book.BandBreach(price) != "" {
+			break // Matching halts the stock before reaching this level
+		}
+
+		// Hidden iceberg quantity refills within the same sweep, so whole quantities count
+		var volume int64
+		stopped := false
+		for _, resting := range level.Orders() {
+			mode := me.selfTradeMode(order, resting)
+			if mode == types.SelfTradeAllow {
+				volume += resting.Quantity
+				continue
+			}
+			if mode != types.SelfTradeCancelOldest {
+				// Matching stops here, or removes quantity without filling it
+				stopped = true
+				break
+			}
+		}
+		available := volume
+		if isMarketBuy {
+			available = min(available, balance/price)
+			balance -= available * price
+		}
+		needed -= available
+		if needed <= 0 {
+			return true
+		}
+		if stopped || (isMarketBuy && available < volume) {
+			break // Matching can't get past this level
+		}
+	}
+	return false
+}
+
+// publishCancelled emits an OrderCancelledEvent so the listener releases the order's holds
+func (me *MatchingEngine) publishCancelled(book *types.StockOrderBook, order *types.Order, remainingQty int64, reason types.CancelReason) {
+	if me.eventStreamer != nil {
+		me.safePublish(book, &types.OrderCancelledEvent{
+			OrderID:           order.OrderId,
+			TraderID:          order.TraderId,
+			OrderType:         order.OrderType,
+			OrderSide:         order.OrderSide,
+			StockTicker:       order.StockTicker,
+			RemainingQuantity: remainingQty,
+			Reason:            reason,
+		}, types.OrderCancelled)
+	}
+}
+
+// publishAmended announces a change to an order's remaining quantity or limit price,
+// taking the new values from the order itself
+func (me *MatchingEngine) publishAmended(book *types.StockOrderBook, order *types.Order, oldQuantity, oldLimitPrice int64, requeued bool) {
+	if me.eventStreamer != nil {
+		me.safePublish(book, &types.OrderAmendedEvent{
+			OrderID:            order.OrderId,
+			TraderID:           order.TraderId,
+			StockTicker:        order.StockTicker,
+			OrderType:          order.OrderType,
+			OrderSide:          order.OrderSide,
+			OldQuantity:        oldQuantity,
+			NewQuantity:        order.Quantity,
+			OldLimitPriceCents: oldLimitPrice,
+			NewLimitPriceCents: order.LimitPrice,
+			Requeued:           requeued,
+			ReservedCash:       reservedCash(order),
+		}, types.OrderAmended)
+	}
+}
+
+// triggerStopOrder converts a stop order into the order type it releases as
+// and announces the conversion. Must be called with the book lock held.
+func (me *MatchingEngine) triggerStopOrder(book *types.StockOrderBook, order *types.Order) {
+	if order.OrderType == types.StopLimitOrder {
+		order.OrderType = types.LimitOrder
+	} else {
+		order.OrderType = types.MarketOrder
+	}
+
+	if me.eventStreamer != nil {
+		me.safePublish(book, &types.OrderTriggeredEvent{
+			OrderID:           order.OrderId,
+			TraderID:          order.TraderId,
+			StockTicker:       order.StockTicker,
+			OrderType:         order.OrderType,
+			OrderSide:         order.OrderSide,
+			Quantity:          order.Quantity,
+			TriggerPriceCents: order.TriggerPrice,
+			TradePriceCents:   book.LastTradePrice,
+		}, types.OrderTriggered)
+	}
+}
+
+// recordTrade notes a trade at price on the book and announces the trailing stops it moved,
+// so their triggers survive a rebuild. Must be called with the book lock held.
+func (me *MatchingEngine) recordTrade(book *types.StockOrderBook, price int64) {
+	me.publishTrailed(book, book.RecordTrade(price))
+}
+
+// publishTrailed emits a TrailingStopMovedEvent for each of the moved trailing stops
+func (me *MatchingEngine) publishTrailed(book *types.StockOrderBook, moved []*types.Order) {
+	if me.eventStreamer == nil {
+		return
+	}
+	for _, order := range moved {
+		me.safePublish(book, &types.TrailingStopMovedEvent{
+			OrderID:           order.OrderId,
+			TraderID:          order.TraderId,
+			StockTicker:       order.StockTicker,
+			OrderSide:         order.OrderSide,
+			TriggerPriceCents: order.TriggerPrice,
+		}, types.TrailingStopMoved)
+	}
+}
+
+// releaseTriggeredStops matches every held stop order crossed by the last trade price.
+// Trades from released stops can trigger further stops, so it loops until none remain.
+// Must be called with the book lock held.
+func (me *MatchingEngine) releaseTriggeredStops(book *types.StockOrderBook) {
+	if book.InAuction {
+		return // Stops are held until the auction is uncrossed
+	}
+	for {
+		triggered := book.Stops.PopTriggered(book.LastTradePrice)
+		if len(triggered) == 0 {
+			return
+		}
+		for _, order := range triggered {
+			me.triggerStopOrder(book, order)
+			me.matchOrder(book, order)
+			me.forgetIfGone(book, order)
+		}
 	}
-	matches, remaining := me.matchSellOrder(orderBook, order)
-	return matches, remaining, nil
 }
 
 // matchBuyOrder matches a buy order against the sell side
@@ -155,10 +602,12 @@ func (me *MatchingEngine) matchBuyOrder(book *types.StockOrderBook, buyOrder *ty
 	var matches []types.MatchedEvent
 	remainingQty := buyOrder.Quantity
 	originalBuyQty := buyOrder.Quantity
+	var decremented int64 // Removed by self-trade prevention rather than filled
+	selfTradeStopped := false
 	remainingBalance := buyOrder.AvailableBalance // Track remaining cash for market orders
 
 	// Iterate through best asks using heap
-	for remainingQty > 0 {
+	for remainingQty > 0 && !selfTradeStopped {
 		askPrice, ok := book.SellSide.GetBestPrice()
 		if !ok {
 			break // No more sell orders
@@ -174,137 +623,169 @@ func (me *MatchingEngine) matchBuyOrder(book *types.StockOrderBook, buyOrder *ty
 			break // Buyer can't afford any more shares
 		}
 
+		// A trade outside the price bands halts the stock instead
+		if reason := book.BandBreach(askPrice); reason != "" {
+			me.halt(book, buyOrder.StockTicker, reason, askPrice)
+			break
+		}
+
 		level := book.SellSide.GetBestLevel()
-		now := time.Now()
+		now := me.clock()
 		// Match against orders at this price level
-		for !level.IsEmpty() && remainingQty > 0 {
+		for !level.IsEmpty() && remainingQty > 0 && !selfTradeStopped {
 			// For market orders, re-check affordability at this price level
 			if buyOrder.OrderType == types.MarketOrder && remainingBalance < askPrice {
 				break
 			}
 
-			sellOrder := level.Front()
-			originalSellQty := sellOrder.Quantity
+			// The stock's matching policy shares what can be matched among the level's orders
+			allocQty := remainingQty
+			if buyOrder.OrderType == types.MarketOrder {
+				allocQty = min(allocQty, remainingBalance/askPrice)
+			}
+			allocations := book.Policy.Allocate(level, allocQty)
+			if len(allocations) == 0 {
+				break
+			}
 
-			// Calculate match quantity
-			matchQty := min(remainingQty, sellOrder.Quantity)
+			for _, allocation := range allocations {
+				sellOrder := allocation.Order
 
-			// For market orders, cap quantity by what the buyer can actually afford
-			if buyOrder.OrderType == types.MarketOrder {
-				affordableQty := remainingBalance / askPrice
-				if affordableQty < matchQty {
-					matchQty = affordableQty
+				// Orders from the same trader or owner group never trade with each other
+				if mode := me.selfTradeMode(buyOrder, sellOrder); mode != types.SelfTradeAllow {
+					decrement, stop := me.preventSelfTrade(book, book.SellSide, buyOrder, sellOrder, remainingQty, askPrice, mode)
+					remainingQty -= decrement
+					decremented += decrement
+					selfTradeStopped = stop
+					break // Re-allocate what is left of the level
 				}
-				if matchQty == 0 {
-					remainingQty = 0 // Force exit — can't afford any more
-					break
-				}
-			}
 
-			tradeCost := askPrice * matchQty
+				originalSellQty := sellOrder.Quantity
 
-			// Create match event
-			match := types.MatchedEvent{
-				BuyerOrderId:       buyOrder.OrderId,
-				SellerOrderId:      sellOrder.OrderId,
-				PricePerStockCents: askPrice,
-				Quantity:           matchQty,
-				Timestamp:          now,
-			}
-			matches = append(matches, match)
-			if me.eventStreamer != nil {
-				me.safePublish(&types.TradeExecutedEvent{
-					StockTicker:     buyOrder.StockTicker,
-					BuyerOrderID:    buyOrder.OrderId,
-					SellerOrderID:   sellOrder.OrderId,
-					BuyerOrderType:  buyOrder.OrderType,
-					BuyerTraderID:   buyOrder.TraderId,
-					SellerTraderID:  sellOrder.TraderId,
-					Quantity:        matchQty,
-					PriceCents:      askPrice,
-					TotalValueCents: tradeCost,
-				}, types.TradeExecuted)
-			}
-			// Update quantities
-			remainingQty -= matchQty
-			sellOrder.Quantity -= matchQty
+				// Calculate match quantity
+				matchQty := min(remainingQty, allocation.Quantity)
 
-			// Track spend for market orders
-			if buyOrder.OrderType == types.MarketOrder {
-				remainingBalance -= tradeCost
-			}
+				// For market orders, cap quantity by what the buyer can actually afford
+				if buyOrder.OrderType == types.MarketOrder {
+					affordableQty := remainingBalance / askPrice
+					if affordableQty < matchQty {
+						matchQty = affordableQty
+					}
+					if matchQty == 0 {
+						remainingQty = 0 // Force exit — can't afford any more
+						break
+					}
+				}
 
-			// Emit events for the resting sell order
-			if sellOrder.Quantity == 0 {
-				// Resting sell order fully filled
-				if me.eventStreamer != nil {
-					me.safePublish(&types.OrderFilledEvent{
-						OrderID:        sellOrder.OrderId,
-						TraderID:       sellOrder.TraderId,
-						Quantity:       originalSellQty,
-						FillPriceCents: askPrice,
-					}, types.OrderFilled)
+				tradeCost := askPrice * matchQty
+
+				// Create match event
+				match := types.MatchedEvent{
+					BuyerOrderId:       buyOrder.OrderId,
+					SellerOrderId:      sellOrder.OrderId,
+					PricePerStockCents: askPrice,
+					Quantity:           matchQty,
+					Timestamp:          now,
 				}
-				book.SellSide.RemoveOrder(sellOrder.OrderId)
-			} else {
-				// Resting sell order partially filled
+				matches = append(matches, match)
 				if me.eventStreamer != nil {
-					me.safePublish(&types.OrderPartiallyFilledEvent{
-						OrderID:           sellOrder.OrderId,
-						TraderID:          sellOrder.TraderId,
-						FilledQuantity:    matchQty,
-						RemainingQuantity: sellOrder.Quantity,
-						FillPriceCents:    askPrice,
-					}, types.OrderPartiallyFilled)
+					me.safePublish(book, &types.TradeExecutedEvent{
+						StockTicker:     buyOrder.StockTicker,
+						BuyerOrderID:    buyOrder.OrderId,
+						SellerOrderID:   sellOrder.OrderId,
+						BuyerOrderType:  buyOrder.OrderType,
+						BuyerTraderID:   buyOrder.TraderId,
+						SellerTraderID:  sellOrder.TraderId,
+						Quantity:        matchQty,
+						PriceCents:      askPrice,
+						TotalValueCents: tradeCost,
+					}, types.TradeExecuted)
+				}
+				// Update quantities
+				remainingQty -= matchQty
+				if book.SellSide.FillOrder(sellOrder, matchQty) {
+					me.orders.remove(sellOrder.OrderId)
+				}
+				me.recordTrade(book, askPrice)
+
+				// Track spend for market orders
+				if buyOrder.OrderType == types.MarketOrder {
+					remainingBalance -= tradeCost
+				}
+
+				// Emit events for the resting sell order
+				if sellOrder.Quantity == 0 {
+					// Resting sell order fully filled
+					if me.eventStreamer != nil {
+						me.safePublish(book, &types.OrderFilledEvent{
+							OrderID:        sellOrder.OrderId,
+							TraderID:       sellOrder.TraderId,
+							Quantity:       originalSellQty,
+							FillPriceCents: askPrice,
+						}, types.OrderFilled)
+					}
+				} else {
+					// Resting sell order partially filled
+					if me.eventStreamer != nil {
+						me.safePublish(book, &types.OrderPartiallyFilledEvent{
+							OrderID:           sellOrder.OrderId,
+							TraderID:          sellOrder.TraderId,
+							FilledQuantity:    matchQty,
+							RemainingQuantity: sellOrder.Quantity,
+							FillPriceCents:    askPrice,
+						}, types.OrderPartiallyFilled)
+					}
 				}
 			}
 		}
 	}
 
 	// Emit OrderFilledEvent for incoming buy order if fully consumed
-	if remainingQty == 0 && originalBuyQty > 0 {
+	filledQty := originalBuyQty - remainingQty - decremented
+	if remainingQty == 0 && decremented == 0 && originalBuyQty > 0 {
 		if me.eventStreamer != nil {
-			me.safePublish(&types.OrderFilledEvent{
+			me.safePublish(book, &types.OrderFilledEvent{
 				OrderID:        buyOrder.OrderId,
 				TraderID:       buyOrder.TraderId,
 				Quantity:       originalBuyQty,
 				FillPriceCents: 0, // Client calculates from partial events
 			}, types.OrderFilled)
 		}
-	} else if remainingQty > 0 && remainingQty < originalBuyQty {
+	} else if filledQty > 0 {
 		// Emit single partial event for incoming buy order if partially filled
 		if me.eventStreamer != nil {
-			me.safePublish(&types.OrderPartiallyFilledEvent{
+			me.safePublish(book, &types.OrderPartiallyFilledEvent{
 				OrderID:           buyOrder.OrderId,
 				TraderID:          buyOrder.TraderId,
-				FilledQuantity:    originalBuyQty - remainingQty,
+				FilledQuantity:    filledQty,
 				RemainingQuantity: remainingQty,
 				FillPriceCents:    0, // Multiple fills at different prices
 			}, types.OrderPartiallyFilled)
 		}
 	}
 
-	// Market orders: cancel unfilled portion (IOC behavior)
-	if remainingQty > 0 && buyOrder.OrderType == types.MarketOrder {
-		if me.eventStreamer != nil {
-			me.safePublish(&types.OrderCancelledEvent{
-				OrderID:           buyOrder.OrderId,
-				TraderID:          buyOrder.TraderId,
-				OrderType:         buyOrder.OrderType,
-				OrderSide:         buyOrder.OrderSide,
-				StockTicker:       buyOrder.StockTicker,
-				RemainingQuantity: remainingQty,
-			}, types.OrderCancelled)
-		}
+	// Self-trade prevention cancelled the rest of the incoming order
+	if selfTradeStopped {
+		me.publishCancelled(book, buyOrder, remainingQty, types.CancelReasonSelfTrade)
+		return matches, remainingQty + decremented
+	}
+
+	// Market and IOC/FOK orders: cancel unfilled portion
+	if remainingQty > 0 && !buyOrder.RestsOnBook() {
+		me.publishCancelled(book, buyOrder, remainingQty, types.CancelReasonImmediateOrCancel)
 	}
 
-	// If there's remaining quantity for a limit order, add to book
-	if remainingQty > 0 && buyOrder.OrderType == types.LimitOrder {
+	// If there's remaining quantity for a resting limit order, add to book
+	if remainingQty > 0 && buyOrder.RestsOnBook() {
 		buyOrder.Quantity = remainingQty
-		book.BuySide.AddOrder(buyOrder)
+		if decremented > 0 {
+			// Release what self-trade prevention decremented from the order's holds
+			me.publishAmended(book, buyOrder, remainingQty+decremented, buyOrder.LimitPrice, false)
+		}
+		book.Rest(buyOrder)
 	}
 
-	return matches, remainingQty
+	return matches, remainingQty + decremented
 }
 
 // matchSellOrder matches a sell order against the buy side
@@ -312,9 +793,11 @@ func (me *MatchingEngine) matchSellOrder(book *types.StockOrderBook, sellOrder *
 	var matches []types.MatchedEvent
 	remainingQty := sellOrder.Quantity
 	originalSellQty := sellOrder.Quantity
+	var decremented int64 // Removed by self-trade prevention rather than filled
+	selfTradeStopped := false
 
 	// Iterate through best bids using heap
-	for remainingQty > 0 {
+	for remainingQty > 0 && !selfTradeStopped {
 		bidPrice, ok := book.BuySide.GetBestPrice()
 		if !ok {
 			break // No more buy orders
@@ -325,162 +808,409 @@ func (me *MatchingEngine) matchSellOrder(book *types.StockOrderBook, sellOrder *
 			break // No more matches possible
 		}
 
+		// A trade outside the price bands halts the stock instead
+		if reason := book.BandBreach(bidPrice); reason != "" {
+			me.halt(book, sellOrder.StockTicker, reason, bidPrice)
+			break
+		}
+
 		level := book.BuySide.GetBestLevel()
-		now := time.Now()
+		now := me.clock()
 		// Match against orders at this price level
-		for !level.IsEmpty() && remainingQty > 0 {
-			buyOrder := level.Front()
-			originalBuyQty := buyOrder.Quantity
-
-			// Calculate match quantity
-			matchQty := min(remainingQty, buyOrder.Quantity)
-
-			// Create match event
-			match := types.MatchedEvent{
-				BuyerOrderId:       buyOrder.OrderId,
-				SellerOrderId:      sellOrder.OrderId,
-				PricePerStockCents: bidPrice,
-				Quantity:           matchQty,
-				Timestamp:          now,
-			}
-			matches = append(matches, match)
-
-			// Emit trade executed event
-			if me.eventStreamer != nil {
-				me.safePublish(&types.TradeExecutedEvent{
-					StockTicker:     sellOrder.StockTicker,
-					BuyerOrderID:    buyOrder.OrderId,
-					SellerOrderID:   sellOrder.OrderId,
-					BuyerOrderType:  buyOrder.OrderType,
-					BuyerTraderID:   buyOrder.TraderId,
-					SellerTraderID:  sellOrder.TraderId,
-					Quantity:        matchQty,
-					PriceCents:      bidPrice,
-					TotalValueCents: bidPrice * matchQty,
-				}, types.TradeExecuted)
+		for !level.IsEmpty() && remainingQty > 0 && !selfTradeStopped {
+			// The stock's matching policy shares what can be matched among the level's orders
+			allocQty := remainingQty
+			allocations := book.Policy.Allocate(level, allocQty)
+			if len(allocations) == 0 {
+				break
 			}
 
-			// Update quantities
-			remainingQty -= matchQty
-			buyOrder.Quantity -= matchQty
+			for _, allocation := range allocations {
+				buyOrder := allocation.Order
 
-			// Emit events for the resting buy order
-			if buyOrder.Quantity == 0 {
-				// Resting buy order fully filled
-				if me.eventStreamer != nil {
-					me.safePublish(&types.OrderFilledEvent{
-						OrderID:        buyOrder.OrderId,
-						TraderID:       buyOrder.TraderId,
-						Quantity:       originalBuyQty,
-						FillPriceCents: bidPrice,
-					}, types.OrderFilled)
+				// Orders from the same trader or owner group never trade with each other
+				if mode := me.selfTradeMode(sellOrder, buyOrder); mode != types.SelfTradeAllow {
+					decrement, stop := me.preventSelfTrade(book, book.BuySide, sellOrder, buyOrder, remainingQty, bidPrice, mode)
+					remainingQty -= decrement
+					decremented += decrement
+					selfTradeStopped = stop
+					break // Re-allocate what is left of the level
 				}
-				book.BuySide.RemoveOrder(buyOrder.OrderId)
-			} else {
-				// Resting buy order partially filled
+
+				originalBuyQty := buyOrder.Quantity
+
+				// Calculate match quantity
+				matchQty := min(remainingQty, allocation.Quantity)
+
+				// Create match event
+				match := types.MatchedEvent{
+					BuyerOrderId:       buyOrder.OrderId,
+					SellerOrderId:      sellOrder.OrderId,
+					PricePerStockCents: bidPrice,
+					Quantity:           matchQty,
+					Timestamp:          now,
+				}
+				matches = append(matches, match)
+
+				// Emit trade executed event
 				if me.eventStreamer != nil {
-					me.safePublish(&types.OrderPartiallyFilledEvent{
-						OrderID:           buyOrder.OrderId,
-						TraderID:          buyOrder.TraderId,
-						FilledQuantity:    matchQty,
-						RemainingQuantity: buyOrder.Quantity,
-						FillPriceCents:    bidPrice,
-					}, types.OrderPartiallyFilled)
+					me.safePublish(book, &types.TradeExecutedEvent{
+						StockTicker:     sellOrder.StockTicker,
+						BuyerOrderID:    buyOrder.OrderId,
+						SellerOrderID:   sellOrder.OrderId,
+						BuyerOrderType:  buyOrder.OrderType,
+						BuyerTraderID:   buyOrder.TraderId,
+						SellerTraderID:  sellOrder.TraderId,
+						Quantity:        matchQty,
+						PriceCents:      bidPrice,
+						TotalValueCents: bidPrice * matchQty,
+					}, types.TradeExecuted)
+				}
+
+				// Update quantities
+				remainingQty -= matchQty
+				if book.BuySide.FillOrder(buyOrder, matchQty) {
+					me.orders.remove(buyOrder.OrderId)
+				}
+				me.recordTrade(book, bidPrice)
+
+				// Emit events for the resting buy order
+				if buyOrder.Quantity == 0 {
+					// Resting buy order fully filled
+					if me.eventStreamer != nil {
+						me.safePublish(book, &types.OrderFilledEvent{
+							OrderID:        buyOrder.OrderId,
+							TraderID:       buyOrder.TraderId,
+							Quantity:       originalBuyQty,
+							FillPriceCents: bidPrice,
+						}, types.OrderFilled)
+					}
+				} else {
+					// Resting buy order partially filled
+					if me.eventStreamer != nil {
+						me.safePublish(book, &types.OrderPartiallyFilledEvent{
+							OrderID:           buyOrder.OrderId,
+							TraderID:          buyOrder.TraderId,
+							FilledQuantity:    matchQty,
+							RemainingQuantity: buyOrder.Quantity,
+							FillPriceCents:    bidPrice,
+						}, types.OrderPartiallyFilled)
+					}
 				}
 			}
 		}
 	}
 
 	// Emit OrderFilledEvent for incoming sell order if fully consumed
-	if remainingQty == 0 && originalSellQty > 0 {
+	filledQty := originalSellQty - remainingQty - decremented
+	if remainingQty == 0 && decremented == 0 && originalSellQty > 0 {
 		if me.eventStreamer != nil {
-			me.safePublish(&types.OrderFilledEvent{
+			me.safePublish(book, &types.OrderFilledEvent{
 				OrderID:        sellOrder.OrderId,
 				TraderID:       sellOrder.TraderId,
 				Quantity:       originalSellQty,
 				FillPriceCents: 0, // Client calculates from partial events
 			}, types.OrderFilled)
 		}
-	} else if remainingQty > 0 && remainingQty < originalSellQty {
+	} else if filledQty > 0 {
 		// Emit single partial event for incoming sell order if partially filled
 		if me.eventStreamer != nil {
-			me.safePublish(&types.OrderPartiallyFilledEvent{
+			me.safePublish(book, &types.OrderPartiallyFilledEvent{
 				OrderID:           sellOrder.OrderId,
 				TraderID:          sellOrder.TraderId,
-				FilledQuantity:    originalSellQty - remainingQty,
+				FilledQuantity:    filledQty,
 				RemainingQuantity: remainingQty,
 				FillPriceCents:    0, // Multiple fills at different prices
 			}, types.OrderPartiallyFilled)
 		}
 	}
 
-	// Market orders: cancel unfilled portion (IOC behavior)
-	if remainingQty > 0 && sellOrder.OrderType == types.MarketOrder {
-		if me.eventStreamer != nil {
-			me.safePublish(&types.OrderCancelledEvent{
-				OrderID:           sellOrder.OrderId,
-				TraderID:          sellOrder.TraderId,
-				OrderType:         sellOrder.OrderType,
-				OrderSide:         sellOrder.OrderSide,
-				StockTicker:       sellOrder.StockTicker,
-				RemainingQuantity: remainingQty,
-			}, types.OrderCancelled)
-		}
+	// Self-trade prevention cancelled the rest of the incoming order
+	if selfTradeStopped {
+		me.publishCancelled(book, sellOrder, remainingQty, types.CancelReasonSelfTrade)
+		return matches, remainingQty + decremented
 	}
 
-	// If there's remaining quantity for a limit order, add to book
-	if remainingQty > 0 && sellOrder.OrderType == types.LimitOrder {
+	// Market and IOC/FOK orders: cancel unfilled portion
+	if remainingQty > 0 && !sellOrder.RestsOnBook() {
+		me.publishCancelled(book, sellOrder, remainingQty, types.CancelReasonImmediateOrCancel)
+	}
+
+	// If there's remaining quantity for a resting limit order, add to book
+	if remainingQty > 0 && sellOrder.RestsOnBook() {
 		sellOrder.Quantity = remainingQty
-		book.SellSide.AddOrder(sellOrder)
+		if decremented > 0 {
+			// Release what self-trade prevention decremented from the order's holds
+			me.publishAmended(book, sellOrder, remainingQty+decremented, sellOrder.LimitPrice, false)
+		}
+		book.Rest(sellOrder)
 	}
 
-	return matches, remainingQty
+	return matches, remainingQty + decremented
 }
 
-// CancelOrder cancels an existing order
-// Returns (found, error) where found indicates if the order was found and canceled
-func (me *MatchingEngine) CancelOrder(stock, orderId string, side types.OrderSide) (bool, error) {
+// CancelOrder cancels an open order by ID on behalf of traderId, who must own it.
+// Returns (found, error) where found indicates if the order was found; an order owned by
+// another trader is found but left open, with an ErrorCodeUnauthorized rejection.
+func (me *MatchingEngine) CancelOrder(orderId string, traderId int64) (bool, error) {
+	me.stateMu.RLock()
+	defer me.stateMu.RUnlock()
 	// Validate inputs
-	if stock == "" {
-		return false, errors.New("stock cannot be empty")
-	}
 	if orderId == "" {
 		return false, errors.New("order ID cannot be empty")
 	}
 
-	value, exists := me.orderBooks.Load(stock)
+	location, indexed := me.orders.lookup(orderId)
+	if !indexed {
+		return false, nil
+	}
+	value, exists := me.orderBooks.Load(location.stock)
 	if !exists {
-		return false, nil // Order book doesn't exist, so order not found
+		return false, nil
 	}
 
 	book, ok := value.(*types.StockOrderBook)
 	if !ok {
 		return false, errors.New("invalid order book type in sync.Map")
 	}
-	book.Mu.Lock()
-	defer book.Mu.Unlock()
 
-	var order *types.Order
-	var removed bool
-	if side == types.Buy {
-		order, removed = book.BuySide.RemoveOrder(orderId)
-	} else {
-		order, removed = book.SellSide.RemoveOrder(orderId)
+	var found bool
+	var err error
+	me.onBook(book, func() {
+		// The order may have left the book between the lookup and taking the lock
+		order, open := findOrder(book, orderId, location.side)
+		if !open {
+			return
+		}
+		found = true
+		if order.TraderId != traderId {
+			err = &RejectionError{Code: types.ErrorCodeUnauthorized, Message: "The order belongs to another trader"}
+			return
+		}
+		if err = me.record(types.Command{Type: types.CommandCancel, Stock: location.stock, OrderId: orderId, Side: location.side}); err != nil {
+			return
+		}
+		me.cancelOrder(book, orderId, location.side)
+	})
+	return found, err
+}
+
+// findOrder returns an order resting on the given side of the book or held as a stop
+func findOrder(book *types.StockOrderBook, orderId string, side types.OrderSide) (*types.Order, bool) {
+	if order, found := book.Side(side).GetOrder(orderId); found {
+		return order, true
 	}
+	return book.Stops.GetOrder(orderId)
+}
+
+// cancelOrder removes an order from its side of the book, or from the held stops, and
+// announces the cancellation. Must be called with the book lock held.
+func (me *MatchingEngine) cancelOrder(book *types.StockOrderBook, orderId string, side types.OrderSide) bool {
+	order, removed := book.Side(side).RemoveOrder(orderId)
 	if !removed {
-		return false, nil
+		// Untriggered stop orders are held outside the book sides
+		order, removed = book.Stops.RemoveOrder(orderId)
 	}
+	if !removed {
+		return false
+	}
+	me.orders.remove(orderId)
 
-	if me.eventStreamer != nil {
-		me.safePublish(&types.OrderCancelledEvent{
-			OrderID:           order.OrderId,
-			TraderID:          order.TraderId,
-			OrderType:         order.OrderType,
-			OrderSide:         order.OrderSide,
-			StockTicker:       order.StockTicker,
-			RemainingQuantity: order.Quantity,
-		}, types.OrderCancelled)
+	me.publishCancelled(book, order, order.Quantity, types.CancelReasonUserRequested)
+	return true
+}
+
+// AmendOrder changes the remaining quantity and/or limit price of a resting limit order or a
+// held stop order on behalf of traderId, who must own it. Reducing the quantity at the same
+// price keeps the order's time priority; changing the price or increasing the quantity
+// re-queues it at the back of its level, matching first if the new price crosses. Held stops
+// are changed where they wait, and stop-market and trailing stops take a limit price of 0.
+// Sell increases must be covered by availableShares and increases in what a buy costs by
+// availableBalance.
+// Returns (matches, found, error) where found indicates if the order was found on the book;
+// an order owned by another trader is found but left as it was, with an ErrorCodeUnauthorized
+// rejection.
+func (me *MatchingEngine) AmendOrder(orderId string, traderId int64, newQuantity, newLimitPrice, availableShares, availableBalance int64) ([]types.MatchedEvent, bool, error) {
+	me.stateMu.RLock()
+	defer me.stateMu.RUnlock()
+	// Validate inputs
+	if orderId == "" {
+		return nil, false, errors.New("order ID cannot be empty")
+	}
+	// Amend rejections leave the order untouched, so no OrderRejectedEvent is published
+	if newQuantity <= 0 {
+		return nil, false, &RejectionError{Code: types.ErrorCodeInvalidQuantity, Message: "Quantity must be greater than 0"}
+	}
+	if newLimitPrice < 0 {
+		return nil, false, &RejectionError{Code: types.ErrorCodeInvalidPrice, Message: "Limit price must not be negative"}
+	}
+
+	location, indexed := me.orders.lookup(orderId)
+	if !indexed {
+		return nil, false, nil
+	}
+	value, exists := me.orderBooks.Load(location.stock)
+	if !exists {
+		return nil, false, nil
+	}
+
+	book, ok := value.(*types.StockOrderBook)
+	if !ok {
+		return nil, false, errors.New("invalid order book type in sync.Map")
 	}
 
-	return true, nil
+	var matches []types.MatchedEvent
+	var found bool
+	var err error
+	me.onBook(book, func() {
+		matches, found, err = me.checkAmend(book, orderId, location.side, traderId, newQuantity, newLimitPrice, availableShares, availableBalance)
+	})
+	return matches, found, err
+}
+
+// checkAmend validates an amendment against the order and its book, journals it and applies it.
+// Must be called with the book lock held.
+func (me *MatchingEngine) checkAmend(book *types.StockOrderBook, orderId string, side types.OrderSide, traderId, newQuantity, newLimitPrice, availableShares, availableBalance int64) ([]types.MatchedEvent, bool, error) {
+	// The order may have left the book between the lookup and taking the lock
+	order, found := findOrder(book, orderId, side)
+	if !found {
+		return nil, false, nil
+	}
+	if order.TraderId != traderId {
+		return nil, true, &RejectionError{Code: types.ErrorCodeUnauthorized, Message: "The order belongs to another trader"}
+	}
+	if me.session.current() == types.PhaseClosed {
+		return nil, true, &RejectionError{Code: types.ErrorCodeMarketClosed, Message: "The market is closed"}
+	}
+	if book.IsHalted() {
+		return nil, true, &RejectionError{Code: types.ErrorCodeStockNotTrading, Message: "Trading in this stock is halted"}
+	}
+	hasLimit := order.OrderType == types.LimitOrder || order.OrderType == types.StopLimitOrder
+	if hasLimit && newLimitPrice == 0 {
+		return nil, true, &RejectionError{Code: types.ErrorCodeInvalidPrice, Message: "Limit price must be greater than 0"}
+	}
+	if !hasLimit && newLimitPrice != 0 {
+		return nil, true, &RejectionError{Code: types.ErrorCodeInvalidPrice, Message: "Stop-market and trailing stops have no limit price"}
+	}
+	if !book.Spec.OnTick(newLimitPrice) {
+		return nil, true, &RejectionError{Code: types.ErrorCodeInvalidPrice, Message: fmt.Sprintf("Limit price must be a multiple of the %d cent tick size", book.Spec.TickSize)}
+	}
+	if newQuantity < book.Spec.MinQuantity || !book.Spec.OnLot(newQuantity) {
+		return nil, true, &RejectionError{Code: types.ErrorCodeInvalidQuantity, Message: fmt.Sprintf("Quantity must be a multiple of the %d share lot size and at least %d", book.Spec.LotSize, book.Spec.MinQuantity)}
+	}
+
+	oldQuantity, oldLimitPrice := order.Quantity, order.LimitPrice
+	if newQuantity == oldQuantity && newLimitPrice == oldLimitPrice {
+		return nil, true, nil
+	}
+	if side == types.Sell && newQuantity-oldQuantity > availableShares {
+		return nil, true, &RejectionError{Code: types.ErrorCodeInsufficientShares, Message: "Sell quantity increase exceeds available shares"}
+	}
+	if newQuantity < order.DisplayQuantity {
+		return nil, true, &RejectionError{Code: types.ErrorCodeInvalidQuantity, Message: "Quantity must not be below the display quantity"}
+	}
+	if order.PostOnly && newLimitPrice != oldLimitPrice {
+		price, ok := postOnlyPrice(book, order, newLimitPrice)
+		if !ok {
+			return nil, true, &RejectionError{Code: types.ErrorCodePostOnlyWouldCross, Message: "Post-only order would take liquidity"}
+		}
+		newLimitPrice = price
+	}
+	// Limit buys hold their remaining quantity at the limit price and stop-market and trailing-stop
+	// buys their reserve; the listener holds the increase
+	if reservesCash(order) && me.amendedReserve(order, newQuantity)-order.AvailableBalance > availableBalance {
+		return nil, true, &RejectionError{Code: types.ErrorCodeInsufficientFunds, Message: "Reserve increase exceeds available balance"}
+	}
+	if side == types.Buy && newQuantity*newLimitPrice-oldQuantity*oldLimitPrice > availableBalance {
+		return nil, true, &RejectionError{Code: types.ErrorCodeInsufficientFunds, Message: "Buy cost increase exceeds available balance"}
+	}
+
+	if err := me.record(types.Command{Type: types.CommandAmend, Stock: book.Stock(), OrderId: orderId, Side: side, Quantity: newQuantity, PriceCents: newLimitPrice}); err != nil {
+		return nil, true, &RejectionError{Code: types.ErrorCodeInternalError, Message: "The amendment could not be recorded"}
+	}
+	return me.amendOrder(book, order, newQuantity, newLimitPrice), true, nil
+}
+
+// amendOrder applies a validated amendment to a resting order, matching it if it was
+// re-queued at a crossing price, or to a held stop. Must be called with the book lock held.
+func (me *MatchingEngine) amendOrder(book *types.StockOrderBook, order *types.Order, newQuantity, newLimitPrice int64) []types.MatchedEvent {
+	oldQuantity, oldLimitPrice := order.Quantity, order.LimitPrice
+	if order.OrderType.IsStop() {
+		// Held stops have no place in a queue until they are triggered
+		if reservesCash(order) {
+			order.AvailableBalance = me.amendedReserve(order, newQuantity)
+		}
+		order.Quantity = newQuantity
+		order.LimitPrice = newLimitPrice
+		me.publishAmended(book, order, oldQuantity, oldLimitPrice, false)
+		return nil
+	}
+
+	bookSide := book.Side(order.OrderSide)
+	requeue := newLimitPrice != oldLimitPrice || newQuantity > oldQuantity
+	if requeue {
+		bookSide.RemoveOrder(order.OrderId)
+		order.Quantity = newQuantity
+		order.LimitPrice = newLimitPrice
+		order.Timestamp = me.clock()
+	} else {
+		bookSide.ReduceOrder(order.OrderId, newQuantity)
+	}
+
+	me.publishAmended(book, order, oldQuantity, oldLimitPrice, requeue)
+
+	if !requeue {
+		return nil
+	}
+	matches, _ := me.matchOrder(book, order)
+	me.forgetIfGone(book, order)
+	me.releaseTriggeredStops(book)
+	return matches
+}
+
+// ExpireOrders cancels every resting or held DAY/GTD order whose expiry is at or before now.
+// Returns the number of orders cancelled.
+func (me *MatchingEngine) ExpireOrders(now time.Time) int {
+	me.stateMu.RLock()
+	defer me.stateMu.RUnlock()
+	expired := 0
+	me.orderBooks.Range(func(key, value any) bool {
+		book, ok := value.(*types.StockOrderBook)
+		if !ok {
+			return true
+		}
+		me.onBook(book, func() {
+			if !book.Expiries.Due(now) {
+				return
+			}
+			if err := me.record(types.Command{Type: types.CommandExpire, Stock: key.(string), Time: now}); err != nil {
+				return // Retried on the next sweep
+			}
+			expired += me.expireOrders(book, now)
+		})
+		return true
+	})
+	return expired
+}
+
+// expireOrders cancels the book's orders whose expiry is at or before now.
+// Must be called with the book lock held.
+func (me *MatchingEngine) expireOrders(book *types.StockOrderBook, now time.Time) int {
+	expired := 0
+	for _, orderId := range book.Expiries.PopExpired(now) {
+		order, removed := book.BuySide.RemoveOrder(orderId)
+		if !removed {
+			order, removed = book.SellSide.RemoveOrder(orderId)
+		}
+		if !removed {
+			order, removed = book.Stops.RemoveOrder(orderId)
+		}
+		if !removed {
+			continue // Already filled or cancelled
+		}
+		me.orders.remove(orderId)
+		me.publishCancelled(book, order, order.Quantity, types.CancelReasonExpired)
+		expired++
+	}
+	return expired
 }
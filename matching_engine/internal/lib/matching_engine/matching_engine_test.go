@@ -1,9 +1,14 @@
 package matchingengine
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/events"
 	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/events/streaming_client/clients"
 	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
 )
@@ -226,7 +231,7 @@ func TestMatchingEngine(t *testing.T) {
 		engine.SubmitOrder(buyOrder)
 
 		// Cancel it
-		cancelled, err := engine.CancelOrder("AAPL", "buy1", types.Buy)
+		cancelled, err := engine.CancelOrder("buy1", 0)
 		if err != nil {
 			t.Errorf("unexpected error : %s", err.Error())
 		}
@@ -235,7 +240,7 @@ func TestMatchingEngine(t *testing.T) {
 		}
 
 		// Try to cancel again - should return false
-		cancelledAgain, err := engine.CancelOrder("AAPL", "buy1", types.Buy)
+		cancelledAgain, err := engine.CancelOrder("buy1", 0)
 		if err != nil {
 			t.Errorf("unexpected error : %s", err.Error())
 		}
@@ -382,3 +387,111 @@ func TestMatchingEngineConcurrency(t *testing.T) {
 		// Should not panic or deadlock
 	})
 }
+
+// Helper to create a limit order for a specific trader
+func newTraderOrder(id string, traderId int64, side types.OrderSide, qty, price int64) *types.Order {
+	order := newOrder(id, "AAPL", side, types.LimitOrder, qty, price)
+	order.TraderId = traderId
+	return order
+}
+
+// Helper to trade qty shares at price so the book has a last trade price
+func tradeAt(engine *MatchingEngine, id string, qty, price int64) {
+	engine.SubmitOrder(newOrder(id+"-sell", "AAPL", types.Sell, types.LimitOrder, qty, price))
+	engine.SubmitOrder(newOrder(id+"-buy", "AAPL", types.Buy, types.LimitOrder, qty, price))
+}
+
+func TestAvailableShares(t *testing.T) {
+	t.Run("should reject sells exceeding available shares", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+
+		engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 10, 15000))
+
+		sell := newOrder("sell1", "AAPL", types.Sell, types.MarketOrder, 10, 0)
+		shares := int64(5)
+		sell.AvailableShares = &shares
+		matches, _, err := engine.SubmitOrder(sell)
+		var rejection *RejectionError
+		if !errors.As(err, &rejection) || rejection.Code != types.ErrorCodeInsufficientShares {
+			t.Errorf("expected insufficient shares rejection, got %v", err)
+		}
+		if len(matches) != 0 {
+			t.Errorf("expected no trades, got %d", len(matches))
+		}
+	})
+
+	t.Run("should accept sells covered by available shares", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+
+		sell := newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 15000)
+		shares := int64(10)
+		sell.AvailableShares = &shares
+		if _, _, err := engine.SubmitOrder(sell); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("should not cap sells that leave available shares unset", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+
+		sell := newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 15000)
+		if _, _, err := engine.SubmitOrder(sell); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("should reject amends increasing a sell beyond available shares", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 15000))
+
+		_, _, err := engine.AmendOrder("sell1", 0, 20, 15000, 5, 0)
+		var rejection *RejectionError
+		if !errors.As(err, &rejection) || rejection.Code != types.ErrorCodeInsufficientShares {
+			t.Errorf("expected insufficient shares rejection, got %v", err)
+		}
+		if engine.getOrCreateOrderBook("AAPL").SellSide.GetBestLevel().Volume() != 10 {
+			t.Error("expected sell to be unchanged")
+		}
+	})
+}
+
+// envelopeStreamer keeps every published event as it reaches the stream
+type envelopeStreamer struct {
+	clients.TestStreamingClient
+	mu     sync.Mutex
+	events []types.Event
+}
+
+func (s *envelopeStreamer) Publish(ctx context.Context, eventData any, eventType types.EventType, seq types.EventSequence) error {
+	data, err := events.MarshalEvent(eventData, eventType, seq)
+	if err != nil {
+		return err
+	}
+	var evt types.Event
+	if err := json.Unmarshal(data, &evt); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, evt)
+	return nil
+}
+
+func TestClientOrderIDs(t *testing.T) {
+	t.Run("should publish the client order ID with the placed order", func(t *testing.T) {
+		streamer := &envelopeStreamer{}
+		engine := NewMatchingEngine(streamer)
+		order := newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 10, 15000)
+		order.ClientOrderId = "my-order-1"
+		engine.SubmitOrder(order)
+
+		var placed types.OrderPlacedEvent
+		if len(streamer.events) != 1 || json.Unmarshal(streamer.events[0].Data, &placed) != nil {
+			t.Fatalf("expected one OrderPlacedEvent, got %d events", len(streamer.events))
+		}
+		if placed.ClientOrderID != "my-order-1" {
+			t.Errorf("expected client order ID my-order-1, got %q", placed.ClientOrderID)
+		}
+	})
+}
@@ -0,0 +1,99 @@
+package matchingengine
+
+import (
+	"testing"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/events/streaming_client/clients"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+func filledBySeller(matches []types.MatchedEvent) map[string]int64 {
+	filled := make(map[string]int64)
+	for _, match := range matches {
+		filled[match.SellerOrderId] += match.Quantity
+	}
+	return filled
+}
+
+func TestMatchingPolicies(t *testing.T) {
+	t.Run("should fill the oldest order first by default", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 15000))
+		engine.SubmitOrder(newOrder("sell2", "AAPL", types.Sell, types.LimitOrder, 30, 15000))
+
+		matches, _, _ := engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 20, 15000))
+		filled := filledBySeller(matches)
+		if filled["sell1"] != 10 || filled["sell2"] != 10 {
+			t.Errorf("expected 10/10 FIFO fills, got %v", filled)
+		}
+	})
+
+	t.Run("should split pro-rata by resting quantity", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithMatchingPolicy("AAPL", types.ProRata{}))
+
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 15000))
+		engine.SubmitOrder(newOrder("sell2", "AAPL", types.Sell, types.LimitOrder, 30, 15000))
+
+		matches, remaining, _ := engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 20, 15000))
+		filled := filledBySeller(matches)
+		if remaining != 0 || filled["sell1"] != 5 || filled["sell2"] != 15 {
+			t.Errorf("expected 5/15 pro-rata fills, got %v (remaining %d)", filled, remaining)
+		}
+	})
+
+	t.Run("should give rounding leftovers to the oldest orders", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithMatchingPolicy("AAPL", types.ProRata{}))
+
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 15000))
+		engine.SubmitOrder(newOrder("sell2", "AAPL", types.Sell, types.LimitOrder, 10, 15000))
+		engine.SubmitOrder(newOrder("sell3", "AAPL", types.Sell, types.LimitOrder, 10, 15000))
+
+		matches, _, _ := engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 10, 15000))
+		filled := filledBySeller(matches)
+		if filled["sell1"] != 4 || filled["sell2"] != 3 || filled["sell3"] != 3 {
+			t.Errorf("expected 4/3/3 fills, got %v", filled)
+		}
+	})
+
+	t.Run("should fill the top order before sharing pro-rata", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithMatchingPolicy("AAPL", types.ProRataTopOrder{}))
+
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 15000))
+		engine.SubmitOrder(newOrder("sell2", "AAPL", types.Sell, types.LimitOrder, 20, 15000))
+		engine.SubmitOrder(newOrder("sell3", "AAPL", types.Sell, types.LimitOrder, 20, 15000))
+
+		matches, _, _ := engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 30, 15000))
+		filled := filledBySeller(matches)
+		if filled["sell1"] != 10 || filled["sell2"] != 10 || filled["sell3"] != 10 {
+			t.Errorf("expected 10/10/10 fills, got %v", filled)
+		}
+	})
+
+	t.Run("should only apply the policy to its stock", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithMatchingPolicy("MSFT", types.ProRata{}))
+
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 15000))
+		engine.SubmitOrder(newOrder("sell2", "AAPL", types.Sell, types.LimitOrder, 30, 15000))
+
+		matches, _, _ := engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 20, 15000))
+		if filled := filledBySeller(matches); filled["sell1"] != 10 {
+			t.Errorf("expected FIFO fills for AAPL, got %v", filled)
+		}
+	})
+
+	t.Run("should cap market buys by balance across allocations", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithMatchingPolicy("AAPL", types.ProRata{}))
+
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 100))
+		engine.SubmitOrder(newOrder("sell2", "AAPL", types.Sell, types.LimitOrder, 10, 100))
+
+		buy := newOrder("buy1", "AAPL", types.Buy, types.MarketOrder, 20, 0)
+		buy.AvailableBalance = 1000
+		matches, _, _ := engine.SubmitOrder(buy)
+		filled := filledBySeller(matches)
+		if filled["sell1"] != 5 || filled["sell2"] != 5 {
+			t.Errorf("expected 5/5 fills within balance, got %v", filled)
+		}
+	})
+}
@@ -0,0 +1,146 @@
+package matchingengine
+
+import (
+	"time"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+// Option configures optional MatchingEngine behaviour
+type Option func(*MatchingEngine)
+
+// WithDayClose sets how the expiry of DAY orders is derived from their submission time.
+// Defaults to midnight UTC at the end of the submission day.
+func WithDayClose(dayClose func(time.Time) time.Time) Option {
+	return func(me *MatchingEngine) {
+		me.dayClose = dayClose
+	}
+}
+
+// WithSelfTradePrevention sets what happens when an order would trade against another order
+// from the same trader, for orders that don't choose a mode themselves. Defaults to SelfTradeAllow.
+func WithSelfTradePrevention(mode types.SelfTradePrevention) Option {
+	return func(me *MatchingEngine) {
+		me.selfTrade = mode
+	}
+}
+
+// WithOwnerGroupSelfTrade extends self-trade prevention to a trader and the bots they own
+func WithOwnerGroupSelfTrade(enabled bool) Option {
+	return func(me *MatchingEngine) {
+		me.selfTradeByOwner = enabled
+	}
+}
+
+// WithMatchingPolicy sets how resting orders at a price level share incoming quantity for one
+// stock. Stocks without a policy use FIFO.
+func WithMatchingPolicy(stock string, policy types.MatchingPolicy) Option {
+	return func(me *MatchingEngine) {
+		if me.policies == nil {
+			me.policies = make(map[string]types.MatchingPolicy)
+		}
+		me.policies[stock] = policy
+	}
+}
+
+// WithStopReserveSlippage sets how far above its trigger price a stop-market or trailing-stop
+// buy may trade before running out of reserved cash, in basis points. Defaults to 0, which
+// reserves exactly the quantity at the trigger price.
+func WithStopReserveSlippage(bps int64) Option {
+	return func(me *MatchingEngine) {
+		me.stopSlippageBps = bps
+	}
+}
+
+// WithPriceBands sets the price bands of every stock without its own. Defaults to no bands.
+func WithPriceBands(bands types.PriceBands) Option {
+	return func(me *MatchingEngine) {
+		me.bands = bands
+	}
+}
+
+// WithStockPriceBands sets the price bands of one stock
+func WithStockPriceBands(stock string, bands types.PriceBands) Option {
+	return func(me *MatchingEngine) {
+		if me.stockBands == nil {
+			me.stockBands = make(map[string]types.PriceBands)
+		}
+		me.stockBands[stock] = bands
+	}
+}
+
+// WithInstrumentSpec sets the tick size, lot size and minimum quantity of one stock.
+// Stocks without a spec use types.DefaultInstrumentSpec.
+func WithInstrumentSpec(stock string, spec types.InstrumentSpec) Option {
+	return func(me *MatchingEngine) {
+		me.registry.put(types.Instrument{Ticker: stock, Spec: spec, Active: true})
+	}
+}
+
+// WithInstruments seeds the instrument registry. Once seeded, orders for tickers that
+// aren't registered are rejected with STOCK_NOT_FOUND.
+func WithInstruments(instruments []types.Instrument) Option {
+	return func(me *MatchingEngine) {
+		for _, instrument := range instruments {
+			me.registry.put(instrument)
+		}
+		me.registry.enforced = true
+	}
+}
+
+// WithSessionCalendar sets the market's trading day. Orders are rejected while the calendar
+// says the market is closed, and DAY orders expire at the next close.
+// Defaults to continuous trading at all times.
+func WithSessionCalendar(calendar *types.SessionCalendar) Option {
+	return func(me *MatchingEngine) {
+		me.session.calendar = calendar
+		me.session.phase = calendar.PhaseAt(time.Now())
+		me.dayClose = func(t time.Time) time.Time {
+			if end := calendar.NextClose(t); !end.IsZero() {
+				return end
+			}
+			return endOfDayUTC(t)
+		}
+	}
+}
+
+// WithJournal records every command that changes a book in journal before it is applied,
+// so Recover can rebuild the books after a restart
+func WithJournal(journal CommandJournal) Option {
+	return func(me *MatchingEngine) {
+		me.journal = journal
+	}
+}
+
+// WithSnapshots saves the books to store on SaveSnapshot and restores the latest of them
+// in Recover
+func WithSnapshots(store SnapshotStore) Option {
+	return func(me *MatchingEngine) {
+		me.snapshots = store
+	}
+}
+
+// WithSequencers gives every book its own goroutine that runs the stock's commands one at a
+// time from a queue of queueSize, instead of callers locking the book themselves. Events raised
+// by a command are queued together once it has finished matching, and a publisher goroutine
+// hands them to the streamer. A queueSize of 0 keeps
+// the book locks.
+func WithSequencers(queueSize int) Option {
+	return func(me *MatchingEngine) {
+		me.queueSize = queueSize
+	}
+}
+
+// WithPriceLadder sets how each side of a new book keeps its price levels in order.
+// Defaults to types.NewArrayLadder.
+func WithPriceLadder(newLadder func(isBuySide bool) types.PriceLadder) Option {
+	return func(me *MatchingEngine) {
+		me.newLadder = newLadder
+	}
+}
+
+// endOfDayUTC returns midnight UTC at the end of t's day
+func endOfDayUTC(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC)
+}
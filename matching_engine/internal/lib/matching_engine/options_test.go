@@ -0,0 +1,31 @@
+package matchingengine
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/events/streaming_client/clients"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+func TestWithPriceLadder(t *testing.T) {
+	t.Run("should match the same on either ladder", func(t *testing.T) {
+		var snapshots []types.BookSnapshot
+		for _, newLadder := range []func(bool) types.PriceLadder{types.NewArrayLadder, types.NewHeapLadder} {
+			engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithPriceLadder(newLadder))
+			for i := range 20 {
+				engine.SubmitOrder(newOrder(fmt.Sprintf("sell%d", i), "AAPL", types.Sell, types.LimitOrder, 10, int64(15000+i%7*10)))
+			}
+			engine.CancelOrder("sell3", 0)
+			engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 95, 15040))
+			engine.SubmitOrder(newOrder("buy2", "AAPL", types.Buy, types.LimitOrder, 10, 14900))
+			snapshot, _ := engine.Snapshot("AAPL", 0)
+			snapshot.Timestamp = time.Time{}
+			snapshots = append(snapshots, snapshot)
+		}
+		if fmt.Sprint(snapshots[0]) != fmt.Sprint(snapshots[1]) {
+			t.Errorf("expected identical books, got\n%+v\n%+v", snapshots[0], snapshots[1])
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package matchingengine
+
+import (
+	"sync"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+// orderLocation is the book and side an order rests on or is held for
+type orderLocation struct {
+	stock string
+	side  types.OrderSide
+}
+
+// orderIndex finds the book of every open order across stocks, so orders can be addressed by
+// ID alone. Entries are added when an order is accepted and dropped when it leaves its book;
+// the book stays the authority, so a lookup is always checked against it.
+type orderIndex struct {
+	mu     sync.RWMutex
+	orders map[string]orderLocation // orderId -> location
+}
+
+func (x *orderIndex) add(order *types.Order) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if x.orders == nil {
+		x.orders = make(map[string]orderLocation)
+	}
+	x.orders[order.OrderId] = orderLocation{stock: order.StockTicker, side: order.OrderSide}
+}
+
+func (x *orderIndex) remove(orderId string) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	delete(x.orders, orderId)
+}
+
+// lookup returns where an order was last seen, or false if it isn't open
+func (x *orderIndex) lookup(orderId string) (orderLocation, bool) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	location, ok := x.orders[orderId]
+	return location, ok
+}
+
+func (x *orderIndex) len() int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return len(x.orders)
+}
+
+// forgetIfGone drops an order that was just matched from the index unless it is left resting.
+// Must be called with the book lock held.
+func (me *MatchingEngine) forgetIfGone(book *types.StockOrderBook, order *types.Order) {
+	if _, found := findOrder(book, order.OrderId, order.OrderSide); !found {
+		me.orders.remove(order.OrderId)
+	}
+}
@@ -0,0 +1,102 @@
+package matchingengine
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/events/streaming_client/clients"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+func TestCancelByID(t *testing.T) {
+	t.Run("should find the order's stock and side from its ID", func(t *testing.T) {
+		streamer := &envelopeStreamer{}
+		engine := NewMatchingEngine(streamer)
+		engine.SubmitOrder(newTraderOrder("buy1", 1, types.Buy, 10, 15000))
+		sell := newTraderOrder("sell1", 2, types.Sell, 10, 16000)
+		sell.StockTicker = "MSFT"
+		engine.SubmitOrder(sell)
+
+		found, err := engine.CancelOrder("sell1", 2)
+		if err != nil || !found {
+			t.Fatalf("expected the order to be cancelled, got found=%v err=%v", found, err)
+		}
+		book := engine.getOrCreateOrderBook("MSFT")
+		if _, resting := book.SellSide.GetOrder("sell1"); resting {
+			t.Error("expected the order to be removed from the MSFT book")
+		}
+		last := streamer.events[len(streamer.events)-1]
+		if last.Type != types.OrderCancelled || last.StockTicker != "MSFT" {
+			t.Errorf("expected an OrderCancelledEvent for MSFT, got type %d for %s", last.Type, last.StockTicker)
+		}
+	})
+
+	t.Run("should reject cancelling another trader's order", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+		engine.SubmitOrder(newTraderOrder("buy1", 1, types.Buy, 10, 15000))
+
+		found, err := engine.CancelOrder("buy1", 2)
+		var rejection *RejectionError
+		if !found || !errors.As(err, &rejection) || rejection.Code != types.ErrorCodeUnauthorized {
+			t.Fatalf("expected an unauthorized rejection, got found=%v err=%v", found, err)
+		}
+		if _, resting := engine.getOrCreateOrderBook("AAPL").BuySide.GetOrder("buy1"); !resting {
+			t.Error("expected the order to stay on the book")
+		}
+	})
+
+	t.Run("should cancel held stop orders", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+		stop := newTraderOrder("stop1", 1, types.Sell, 10, 0)
+		stop.OrderType = types.StopMarketOrder
+		stop.TriggerPrice = 14000
+		engine.SubmitOrder(stop)
+
+		if found, err := engine.CancelOrder("stop1", 1); err != nil || !found {
+			t.Fatalf("expected the stop order to be cancelled, got found=%v err=%v", found, err)
+		}
+		if engine.getOrCreateOrderBook("AAPL").Stops.Len() != 0 {
+			t.Error("expected no held stop orders")
+		}
+	})
+
+	t.Run("should drop orders from the index once they leave the book", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+		engine.SubmitOrder(newTraderOrder("sell1", 1, types.Sell, 10, 15000))
+		engine.SubmitOrder(newTraderOrder("sell2", 1, types.Sell, 10, 15100))
+		engine.SubmitOrder(newTraderOrder("buy1", 2, types.Buy, 15, 15100)) // Fills sell1, part of sell2
+		ioc := newTraderOrder("buy2", 2, types.Buy, 10, 14000)
+		ioc.TimeInForce = types.ImmediateOrCancel
+		engine.SubmitOrder(ioc)
+
+		if engine.orders.len() != 1 {
+			t.Fatalf("expected only sell2 indexed, got %d orders", engine.orders.len())
+		}
+		if found, _ := engine.CancelOrder("sell1", 1); found {
+			t.Error("expected a filled order not to be found")
+		}
+		if found, _ := engine.CancelOrder("sell2", 1); !found {
+			t.Error("expected the partially filled order to be found")
+		}
+		if engine.orders.len() != 0 {
+			t.Errorf("expected an empty index, got %d orders", engine.orders.len())
+		}
+	})
+
+	t.Run("should index orders restored from a saved state", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+		engine.SubmitOrder(newTraderOrder("buy1", 1, types.Buy, 10, 15000))
+		stop := newTraderOrder("stop1", 1, types.Sell, 10, 0)
+		stop.OrderType = types.StopMarketOrder
+		stop.TriggerPrice = 14000
+		engine.SubmitOrder(stop)
+
+		restored := NewMatchingEngine(&clients.TestStreamingClient{})
+		restored.restore(engine.State())
+		for _, orderId := range []string{"buy1", "stop1"} {
+			if found, err := restored.CancelOrder(orderId, 1); err != nil || !found {
+				t.Errorf("expected %s to be cancelled, got found=%v err=%v", orderId, found, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,84 @@
+package matchingengine
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/events/streaming_client/clients"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+// Helper to create a post-only limit order
+func newPostOnlyOrder(id string, side types.OrderSide, qty, price int64, reprice bool) *types.Order {
+	order := newOrder(id, "AAPL", side, types.LimitOrder, qty, price)
+	order.PostOnly = true
+	order.PostOnlyReprice = reprice
+	return order
+}
+
+func TestPostOnlyOrders(t *testing.T) {
+	t.Run("should rest post-only order that does not cross", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 15000))
+
+		matches, remaining, err := engine.SubmitOrder(newPostOnlyOrder("buy1", types.Buy, 10, 14999, false))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(matches) != 0 || remaining != 10 {
+			t.Errorf("expected order to rest untouched, got %d matches and %d remaining", len(matches), remaining)
+		}
+	})
+
+	t.Run("should reject post-only order that would cross", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+
+		engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 10, 15000))
+
+		matches, _, err := engine.SubmitOrder(newPostOnlyOrder("sell1", types.Sell, 10, 15000, false))
+		var rejection *RejectionError
+		if !errors.As(err, &rejection) || rejection.Code != types.ErrorCodePostOnlyWouldCross {
+			t.Fatalf("expected post-only rejection, got %v", err)
+		}
+		if len(matches) != 0 {
+			t.Errorf("expected no trades, got %d", len(matches))
+		}
+		if engine.getOrCreateOrderBook("AAPL").BuySide.GetBestLevel().Volume() != 10 {
+			t.Error("expected resting bid to be untouched")
+		}
+	})
+
+	t.Run("should reprice crossing post-only order one tick away", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 15000))
+
+		buy := newPostOnlyOrder("buy1", types.Buy, 10, 15100, true)
+		matches, _, err := engine.SubmitOrder(buy)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(matches) != 0 {
+			t.Errorf("expected no trades, got %d", len(matches))
+		}
+		if buy.LimitPrice != 14999 {
+			t.Errorf("expected order repriced to 14999, got %d", buy.LimitPrice)
+		}
+		if price, _ := engine.getOrCreateOrderBook("AAPL").BuySide.GetBestPrice(); price != 14999 {
+			t.Errorf("expected best bid 14999, got %d", price)
+		}
+	})
+
+	t.Run("should reject post-only on non-resting orders", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+
+		order := newPostOnlyOrder("buy1", types.Buy, 10, 15000, false)
+		order.TimeInForce = types.ImmediateOrCancel
+		_, _, err := engine.SubmitOrder(order)
+		var rejection *RejectionError
+		if !errors.As(err, &rejection) || rejection.Code != types.ErrorCodeInvalidOrderType {
+			t.Errorf("expected invalid order type rejection, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,184 @@
+package matchingengine
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+// StockSide names one side of a stock's book
+type StockSide struct {
+	Stock string
+	Side  types.OrderSide
+}
+
+// TraderStock names a trader's position in a stock
+type TraderStock struct {
+	TraderId int64
+	Stock    string
+}
+
+// RebuildTotals is what the database records about open orders apart from the order rows the
+// books are rebuilt from. Rebuild reconciles the books against it.
+type RebuildTotals struct {
+	Quantities map[StockSide]int64   // Remaining quantity of open orders, held stops included
+	CashHolds  map[int64]int64       // Cash held for each trader's open buys, in cents
+	ShareHolds map[TraderStock]int64 // Shares held for each trader's open sells
+}
+
+// BookPrices are the prices a stock's book measures stops and price bands from
+type BookPrices struct {
+	LastTrade int64 // Price of the most recent trade, which stops trigger from
+	Reference int64 // Static price band reference, 0 if none
+}
+
+// Rebuild puts open orders loaded from the database back on their books, in the order given,
+// without matching them or publishing anything. Limit orders rest on their side and untriggered
+// stop orders are held until their trigger. Each stock's book starts from its prices. The books
+// are then reconciled against totals. Must be called before the engine takes any traffic.
+func (me *MatchingEngine) Rebuild(orders []*types.Order, prices map[string]BookPrices, totals RebuildTotals) error {
+	for stock, price := range prices {
+		book := me.getOrCreateOrderBook(stock)
+		book.Mu.Lock()
+		book.LastTradePrice = price.LastTrade
+		book.ReferencePrice = price.Reference
+		book.Mu.Unlock()
+	}
+	for _, order := range orders {
+		if err := checkRebuilt(order); err != nil {
+			return err
+		}
+		book := me.getOrCreateOrderBook(order.StockTicker)
+		book.Mu.Lock()
+		if _, listed := me.orders.lookup(order.OrderId); listed {
+			book.Mu.Unlock()
+			return fmt.Errorf("order %s is listed twice", order.OrderId)
+		}
+		if order.OrderType.IsStop() {
+			book.Stops.AddOrder(order)
+			book.Expiries.Schedule(order)
+		} else {
+			book.Rest(order)
+		}
+		me.orders.add(order)
+		book.Mu.Unlock()
+	}
+	return me.reconcile(totals)
+}
+
+// checkRebuilt rejects orders the engine could not have left open
+func checkRebuilt(order *types.Order) error {
+	if order.Quantity <= 0 {
+		return fmt.Errorf("order %s has no quantity left", order.OrderId)
+	}
+	if order.OrderType != types.LimitOrder && !order.OrderType.IsStop() {
+		return fmt.Errorf("order %s cannot rest on the book or be held", order.OrderId)
+	}
+	if (order.OrderType == types.LimitOrder || order.OrderType == types.StopLimitOrder) && order.LimitPrice <= 0 {
+		return fmt.Errorf("order %s has no limit price", order.OrderId)
+	}
+	if order.OrderType.IsStop() && order.TriggerPrice <= 0 {
+		return fmt.Errorf("stop order %s has no trigger price", order.OrderId)
+	}
+	return nil
+}
+
+// reconcile checks the books against the database's totals: each side must hold exactly the
+// quantity recorded for it, no book may be crossed, and the cash and shares held for every
+// trader must cover exactly their open orders
+func (me *MatchingEngine) reconcile(totals RebuildTotals) error {
+	quantities := make(map[StockSide]int64)
+	cashHolds := make(map[int64]int64)
+	shareHolds := make(map[TraderStock]int64)
+	var err error
+	me.orderBooks.Range(func(key, value any) bool {
+		book, ok := value.(*types.StockOrderBook)
+		if !ok {
+			return true
+		}
+		stock := key.(string)
+		book.Mu.Lock()
+		defer book.Mu.Unlock()
+
+		// A crossed book means trades are missing from the orders table
+		bid, hasBid := book.BuySide.GetBestPrice()
+		ask, hasAsk := book.SellSide.GetBestPrice()
+		if hasBid && hasAsk && bid >= ask {
+			err = fmt.Errorf("%s book is crossed: best bid %d, best ask %d", stock, bid, ask)
+			return false
+		}
+		count := func(order *types.Order) {
+			quantities[StockSide{Stock: stock, Side: order.OrderSide}] += order.Quantity
+			if order.OrderSide == types.Sell {
+				shareHolds[TraderStock{TraderId: order.TraderId, Stock: stock}] += order.Quantity
+			} else {
+				cashHolds[order.TraderId] += heldCash(order)
+			}
+		}
+		state := book.State()
+		for _, levels := range [][]types.LevelState{state.Bids, state.Asks} {
+			for _, level := range levels {
+				for i := range level.Orders {
+					count(&level.Orders[i].Order)
+				}
+			}
+		}
+		for i := range state.Stops {
+			count(&state.Stops[i].Order)
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	if key, found := firstMismatch(quantities, totals.Quantities); found {
+		return fmt.Errorf("%s %s orders hold %d shares on the books, %d in the database", key.Stock, sideName(key.Side), quantities[key], totals.Quantities[key])
+	}
+	if trader, found := firstMismatch(cashHolds, totals.CashHolds); found {
+		return fmt.Errorf("trader %d has %d cents held in the database, %d for their open buys", trader, totals.CashHolds[trader], cashHolds[trader])
+	}
+	if key, found := firstMismatch(shareHolds, totals.ShareHolds); found {
+		return fmt.Errorf("trader %d has %d %s shares held in the database, %d for their open sells", key.TraderId, totals.ShareHolds[key], key.Stock, shareHolds[key])
+	}
+	return nil
+}
+
+// heldCash is the cash the event listener holds for an open buy: the remaining quantity at the
+// limit price, or the reserve of a stop-market or trailing-stop buy
+func heldCash(order *types.Order) int64 {
+	if reservesCash(order) {
+		return reservedCash(order)
+	}
+	return order.Quantity * order.LimitPrice
+}
+
+// firstMismatch returns the first key, in a stable order, whose total counted on the books
+// differs from the one recorded; a missing entry counts as 0
+func firstMismatch[K comparable](counted, recorded map[K]int64) (K, bool) {
+	keys := make([]K, 0, len(counted)+len(recorded))
+	for key := range counted {
+		keys = append(keys, key)
+	}
+	for key := range recorded {
+		if _, ok := counted[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+	for _, key := range keys {
+		if counted[key] != recorded[key] {
+			return key, true
+		}
+	}
+	var none K
+	return none, false
+}
+
+func sideName(side types.OrderSide) string {
+	if side == types.Sell {
+		return "SELL"
+	}
+	return "BUY"
+}
@@ -0,0 +1,209 @@
+package matchingengine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/events/streaming_client/clients"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+// databaseTotals is what the database records for open orders that were placed as given
+func databaseTotals(orders []*types.Order) RebuildTotals {
+	totals := RebuildTotals{
+		Quantities: make(map[StockSide]int64),
+		CashHolds:  make(map[int64]int64),
+		ShareHolds: make(map[TraderStock]int64),
+	}
+	for _, order := range orders {
+		totals.Quantities[StockSide{Stock: order.StockTicker, Side: order.OrderSide}] += order.Quantity
+		switch {
+		case order.OrderSide == types.Sell:
+			totals.ShareHolds[TraderStock{TraderId: order.TraderId, Stock: order.StockTicker}] += order.Quantity
+		case order.OrderType == types.StopMarketOrder || order.OrderType == types.TrailingStopOrder:
+			totals.CashHolds[order.TraderId] += order.AvailableBalance
+		default:
+			totals.CashHolds[order.TraderId] += order.Quantity * order.LimitPrice
+		}
+	}
+	return totals
+}
+
+func TestRebuild(t *testing.T) {
+	t.Run("should rest orders in arrival order without publishing", func(t *testing.T) {
+		streamer := &recordingStreamer{}
+		engine := NewMatchingEngine(streamer)
+		orders := []*types.Order{
+			newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 10, 15000),
+			newOrder("buy2", "AAPL", types.Buy, types.LimitOrder, 5, 15000),
+			newIcebergOrder("sell1", types.Sell, 20, 15100, 5),
+		}
+		if err := engine.Rebuild(orders, nil, databaseTotals(orders)); err != nil {
+			t.Fatal(err)
+		}
+		if len(streamer.sequences) != 0 {
+			t.Errorf("expected no events, got %d", len(streamer.sequences))
+		}
+
+		matches, _, _ := engine.SubmitOrder(newOrder("sell2", "AAPL", types.Sell, types.LimitOrder, 12, 15000))
+		if len(matches) != 2 || matches[0].BuyerOrderId != "buy1" || matches[1].BuyerOrderId != "buy2" {
+			t.Errorf("expected the older order to fill first, got %+v", matches)
+		}
+		if level := engine.getOrCreateOrderBook("AAPL").SellSide.GetBestLevel(); level.Volume() != 5 {
+			t.Errorf("expected the iceberg to show 5, got %d", level.Volume())
+		}
+	})
+
+	t.Run("should hold untriggered stops until they trigger", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+		stopLimit := newStopOrder("stop1", "AAPL", types.Sell, types.StopLimitOrder, 5, 14800, 14900)
+		stopLimit.TraderId = 1
+		stopMarket := newStopOrder("stop2", "AAPL", types.Buy, types.StopMarketOrder, 4, 0, 15200)
+		stopMarket.TraderId = 2
+		stopMarket.AvailableBalance = 70000
+		orders := []*types.Order{
+			newTraderOrder("buy1", 3, types.Buy, 10, 14900),
+			newTraderOrder("sell1", 4, types.Sell, 10, 15100),
+			stopLimit,
+			stopMarket,
+		}
+		if err := engine.Rebuild(orders, nil, databaseTotals(orders)); err != nil {
+			t.Fatal(err)
+		}
+		book := engine.getOrCreateOrderBook("AAPL")
+		if _, held := book.Stops.GetOrder("stop1"); !held {
+			t.Fatal("expected the stop-limit order to be held")
+		}
+		if _, held := book.Stops.GetOrder("stop2"); !held {
+			t.Fatal("expected the stop-market order to be held")
+		}
+
+		// Trading at the trigger releases the sell stop onto the book
+		engine.SubmitOrder(newTraderOrder("sell2", 5, types.Sell, 10, 14900))
+		if _, held := book.Stops.GetOrder("stop1"); held {
+			t.Error("expected the stop-limit order to trigger")
+		}
+		if best, _ := book.SellSide.GetBestPrice(); best != 14800 {
+			t.Errorf("expected the triggered stop to rest at 14800, got %d", best)
+		}
+		if _, err := engine.CancelOrder("stop2", 2); err != nil {
+			t.Errorf("expected the held stop to be cancellable, got %v", err)
+		}
+	})
+
+	t.Run("should start each book from its last and reference price", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithPriceBands(types.PriceBands{DynamicBps: 500, HaltDuration: time.Minute}))
+		prices := map[string]BookPrices{"AAPL": {LastTrade: 10000, Reference: 9800}}
+		if err := engine.Rebuild(nil, prices, databaseTotals(nil)); err != nil {
+			t.Fatal(err)
+		}
+		book := engine.getOrCreateOrderBook("AAPL")
+		if book.LastTradePrice != 10000 || book.ReferencePrice != 9800 {
+			t.Errorf("expected last price 10000 and reference 9800, got %d and %d", book.LastTradePrice, book.ReferencePrice)
+		}
+
+		// The dynamic band is measured from the last price before anything trades
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 10600))
+		engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 10, 10600))
+		if !book.IsHalted() {
+			t.Error("expected a trade outside the band around the last price to halt")
+		}
+	})
+
+	t.Run("should trail from the trigger a trailing stop last moved to", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+		stop := newTrailingStopOrder("stop1", types.Sell, 10, 500, 0)
+		stop.TriggerPrice = 10500
+		orders := []*types.Order{stop}
+		if err := engine.Rebuild(orders, map[string]BookPrices{"AAPL": {LastTrade: 11000}}, databaseTotals(orders)); err != nil {
+			t.Fatal(err)
+		}
+
+		tradeAt(engine, "t1", 1, 10800)
+		if stop.TriggerPrice != 10500 {
+			t.Errorf("expected the trigger to stay at 10500, got %d", stop.TriggerPrice)
+		}
+		tradeAt(engine, "t2", 1, 10500)
+		if engine.getOrCreateOrderBook("AAPL").Stops.Len() != 0 {
+			t.Error("expected the trailing stop to trigger at its moved trigger")
+		}
+	})
+
+	t.Run("should keep each order's own self-trade prevention mode", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+		stop := newStopOrder("stop1", "AAPL", types.Buy, types.StopLimitOrder, 10, 15100, 15000)
+		stop.TraderId = 1
+		stop.SelfTrade = types.SelfTradeCancelNewest
+		orders := []*types.Order{newTraderOrder("sell1", 1, types.Sell, 10, 15100), stop}
+		if err := engine.Rebuild(orders, nil, databaseTotals(orders)); err != nil {
+			t.Fatal(err)
+		}
+
+		// The triggered stop meets its owner's resting sell
+		tradeAt(engine, "t1", 1, 15000)
+		book := engine.getOrCreateOrderBook("AAPL")
+		if level := book.SellSide.GetBestLevel(); level == nil || level.Volume() != 10 {
+			t.Error("expected the triggered stop not to trade with its owner's sell")
+		}
+		if !book.BuySide.IsEmpty() {
+			t.Error("expected the triggered stop to be cancelled")
+		}
+	})
+
+	t.Run("should refuse quantities the database does not record", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+		orders := []*types.Order{
+			newTraderOrder("buy1", 1, types.Buy, 10, 15000),
+			newTraderOrder("sell1", 2, types.Sell, 10, 15100),
+		}
+		totals := databaseTotals(orders)
+		// A fill the order rows missed
+		totals.Quantities[StockSide{Stock: "AAPL", Side: types.Sell}] = 6
+		if err := engine.Rebuild(orders, nil, totals); err == nil {
+			t.Error("expected the sell side to fail reconciliation")
+		}
+	})
+
+	t.Run("should refuse cash holds that do not match the open buys", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+		orders := []*types.Order{newTraderOrder("buy1", 1, types.Buy, 10, 15000)}
+		totals := databaseTotals(orders)
+		totals.CashHolds[1] -= 15000
+		if err := engine.Rebuild(orders, nil, totals); err == nil {
+			t.Error("expected the cash hold to fail reconciliation")
+		}
+	})
+
+	t.Run("should refuse share holds that do not match the open sells", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+		orders := []*types.Order{newTraderOrder("sell1", 1, types.Sell, 10, 15000)}
+		totals := databaseTotals(orders)
+		// Shares still held for a trader without open sells
+		totals.ShareHolds[TraderStock{TraderId: 2, Stock: "AAPL"}] = 5
+		if err := engine.Rebuild(orders, nil, totals); err == nil {
+			t.Error("expected the share hold to fail reconciliation")
+		}
+	})
+
+	t.Run("should refuse a crossed book", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+		orders := []*types.Order{
+			newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 10, 15100),
+			newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 15000),
+		}
+		if err := engine.Rebuild(orders, nil, databaseTotals(orders)); err == nil {
+			t.Error("expected a crossed book to fail reconciliation")
+		}
+	})
+
+	t.Run("should refuse an order listed twice", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+		orders := []*types.Order{
+			newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 10, 15000),
+			newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 10, 15000),
+		}
+		if err := engine.Rebuild(orders, nil, databaseTotals(orders)); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
@@ -0,0 +1,64 @@
+package matchingengine
+
+import "github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+
+// selfTradeMode returns the prevention mode for a match between incoming and resting,
+// or SelfTradeAllow if the orders belong to different traders and owner groups
+func (me *MatchingEngine) selfTradeMode(incoming, resting *types.Order) types.SelfTradePrevention {
+	sameTrader := incoming.TraderId == resting.TraderId
+	sameGroup := me.selfTradeByOwner && incoming.SelfTradeGroup() == resting.SelfTradeGroup()
+	if !sameTrader && !sameGroup {
+		return types.SelfTradeAllow
+	}
+	if incoming.SelfTrade != types.SelfTradeDefault {
+		return incoming.SelfTrade
+	}
+	return me.selfTrade
+}
+
+// preventSelfTrade applies mode instead of matching incoming against the resting order at
+// the front of its level. Returns how much the incoming order was decremented and whether it
+// must stop matching and have its remainder cancelled.
+// Must be called with the book lock held.
+func (me *MatchingEngine) preventSelfTrade(book *types.StockOrderBook, restingSide *types.OrderBookSide, incoming, resting *types.Order, remainingQty, price int64, mode types.SelfTradePrevention) (int64, bool) {
+	if me.eventStreamer != nil {
+		me.safePublish(book, &types.SelfTradePreventedEvent{
+			StockTicker:      incoming.StockTicker,
+			IncomingOrderID:  incoming.OrderId,
+			RestingOrderID:   resting.OrderId,
+			IncomingTraderID: incoming.TraderId,
+			RestingTraderID:  resting.TraderId,
+			Mode:             mode,
+			Quantity:         min(remainingQty, resting.VisibleQuantity()),
+			PriceCents:       price,
+		}, types.SelfTradePrevented)
+	}
+
+	switch mode {
+	case types.SelfTradeCancelOldest:
+		me.cancelResting(book, restingSide, resting)
+		return 0, false
+	case types.SelfTradeCancelBoth:
+		me.cancelResting(book, restingSide, resting)
+		return 0, true
+	case types.SelfTradeDecrement:
+		decrement := min(remainingQty, resting.Quantity)
+		if decrement == resting.Quantity {
+			me.cancelResting(book, restingSide, resting)
+		} else {
+			oldQuantity := resting.Quantity
+			restingSide.ReduceOrder(resting.OrderId, oldQuantity-decrement)
+			me.publishAmended(book, resting, oldQuantity, resting.LimitPrice, false)
+		}
+		return decrement, decrement == remainingQty
+	default: // SelfTradeCancelNewest
+		return 0, true
+	}
+}
+
+// cancelResting removes a resting order prevented from self-trading and cancels it
+func (me *MatchingEngine) cancelResting(book *types.StockOrderBook, restingSide *types.OrderBookSide, resting *types.Order) {
+	restingSide.RemoveOrder(resting.OrderId)
+	me.orders.remove(resting.OrderId)
+	me.publishCancelled(book, resting, resting.Quantity, types.CancelReasonSelfTrade)
+}
@@ -0,0 +1,170 @@
+package matchingengine
+
+import (
+	"testing"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/events/streaming_client/clients"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+func TestSelfTradePrevention(t *testing.T) {
+	t.Run("should allow self trades by default", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+
+		engine.SubmitOrder(newTraderOrder("sell1", 1, types.Sell, 10, 15000))
+		matches, _, _ := engine.SubmitOrder(newTraderOrder("buy1", 1, types.Buy, 10, 15000))
+		if len(matches) != 1 {
+			t.Errorf("expected 1 match, got %d", len(matches))
+		}
+	})
+
+	t.Run("should cancel newest order", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithSelfTradePrevention(types.SelfTradeCancelNewest))
+
+		engine.SubmitOrder(newTraderOrder("sell1", 1, types.Sell, 10, 15000))
+		matches, remaining, _ := engine.SubmitOrder(newTraderOrder("buy1", 1, types.Buy, 10, 15000))
+
+		if len(matches) != 0 || remaining != 10 {
+			t.Errorf("expected no trades and 10 unfilled, got %d matches and %d", len(matches), remaining)
+		}
+		book := engine.getOrCreateOrderBook("AAPL")
+		if !book.BuySide.IsEmpty() {
+			t.Error("expected incoming buy to be cancelled, not rested")
+		}
+		if book.SellSide.IsEmpty() {
+			t.Error("expected resting sell to remain")
+		}
+	})
+
+	t.Run("should cancel oldest order and keep matching", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithSelfTradePrevention(types.SelfTradeCancelOldest))
+
+		engine.SubmitOrder(newTraderOrder("sell1", 1, types.Sell, 10, 15000))
+		engine.SubmitOrder(newTraderOrder("sell2", 2, types.Sell, 10, 15000))
+		matches, _, _ := engine.SubmitOrder(newTraderOrder("buy1", 1, types.Buy, 10, 15000))
+
+		if len(matches) != 1 || matches[0].SellerOrderId != "sell2" {
+			t.Errorf("expected buy to skip own order and match sell2, got %+v", matches)
+		}
+		if !engine.getOrCreateOrderBook("AAPL").SellSide.IsEmpty() {
+			t.Error("expected own resting sell to be cancelled")
+		}
+	})
+
+	t.Run("should cancel both orders", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithSelfTradePrevention(types.SelfTradeCancelBoth))
+
+		engine.SubmitOrder(newTraderOrder("sell1", 1, types.Sell, 10, 15000))
+		engine.SubmitOrder(newTraderOrder("buy1", 1, types.Buy, 10, 15000))
+
+		book := engine.getOrCreateOrderBook("AAPL")
+		if !book.BuySide.IsEmpty() || !book.SellSide.IsEmpty() {
+			t.Error("expected both orders to be cancelled")
+		}
+	})
+
+	t.Run("should decrement both orders by the smaller quantity", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithSelfTradePrevention(types.SelfTradeDecrement))
+
+		engine.SubmitOrder(newTraderOrder("sell1", 1, types.Sell, 10, 15000))
+		matches, remaining, _ := engine.SubmitOrder(newTraderOrder("buy1", 1, types.Buy, 4, 15000))
+
+		if len(matches) != 0 || remaining != 4 {
+			t.Errorf("expected no trades and 4 unfilled, got %d matches and %d", len(matches), remaining)
+		}
+		book := engine.getOrCreateOrderBook("AAPL")
+		if !book.BuySide.IsEmpty() {
+			t.Error("expected incoming buy to be fully decremented")
+		}
+		if level := book.SellSide.GetBestLevel(); level == nil || level.Volume() != 6 {
+			t.Error("expected resting sell decremented to 6")
+		}
+	})
+
+	t.Run("should rest decremented remainder", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithSelfTradePrevention(types.SelfTradeDecrement))
+
+		engine.SubmitOrder(newTraderOrder("sell1", 1, types.Sell, 4, 15000))
+		engine.SubmitOrder(newTraderOrder("buy1", 1, types.Buy, 10, 15000))
+
+		book := engine.getOrCreateOrderBook("AAPL")
+		if !book.SellSide.IsEmpty() {
+			t.Error("expected resting sell to be cancelled")
+		}
+		if level := book.BuySide.GetBestLevel(); level == nil || level.Volume() != 6 {
+			t.Error("expected buy remainder of 6 to rest")
+		}
+	})
+
+	t.Run("should apply per-order mode over the engine default", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithSelfTradePrevention(types.SelfTradeCancelNewest))
+
+		engine.SubmitOrder(newTraderOrder("sell1", 1, types.Sell, 10, 15000))
+		buy := newTraderOrder("buy1", 1, types.Buy, 10, 15000)
+		buy.SelfTrade = types.SelfTradeAllow
+		matches, _, _ := engine.SubmitOrder(buy)
+		if len(matches) != 1 {
+			t.Errorf("expected order opting out to trade, got %d matches", len(matches))
+		}
+	})
+
+	t.Run("should prevent trades within an owner group", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{},
+			WithSelfTradePrevention(types.SelfTradeCancelNewest), WithOwnerGroupSelfTrade(true))
+
+		bot := newTraderOrder("sell1", 7, types.Sell, 10, 15000)
+		bot.OwnerTraderId = 1
+		engine.SubmitOrder(bot)
+
+		matches, _, _ := engine.SubmitOrder(newTraderOrder("buy1", 1, types.Buy, 10, 15000))
+		if len(matches) != 0 {
+			t.Errorf("expected owner not to trade with own bot, got %d matches", len(matches))
+		}
+
+		matches, _, _ = engine.SubmitOrder(newTraderOrder("buy2", 2, types.Buy, 10, 15000))
+		if len(matches) != 1 {
+			t.Errorf("expected other trader to match bot, got %d matches", len(matches))
+		}
+	})
+
+	t.Run("should kill FOK order that could only fill against its own resting order", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithSelfTradePrevention(types.SelfTradeCancelNewest))
+
+		engine.SubmitOrder(newTraderOrder("sell1", 2, types.Sell, 10, 15000))
+		engine.SubmitOrder(newTraderOrder("sell2", 1, types.Sell, 10, 15000))
+		// Matching would fill 10 against sell1, then stop at the trader's own sell2
+		buy := newTraderOrder("buy1", 1, types.Buy, 15, 15000)
+		buy.TimeInForce = types.FillOrKill
+		matches, remaining, _ := engine.SubmitOrder(buy)
+
+		if len(matches) != 0 || remaining != 15 {
+			t.Errorf("expected no trades and 15 unfilled, got %d matches and %d", len(matches), remaining)
+		}
+		if level := engine.getOrCreateOrderBook("AAPL").SellSide.GetBestLevel(); level.Volume() != 20 {
+			t.Errorf("expected both resting sells untouched, got volume %d", level.Volume())
+		}
+	})
+
+	t.Run("should not count own orders toward FOK depth when cancelling the oldest", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{},
+			WithSelfTradePrevention(types.SelfTradeCancelOldest), WithOwnerGroupSelfTrade(true))
+
+		bot := newTraderOrder("sell1", 7, types.Sell, 10, 15000)
+		bot.OwnerTraderId = 1
+		engine.SubmitOrder(bot)
+		engine.SubmitOrder(newTraderOrder("sell2", 2, types.Sell, 10, 15000))
+
+		buy := newTraderOrder("buy1", 1, types.Buy, 15, 15000)
+		buy.TimeInForce = types.FillOrKill
+		if matches, _, _ := engine.SubmitOrder(buy); len(matches) != 0 {
+			t.Errorf("expected FOK to be killed, got %d matches", len(matches))
+		}
+
+		buy = newTraderOrder("buy2", 1, types.Buy, 10, 15000)
+		buy.TimeInForce = types.FillOrKill
+		matches, _, _ := engine.SubmitOrder(buy)
+		if len(matches) != 1 || matches[0].SellerOrderId != "sell2" {
+			t.Errorf("expected FOK to skip the bot's order and fill against sell2, got %+v", matches)
+		}
+	})
+}
@@ -0,0 +1,170 @@
+package matchingengine
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+// sequencer is the goroutine that owns one stock's book. Commands for the stock queue on a
+// bounded channel and run one at a time, so callers wait in line instead of contending for
+// the book lock, and a full queue holds back new commands until the book catches up.
+type sequencer struct {
+	commands chan func()
+	pending  []pendingEvent // Events raised by the running command, published once it finishes
+}
+
+// publishTimeout bounds how long the streamer may take over one event
+const publishTimeout = 500 * time.Millisecond
+
+// pendingEvent is an event with its stock sequence number, waiting for its global one
+type pendingEvent struct {
+	data      any
+	eventType types.EventType
+	seq       types.EventSequence
+}
+
+// run executes the book's commands in the order they were queued
+func (s *sequencer) run() {
+	for cmd := range s.commands {
+		cmd()
+	}
+}
+
+// sequencerOf returns the sequencer owning a book, starting it on first use, or nil if the
+// engine locks books instead
+func (me *MatchingEngine) sequencerOf(book *types.StockOrderBook) *sequencer {
+	if me.queueSize <= 0 {
+		return nil
+	}
+	if value, ok := me.sequencers.Load(book.Stock()); ok {
+		return value.(*sequencer)
+	}
+	value, loaded := me.sequencers.LoadOrStore(book.Stock(), &sequencer{commands: make(chan func(), me.queueSize)})
+	s := value.(*sequencer)
+	if !loaded {
+		me.running.Add(1)
+		go func() {
+			defer me.running.Done()
+			s.run()
+		}()
+	}
+	return s
+}
+
+// Close stops every book's sequencer once the commands queued on it have run, and returns when
+// their goroutines have exited and their events have been published. Commands that arrive
+// afterwards lock their books directly, as without sequencers.
+func (me *MatchingEngine) Close() {
+	// Commands hold the state lock shared until they finish, so none is queued once it is taken
+	me.stateMu.Lock()
+	defer me.stateMu.Unlock()
+	me.queueSize = 0
+	me.sequencers.Range(func(key, value any) bool {
+		close(value.(*sequencer).commands)
+		me.sequencers.Delete(key)
+		return true
+	})
+	me.running.Wait()
+
+	me.sequenceMu.Lock()
+	if me.wake != nil {
+		close(me.wake)
+		me.wake = nil
+	}
+	me.sequenceMu.Unlock()
+	me.publisher.Wait()
+}
+
+// onBook runs fn with the book lock held and returns once it is done. With sequencers fn runs
+// on the book's goroutine, and the events it raises are queued for the publisher together once
+// the lock is released; otherwise fn runs on the caller's goroutine and publishes as it goes. Commands
+// never journaled, such as Recover's replay, lock the book directly instead.
+func (me *MatchingEngine) onBook(book *types.StockOrderBook, fn func()) {
+	s := me.sequencerOf(book)
+	if s == nil {
+		book.Mu.Lock()
+		defer book.Mu.Unlock()
+		fn()
+		return
+	}
+
+	done := make(chan any, 1)
+	s.commands <- func() {
+		// A panic is handed back to the caller, where the gRPC recovery interceptor sees it,
+		// rather than taking the book's goroutine and the process down with it
+		defer func() { done <- recover() }()
+		defer func() {
+			me.publish(s.pending...)
+			clear(s.pending)
+			s.pending = s.pending[:0]
+		}()
+		book.Mu.Lock()
+		defer book.Mu.Unlock()
+		fn()
+	}
+	if r := <-done; r != nil {
+		panic(r)
+	}
+}
+
+// publish gives events their global sequence numbers and queues them for the streamer in
+// order. With sequencers the publisher goroutine hands them over, so a book's goroutine never
+// waits on the stream; otherwise the caller does before returning.
+func (me *MatchingEngine) publish(events ...pendingEvent) {
+	if len(events) == 0 {
+		return
+	}
+	me.sequenceMu.Lock()
+	for _, e := range events {
+		me.sequence++
+		e.seq.Epoch = me.epoch
+		e.seq.Sequence = me.sequence
+		me.outbox = append(me.outbox, e)
+	}
+	async := me.wake != nil && me.queueSize > 0
+	if async {
+		select {
+		case me.wake <- struct{}{}:
+		default: // The publisher is already due to run
+		}
+	}
+	me.sequenceMu.Unlock()
+	if !async {
+		me.flush()
+	}
+}
+
+// flush hands every numbered event to the streamer, each with its own timeout. Flushes take
+// turns, so the stream carries events in sequence order.
+func (me *MatchingEngine) flush() {
+	me.publishMu.Lock()
+	defer me.publishMu.Unlock()
+	for {
+		me.sequenceMu.Lock()
+		events := me.outbox
+		me.outbox = nil
+		me.sequenceMu.Unlock()
+		if len(events) == 0 {
+			return
+		}
+		for _, e := range events {
+			ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+			if err := me.eventStreamer.Publish(ctx, e.data, e.eventType, e.seq); err != nil {
+				log.Printf("event publish failed: %v, type=%d, sequence=%d", err, int(e.eventType), e.seq.Sequence)
+			}
+			cancel()
+		}
+	}
+}
+
+// runPublisher flushes each time events are queued, until wake is closed
+func (me *MatchingEngine) runPublisher(wake <-chan struct{}) {
+	defer me.publisher.Done()
+	for range wake {
+		me.flush()
+	}
+	me.flush()
+}
@@ -0,0 +1,231 @@
+package matchingengine
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/events/streaming_client/clients"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+// blockingStreamer holds every event until release is closed, like a stream that has stalled
+type blockingStreamer struct {
+	clients.TestStreamingClient
+	release   chan struct{}
+	mu        sync.Mutex
+	sequences []int64
+}
+
+func (s *blockingStreamer) Publish(ctx context.Context, eventData any, eventType types.EventType, seq types.EventSequence) error {
+	<-s.release
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sequences = append(s.sequences, seq.Sequence)
+	return nil
+}
+
+func TestSequencers(t *testing.T) {
+	script := func(engine *MatchingEngine) {
+		engine.SubmitOrder(newTraderOrder("sell1", 1, types.Sell, 10, 15000))
+		engine.SubmitOrder(newTraderOrder("sell2", 2, types.Sell, 10, 15100))
+		engine.SubmitOrder(newTraderOrder("buy1", 3, types.Buy, 15, 15100))
+		engine.AmendOrder("sell2", 2, 3, 15100, 0, 0)
+		engine.SubmitOrder(newTraderOrder("buy2", 3, types.Buy, 5, 14900))
+		engine.CancelOrder("buy2", 3)
+		engine.StartAuction("AAPL")
+		engine.SubmitOrder(newTraderOrder("buy3", 4, types.Buy, 5, 15200))
+		engine.SubmitOrder(newTraderOrder("sell3", 5, types.Sell, 5, 15000))
+		engine.Uncross("AAPL", false)
+	}
+
+	t.Run("should publish the same events as locked books", func(t *testing.T) {
+		locked := &envelopeStreamer{}
+		script(NewMatchingEngine(locked))
+		sequenced := &envelopeStreamer{}
+		engine := NewMatchingEngine(sequenced, WithSequencers(4))
+		script(engine)
+		engine.Close()
+
+		if len(sequenced.events) != len(locked.events) {
+			t.Fatalf("expected %d events, got %d", len(locked.events), len(sequenced.events))
+		}
+		for i, evt := range sequenced.events {
+			want := locked.events[i]
+			if evt.Type != want.Type || evt.Sequence != want.Sequence || evt.StockSequence != want.StockSequence || string(evt.Data) != string(want.Data) {
+				t.Fatalf("event %d differs: expected %d %s, got %d %s", i, want.Type, want.Data, evt.Type, evt.Data)
+			}
+		}
+	})
+
+	t.Run("should not wait on the stream to finish a command", func(t *testing.T) {
+		streamer := &blockingStreamer{release: make(chan struct{})}
+		engine := NewMatchingEngine(streamer, WithSequencers(4))
+		submitted := make(chan struct{})
+		go func() {
+			defer close(submitted)
+			engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 15000))
+			engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 10, 15000))
+			engine.SubmitOrder(newOrder("sell2", "MSFT", types.Sell, types.LimitOrder, 10, 30000))
+		}()
+		select {
+		case <-submitted:
+		case <-time.After(time.Second):
+			t.Fatal("expected commands to finish while the stream is stalled")
+		}
+
+		close(streamer.release)
+		engine.Close()
+		if len(streamer.sequences) == 0 {
+			t.Fatal("expected the events to be published once the stream resumed")
+		}
+		for i, seq := range streamer.sequences {
+			if seq != int64(i+1) {
+				t.Fatalf("expected events in sequence order, got %d at %d", seq, i+1)
+			}
+		}
+	})
+
+	t.Run("should keep each stock's events in order under concurrency", func(t *testing.T) {
+		streamer := &recordingStreamer{}
+		engine := NewMatchingEngine(streamer, WithSequencers(2))
+		var wg sync.WaitGroup
+		for _, stock := range []string{"AAPL", "MSFT", "GOOG", "AMZN"} {
+			for side := range 2 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for i := range 50 {
+						engine.SubmitOrder(newOrder(fmt.Sprintf("%s-%d-%d", stock, side, i), stock, types.OrderSide(side), types.LimitOrder, 1, 10000))
+					}
+				}()
+			}
+		}
+		wg.Wait()
+		engine.Close()
+
+		stockSequences := make(map[string]int64)
+		for i, seq := range streamer.sequences {
+			if seq.Sequence != int64(i+1) {
+				t.Fatalf("expected events to reach the stream in sequence order, got %d at %d", seq.Sequence, i+1)
+			}
+			stockSequences[seq.StockTicker]++
+			if seq.StockSequence != stockSequences[seq.StockTicker] {
+				t.Fatalf("expected %s sequence %d, got %d", seq.StockTicker, stockSequences[seq.StockTicker], seq.StockSequence)
+			}
+		}
+	})
+
+	t.Run("should hand a panic back to the caller", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithSequencers(4))
+		book := engine.getOrCreateOrderBook("AAPL")
+		func() {
+			defer func() {
+				if r := recover(); r != "boom" {
+					t.Errorf("expected the command's panic, got %v", r)
+				}
+			}()
+			engine.onBook(book, func() { panic("boom") })
+		}()
+
+		// The book's goroutine and lock survive the panic
+		if _, remaining, err := engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 10, 15000)); err != nil || remaining != 10 {
+			t.Errorf("expected the book to keep taking orders, got remaining %d, error %v", remaining, err)
+		}
+	})
+
+	t.Run("should drain and stop the sequencers on close", func(t *testing.T) {
+		streamer := &recordingStreamer{}
+		engine := NewMatchingEngine(streamer, WithSequencers(4))
+		var wg sync.WaitGroup
+		for _, stock := range []string{"AAPL", "MSFT"} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range 20 {
+					engine.SubmitOrder(newOrder(fmt.Sprintf("%s-%d", stock, i), stock, types.Buy, types.LimitOrder, 1, 10000))
+				}
+			}()
+		}
+		wg.Wait()
+		engine.Close()
+
+		stopped := 0
+		engine.sequencers.Range(func(_, _ any) bool {
+			stopped++
+			return true
+		})
+		if stopped != 0 || len(streamer.sequences) != 40 {
+			t.Fatalf("expected no sequencers left and 40 events, got %d and %d", stopped, len(streamer.sequences))
+		}
+		// Later commands lock the book themselves
+		if _, remaining, err := engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 10, 15000)); err != nil || remaining != 10 {
+			t.Errorf("expected the book to keep taking orders, got remaining %d, error %v", remaining, err)
+		}
+	})
+}
+
+// slowStreamer takes delay to accept each event, like a stream client whose buffer is backing up
+type slowStreamer struct {
+	clients.TestStreamingClient
+	delay time.Duration
+}
+
+func (s *slowStreamer) Publish(ctx context.Context, eventData any, eventType types.EventType, seq types.EventSequence) error {
+	for start := time.Now(); time.Since(start) < s.delay; {
+	}
+	return nil
+}
+
+// BenchmarkSubmitOrder compares locking each book with running it on its own sequencer.
+// Submitters share four stocks and alternate sides so most orders trade. Besides the time per
+// order it reports the throughput in orders per second and the median and 99th percentile
+// latency of a submission.
+func BenchmarkSubmitOrder(b *testing.B) {
+	stocks := []string{"AAPL", "MSFT", "GOOG", "AMZN"}
+	designs := []struct {
+		name string
+		opts []Option
+	}{
+		{"locks", nil},
+		{"sequencers", []Option{WithSequencers(1024)}},
+	}
+	for _, delay := range []time.Duration{0, 5 * time.Microsecond} {
+		for _, design := range designs {
+			b.Run(fmt.Sprintf("%s/publish=%s", design.name, delay), func(b *testing.B) {
+				engine := NewMatchingEngine(&slowStreamer{delay: delay}, design.opts...)
+				var next atomic.Int64
+				var mu sync.Mutex
+				var latencies []time.Duration
+
+				b.ResetTimer()
+				start := time.Now()
+				b.RunParallel(func(pb *testing.PB) {
+					var own []time.Duration
+					for pb.Next() {
+						n := next.Add(1)
+						order := newOrder(fmt.Sprintf("order-%d", n), stocks[n%int64(len(stocks))], types.OrderSide(n/int64(len(stocks))%2), types.LimitOrder, 10, 15000)
+						submitted := time.Now()
+						engine.SubmitOrder(order)
+						own = append(own, time.Since(submitted))
+					}
+					mu.Lock()
+					latencies = append(latencies, own...)
+					mu.Unlock()
+				})
+				elapsed := time.Since(start)
+				b.StopTimer()
+				engine.Close()
+
+				slices.Sort(latencies)
+				b.ReportMetric(float64(len(latencies))/elapsed.Seconds(), "orders/s")
+				b.ReportMetric(float64(latencies[len(latencies)/2].Nanoseconds()), "p50-ns")
+				b.ReportMetric(float64(latencies[len(latencies)*99/100].Nanoseconds()), "p99-ns")
+			})
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package matchingengine
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+// session tracks the market's phase of the trading day. Without a calendar the market is
+// always in continuous trading.
+type session struct {
+	mu       sync.RWMutex
+	calendar *types.SessionCalendar
+	phase    types.SessionPhase
+}
+
+// current returns the phase the market is in
+func (s *session) current() types.SessionPhase {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.calendar == nil {
+		return types.PhaseContinuous
+	}
+	return s.phase
+}
+
+// SessionPhase returns the phase the market is in
+func (me *MatchingEngine) SessionPhase() types.SessionPhase {
+	return me.session.current()
+}
+
+// AdvanceSession moves the market to the phase its calendar has for now. Entering pre-open or
+// close puts every book into auction mode; leaving them uncrosses the books (a closing auction
+// when leaving close). Every change publishes a SessionPhaseChangedEvent.
+// Returns the phase the market is in.
+func (me *MatchingEngine) AdvanceSession(now time.Time) types.SessionPhase {
+	me.stateMu.RLock()
+	defer me.stateMu.RUnlock()
+	me.session.mu.Lock()
+	if me.session.calendar == nil {
+		me.session.mu.Unlock()
+		return types.PhaseContinuous
+	}
+	previous := me.session.phase
+	next := me.session.calendar.PhaseAt(now)
+	if next == previous {
+		me.session.mu.Unlock()
+		return next
+	}
+	if err := me.record(types.Command{Type: types.CommandSessionPhase, Phase: next, Time: now}); err != nil {
+		me.session.mu.Unlock()
+		return previous // Retried on the next sweep
+	}
+	me.session.phase = next
+	me.session.mu.Unlock()
+
+	me.enterPhase(previous, next, now)
+	return next
+}
+
+// enterPhase applies a session phase change to every book and announces it
+func (me *MatchingEngine) enterPhase(previous, next types.SessionPhase, now time.Time) {
+	me.orderBooks.Range(func(key, value any) bool {
+		book, ok := value.(*types.StockOrderBook)
+		if !ok {
+			return true
+		}
+		stock := key.(string)
+
+		me.onBook(book, func() {
+			// Halted books reopen through ResumeHalted instead
+			if book.IsHalted() {
+				return
+			}
+			if previous.CollectsOrders() {
+				me.uncross(book, stock, previous == types.PhaseClose)
+			}
+			if next.CollectsOrders() {
+				book.InAuction = true
+			}
+		})
+		return true
+	})
+
+	if me.eventStreamer != nil {
+		me.safePublish(nil, &types.SessionPhaseChangedEvent{
+			Phase:         next,
+			PreviousPhase: previous,
+			ChangedAt:     now,
+		}, types.SessionPhaseChanged)
+	}
+}
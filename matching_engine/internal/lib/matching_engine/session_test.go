@@ -0,0 +1,119 @@
+package matchingengine
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/events/streaming_client/clients"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+// newTestCalendar trades every day of the week in UTC: pre-open at 08:00, continuous from
+// 09:00, closing auction from 16:50 and closed from 17:00
+func newTestCalendar(holidays ...string) *types.SessionCalendar {
+	day := []types.PhaseStart{
+		{Phase: types.PhasePreOpen, At: 8 * time.Hour},
+		{Phase: types.PhaseContinuous, At: 9 * time.Hour},
+		{Phase: types.PhaseClose, At: 16*time.Hour + 50*time.Minute},
+		{Phase: types.PhaseClosed, At: 17 * time.Hour},
+	}
+	days := make(map[time.Weekday][]types.PhaseStart)
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		days[weekday] = day
+	}
+	return types.NewSessionCalendar(time.UTC, days, holidays)
+}
+
+func TestSessionCalendar(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, time.March, 2, hour, minute, 0, 0, time.UTC)
+	}
+
+	t.Run("should follow the phases of the trading day", func(t *testing.T) {
+		calendar := newTestCalendar("2026-03-03")
+		cases := []struct {
+			at    time.Time
+			phase types.SessionPhase
+		}{
+			{at(7, 59), types.PhaseClosed},
+			{at(8, 0), types.PhasePreOpen},
+			{at(12, 0), types.PhaseContinuous},
+			{at(16, 55), types.PhaseClose},
+			{at(17, 0), types.PhaseClosed},
+			{at(12, 0).AddDate(0, 0, 1), types.PhaseClosed}, // Holiday
+		}
+		for _, c := range cases {
+			if phase := calendar.PhaseAt(c.at); phase != c.phase {
+				t.Errorf("expected %s at %s, got %s", c.phase, c.at.Format(time.Kitchen), phase)
+			}
+		}
+		if next := calendar.NextClose(at(17, 0)); !next.Equal(at(17, 0).AddDate(0, 0, 2)) {
+			t.Errorf("expected the next close to skip the holiday, got %s", next)
+		}
+	})
+
+	t.Run("should reject orders while the market is closed", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithSessionCalendar(newTestCalendar()))
+		engine.AdvanceSession(at(18, 0))
+
+		_, _, err := engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 10, 15000))
+		var rejection *RejectionError
+		if !errors.As(err, &rejection) || rejection.Code != types.ErrorCodeMarketClosed {
+			t.Errorf("expected MARKET_CLOSED, got %v", err)
+		}
+	})
+
+	t.Run("should open with an auction of the pre-open orders", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithSessionCalendar(newTestCalendar()))
+		engine.AdvanceSession(at(8, 0))
+
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 15000))
+		matches, _, _ := engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 10, 15100))
+		if len(matches) != 0 {
+			t.Errorf("expected no matching during pre-open, got %d matches", len(matches))
+		}
+
+		if phase := engine.AdvanceSession(at(9, 0)); phase != types.PhaseContinuous {
+			t.Fatalf("expected continuous trading, got %s", phase)
+		}
+		book := engine.getOrCreateOrderBook("AAPL")
+		if book.InAuction || book.LastTradePrice != 15000 || book.BuySide.GetBestLevel() != nil {
+			t.Errorf("expected the opening auction to fill both orders, last trade %d", book.LastTradePrice)
+		}
+	})
+
+	t.Run("should run the closing auction before DAY orders expire", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithSessionCalendar(newTestCalendar()))
+		engine.AdvanceSession(at(16, 50))
+
+		sell := newTIFOrder("sell1", types.Sell, 5, 15000, types.Day)
+		sell.ExpireAt = at(17, 0)
+		buy := newTIFOrder("buy1", types.Buy, 10, 15000, types.Day)
+		buy.ExpireAt = at(17, 0)
+		engine.SubmitOrder(sell)
+		engine.SubmitOrder(buy)
+
+		engine.AdvanceSession(at(17, 0))
+		if expired := engine.ExpireOrders(at(17, 0)); expired != 1 {
+			t.Errorf("expected the unfilled rest of the buy to expire, got %d expiries", expired)
+		}
+		book := engine.getOrCreateOrderBook("AAPL")
+		if book.LastTradePrice != 15000 || book.BuySide.GetBestLevel() != nil {
+			t.Errorf("expected a closing trade and an empty book, last trade %d", book.LastTradePrice)
+		}
+	})
+
+	t.Run("should expire DAY orders at the next close", func(t *testing.T) {
+		calendar := newTestCalendar()
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithSessionCalendar(calendar))
+		engine.AdvanceSession(at(12, 0))
+
+		order := newTIFOrder("buy1", types.Buy, 10, 15000, types.Day)
+		before := time.Now()
+		engine.SubmitOrder(order)
+		if !order.ExpireAt.Equal(calendar.NextClose(before)) {
+			t.Errorf("expected expiry at %s, got %s", calendar.NextClose(before), order.ExpireAt)
+		}
+	})
+}
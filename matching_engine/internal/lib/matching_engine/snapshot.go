@@ -0,0 +1,25 @@
+package matchingengine
+
+import "github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+
+// Snapshot returns the best depth price levels of each side of a stock's book, or every level
+// if depth <= 0. A known stock without orders yet gets an empty snapshot; returns false if
+// the ticker is unknown.
+func (me *MatchingEngine) Snapshot(stock string, depth int) (types.BookSnapshot, bool) {
+	value, exists := me.orderBooks.Load(stock)
+	if !exists {
+		// Don't open a book just to read it
+		if _, ok := me.registry.lookup(stock); !ok {
+			return types.BookSnapshot{}, false
+		}
+		return types.NewStockOrderBook(stock).Snapshot(depth), true
+	}
+	book, ok := value.(*types.StockOrderBook)
+	if !ok {
+		return types.BookSnapshot{}, false
+	}
+
+	book.Mu.RLock()
+	defer book.Mu.RUnlock()
+	return book.Snapshot(depth), true
+}
@@ -0,0 +1,64 @@
+package matchingengine
+
+import (
+	"testing"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/events/streaming_client/clients"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+func TestSnapshot(t *testing.T) {
+	t.Run("should aggregate levels best price first", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+		engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 10, 14900))
+		engine.SubmitOrder(newOrder("buy2", "AAPL", types.Buy, types.LimitOrder, 5, 14900))
+		engine.SubmitOrder(newOrder("buy3", "AAPL", types.Buy, types.LimitOrder, 7, 14800))
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 4, 15100))
+		engine.SubmitOrder(newIcebergOrder("sell2", types.Sell, 30, 15000, 10))
+
+		snapshot, ok := engine.Snapshot("AAPL", 0)
+		if !ok {
+			t.Fatal("expected a snapshot")
+		}
+		if len(snapshot.Bids) != 2 || snapshot.Bids[0] != (types.LevelSnapshot{PriceCents: 14900, Quantity: 15, OrderCount: 2}) {
+			t.Errorf("unexpected bids %+v", snapshot.Bids)
+		}
+		if len(snapshot.Asks) != 2 || snapshot.Asks[0] != (types.LevelSnapshot{PriceCents: 15000, Quantity: 10, OrderCount: 1}) {
+			t.Errorf("expected only the visible iceberg quantity, got asks %+v", snapshot.Asks)
+		}
+		if snapshot.BestBidCents != 14900 || snapshot.BestAskCents != 15000 || snapshot.SpreadCents != 100 {
+			t.Errorf("unexpected best prices %d/%d, spread %d", snapshot.BestBidCents, snapshot.BestAskCents, snapshot.SpreadCents)
+		}
+	})
+
+	t.Run("should limit each side to the depth", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 1, 15100))
+		engine.SubmitOrder(newOrder("sell2", "AAPL", types.Sell, types.LimitOrder, 1, 15000))
+		engine.SubmitOrder(newOrder("sell3", "AAPL", types.Sell, types.LimitOrder, 1, 15200))
+
+		snapshot, _ := engine.Snapshot("AAPL", 2)
+		if len(snapshot.Asks) != 2 || snapshot.Asks[1].PriceCents != 15100 {
+			t.Errorf("expected the two best asks, got %+v", snapshot.Asks)
+		}
+		if snapshot.BestBidCents != 0 || snapshot.SpreadCents != 0 {
+			t.Errorf("expected no bid and no spread, got %d and %d", snapshot.BestBidCents, snapshot.SpreadCents)
+		}
+	})
+
+	t.Run("should not open a book for an unknown stock", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithInstruments([]types.Instrument{
+			{Ticker: "AAPL", Spec: types.DefaultInstrumentSpec, Active: true},
+		}))
+		if _, ok := engine.Snapshot("ZZZZ", 10); ok {
+			t.Error("expected no snapshot for an unknown stock")
+		}
+		snapshot, ok := engine.Snapshot("AAPL", 10)
+		if !ok || len(snapshot.Bids) != 0 || snapshot.StockTicker != "AAPL" {
+			t.Errorf("expected an empty snapshot, got %+v", snapshot)
+		}
+		if _, exists := engine.orderBooks.Load("AAPL"); exists {
+			t.Error("expected reading the book not to create it")
+		}
+	})
+}
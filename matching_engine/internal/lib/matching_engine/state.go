@@ -0,0 +1,93 @@
+package matchingengine
+
+import (
+	"log"
+	"sort"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+// SnapshotStore keeps saved copies of the engine's books so a restart only has to replay the
+// journal from the latest one
+type SnapshotStore interface {
+	// Save durably stores a state
+	Save(state types.EngineState) error
+	// Latest returns the most recent state saved, or nil if there is none
+	Latest() (*types.EngineState, error)
+}
+
+// State copies every book at a point between commands. Commands wait while the books are copied.
+func (me *MatchingEngine) State() types.EngineState {
+	me.stateMu.Lock()
+	defer me.stateMu.Unlock()
+
+	state := types.EngineState{
+		Version: types.EngineStateVersion,
+		Phase:   me.session.current(),
+		TakenAt: me.clock(),
+	}
+	if me.journal != nil {
+		state.JournalSequence = me.journal.LastSequence()
+	}
+	me.sequenceMu.Lock()
+	state.EventSequence = me.sequence
+	me.sequenceMu.Unlock()
+	state.Instruments = me.Instruments()
+	me.orderBooks.Range(func(_, value any) bool {
+		book, ok := value.(*types.StockOrderBook)
+		if !ok {
+			return true
+		}
+		book.Mu.RLock()
+		state.Books = append(state.Books, book.State())
+		book.Mu.RUnlock()
+		return true
+	})
+	sort.Slice(state.Books, func(i, j int) bool { return state.Books[i].Stock < state.Books[j].Stock })
+	return state
+}
+
+// SaveSnapshot saves every book to the snapshot store, if there is one, and returns the last
+// journal sequence number the snapshot covers
+func (me *MatchingEngine) SaveSnapshot() (uint64, error) {
+	if me.snapshots == nil {
+		return 0, nil
+	}
+	state := me.State()
+	if err := me.snapshots.Save(state); err != nil {
+		return 0, err
+	}
+	return state.JournalSequence, nil
+}
+
+// restore loads a saved state into an engine that has no books yet
+func (me *MatchingEngine) restore(state types.EngineState) {
+	me.session.mu.Lock()
+	me.session.phase = state.Phase
+	me.session.mu.Unlock()
+	me.sequenceMu.Lock()
+	me.sequence = state.EventSequence
+	me.sequenceMu.Unlock()
+	// Instruments go first so books opened below pick up their saved specs
+	for _, instrument := range state.Instruments {
+		me.registry.put(instrument)
+	}
+
+	for _, saved := range state.Books {
+		book := me.getOrCreateOrderBook(saved.Stock)
+		book.Mu.Lock()
+		book.Restore(saved)
+		book.Mu.Unlock()
+		for _, levels := range [][]types.LevelState{saved.Bids, saved.Asks} {
+			for _, level := range levels {
+				for i := range level.Orders {
+					me.orders.add(&level.Orders[i].Order)
+				}
+			}
+		}
+		for i := range saved.Stops {
+			me.orders.add(&saved.Stops[i].Order)
+		}
+	}
+	log.Printf("restored %d books from snapshot at journal sequence %d", len(state.Books), state.JournalSequence)
+}
@@ -0,0 +1,98 @@
+package matchingengine
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/journal"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/events/streaming_client/clients"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/snapshots"
+)
+
+func TestSnapshots(t *testing.T) {
+	t.Run("should restore the latest snapshot and replay only later commands", func(t *testing.T) {
+		journalDir, snapshotDir := t.TempDir(), t.TempDir()
+		j, _ := journal.Open(journalDir, journal.Options{})
+		store, _ := snapshots.Open(snapshotDir, snapshots.Options{})
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithJournal(j), WithSnapshots(store))
+		engine.SubmitOrder(newIcebergOrder("sell1", types.Sell, 30, 15000, 10))
+		engine.SubmitOrder(newOrder("sell2", "AAPL", types.Sell, types.LimitOrder, 10, 15000))
+		engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 4, 15000))
+		gtd := newTIFOrder("buy2", types.Buy, 5, 14800, types.GoodTillDate)
+		gtd.ExpireAt = time.Now().Add(time.Hour)
+		engine.SubmitOrder(gtd)
+		engine.SubmitOrder(newStopOrder("stop1", "AAPL", types.Sell, types.StopMarketOrder, 3, 0, 14000))
+		if sequence, err := engine.SaveSnapshot(); err != nil || sequence != 5 {
+			t.Fatalf("expected a snapshot at sequence 5, got %d (%v)", sequence, err)
+		}
+		engine.SubmitOrder(newOrder("sell3", "AAPL", types.Sell, types.LimitOrder, 2, 15100))
+		engine.CancelOrder("stop1", 0)
+		j.Close()
+
+		reopened, _ := journal.Open(journalDir, journal.Options{})
+		defer reopened.Close()
+		recovered := NewMatchingEngine(&clients.TestStreamingClient{}, WithJournal(reopened), WithSnapshots(store))
+		replayed, err := recovered.Recover()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if replayed != 2 {
+			t.Errorf("expected the 2 commands after the snapshot replayed, got %d", replayed)
+		}
+		want, _ := json.Marshal(engine.State().Books)
+		got, _ := json.Marshal(recovered.State().Books)
+		if string(want) != string(got) {
+			t.Errorf("expected books %s, got %s", want, got)
+		}
+
+		// The iceberg's partly used slice and its place in the queue survive
+		matches, _, _ := recovered.SubmitOrder(newOrder("buy3", "AAPL", types.Buy, types.LimitOrder, 8, 15000))
+		if len(matches) != 2 || matches[0].SellerOrderId != "sell1" || matches[0].Quantity != 6 || matches[1].SellerOrderId != "sell2" {
+			t.Errorf("unexpected matches after restore %+v", matches)
+		}
+		// The expiry queue is rebuilt from the restored orders
+		if expired := recovered.ExpireOrders(time.Now().Add(2 * time.Hour)); expired != 1 {
+			t.Errorf("expected the GTD order to expire, got %d", expired)
+		}
+	})
+
+	t.Run("should keep instruments and matching policies in the snapshot", func(t *testing.T) {
+		journalDir, snapshotDir := t.TempDir(), t.TempDir()
+		j, _ := journal.Open(journalDir, journal.Options{})
+		store, _ := snapshots.Open(snapshotDir, snapshots.Options{})
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithJournal(j), WithSnapshots(store), WithMatchingPolicy("MSFT", types.ProRataTopOrder{}))
+		engine.AddInstrument(types.Instrument{Ticker: "MSFT", Spec: types.NewInstrumentSpec(5, 10, 0), Active: true})
+		if _, err := engine.SaveSnapshot(); err != nil {
+			t.Fatal(err)
+		}
+		j.Close()
+
+		reopened, _ := journal.Open(journalDir, journal.Options{})
+		defer reopened.Close()
+		recovered := NewMatchingEngine(&clients.TestStreamingClient{}, WithJournal(reopened), WithSnapshots(store))
+		if replayed, err := recovered.Recover(); err != nil || replayed != 0 {
+			t.Fatalf("expected nothing to replay, got %d (%v)", replayed, err)
+		}
+		book := recovered.getOrCreateOrderBook("MSFT")
+		if book.Spec.LotSize != 10 {
+			t.Errorf("expected the saved spec on the book, got %+v", book.Spec)
+		}
+		if _, ok := book.Policy.(types.ProRataTopOrder); !ok {
+			t.Errorf("expected the saved policy, got %T", book.Policy)
+		}
+	})
+
+	t.Run("should refuse a snapshot ahead of the journal", func(t *testing.T) {
+		store, _ := snapshots.Open(t.TempDir(), snapshots.Options{})
+		store.Save(types.EngineState{Version: types.EngineStateVersion, JournalSequence: 7, TakenAt: time.Now()})
+		j, _ := journal.Open(t.TempDir(), journal.Options{})
+		defer j.Close()
+
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithJournal(j), WithSnapshots(store))
+		if _, err := engine.Recover(); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
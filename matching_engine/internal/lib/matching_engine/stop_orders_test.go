@@ -0,0 +1,342 @@
+package matchingengine
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/events/streaming_client/clients"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+// Helper to create a stop order with a trigger price
+func newStopOrder(id, stock string, side types.OrderSide, orderType types.OrderType, qty, limitPrice, triggerPrice int64) *types.Order {
+	order := newOrder(id, stock, side, orderType, qty, limitPrice)
+	order.TriggerPrice = triggerPrice
+	order.AvailableBalance = 1_000_000_000
+	return order
+}
+
+func TestStopOrders(t *testing.T) {
+	t.Run("should hold stop order off-book until triggered", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 15000))
+
+		// Sell stop below the market must not be visible to incoming buys
+		stop := newStopOrder("stop1", "AAPL", types.Sell, types.StopMarketOrder, 10, 0, 14000)
+		matches, remaining, err := engine.SubmitOrder(stop)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(matches) != 0 || remaining != 10 {
+			t.Fatalf("expected stop to rest untouched, got %d matches and %d remaining", len(matches), remaining)
+		}
+
+		book := engine.getOrCreateOrderBook("AAPL")
+		if book.Stops.Len() != 1 {
+			t.Errorf("expected 1 held stop, got %d", book.Stops.Len())
+		}
+		if !book.BuySide.IsEmpty() {
+			t.Error("expected stop order not to rest on the buy side")
+		}
+	})
+
+	t.Run("should release sell stop when trade crosses trigger", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+
+		// Bids the stop will hit once released
+		engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 10, 14000))
+		engine.SubmitOrder(newOrder("buy2", "AAPL", types.Buy, types.LimitOrder, 20, 13900))
+
+		engine.SubmitOrder(newStopOrder("stop1", "AAPL", types.Sell, types.StopMarketOrder, 15, 0, 14000))
+
+		// Trade at 14000 crosses the trigger
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 5, 14000))
+
+		book := engine.getOrCreateOrderBook("AAPL")
+		if book.Stops.Len() != 0 {
+			t.Fatalf("expected stop to be released, %d still held", book.Stops.Len())
+		}
+		// sell1 takes 5 @ 14000, stop takes remaining 5 @ 14000 and 10 @ 13900
+		if book.LastTradePrice != 13900 {
+			t.Errorf("expected last trade at 13900, got %d", book.LastTradePrice)
+		}
+		level := book.BuySide.GetBestLevel()
+		if level == nil || level.Front().OrderId != "buy2" || level.Front().Quantity != 10 {
+			t.Error("expected buy2 to rest with 10 remaining after the stop was filled")
+		}
+	})
+
+	t.Run("should rest stop-limit at its limit price once triggered", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+
+		engine.SubmitOrder(newStopOrder("stop1", "AAPL", types.Buy, types.StopLimitOrder, 10, 15500, 15000))
+
+		// Trade at 15000 triggers the buy stop, but nothing is offered at or below 15500
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 5, 15000))
+		engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 5, 15000))
+
+		book := engine.getOrCreateOrderBook("AAPL")
+		price, ok := book.BuySide.GetBestPrice()
+		if !ok || price != 15500 {
+			t.Fatalf("expected triggered stop-limit to rest at 15500, got %d", price)
+		}
+		if order := book.BuySide.GetBestLevel().Front(); order.OrderType != types.LimitOrder {
+			t.Errorf("expected triggered order to become a limit order, got %d", order.OrderType)
+		}
+	})
+
+	t.Run("should trigger immediately if already crossed", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithStopReserveSlippage(500))
+
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 20, 15000))
+		engine.SubmitOrder(newOrder("buy1", "AAPL", types.Buy, types.LimitOrder, 10, 15000))
+
+		// Last trade is 15000, already above the buy stop's trigger
+		matches, remaining, _ := engine.SubmitOrder(newStopOrder("stop1", "AAPL", types.Buy, types.StopMarketOrder, 10, 0, 14900))
+		if len(matches) != 1 || remaining != 0 {
+			t.Errorf("expected stop to fill immediately, got %d matches and %d remaining", len(matches), remaining)
+		}
+	})
+
+	t.Run("should cancel held stop order", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+
+		engine.SubmitOrder(newStopOrder("stop1", "AAPL", types.Sell, types.StopLimitOrder, 10, 13900, 14000))
+
+		cancelled, err := engine.CancelOrder("stop1", 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !cancelled {
+			t.Error("expected held stop to be cancelled")
+		}
+	})
+
+	t.Run("should reject stop without trigger price", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+
+		_, _, err := engine.SubmitOrder(newStopOrder("stop1", "AAPL", types.Sell, types.StopMarketOrder, 10, 0, 0))
+		if err == nil {
+			t.Error("expected error for missing trigger price")
+		}
+	})
+
+	t.Run("should reject stop-market buys without a balance to reserve", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+
+		stop := newStopOrder("stop1", "AAPL", types.Buy, types.StopMarketOrder, 10, 0, 15100)
+		stop.AvailableBalance = 0
+		_, _, err := engine.SubmitOrder(stop)
+		var rejection *RejectionError
+		if !errors.As(err, &rejection) || rejection.Code != types.ErrorCodeInsufficientFunds {
+			t.Errorf("expected insufficient funds rejection, got %v", err)
+		}
+	})
+
+	t.Run("should announce the cash reserved for stop-market buys", func(t *testing.T) {
+		streamer := &envelopeStreamer{}
+		engine := NewMatchingEngine(streamer, WithStopReserveSlippage(500))
+
+		engine.SubmitOrder(newStopOrder("stop1", "AAPL", types.Buy, types.StopMarketOrder, 10, 0, 15100))
+		capped := newStopOrder("stop2", "AAPL", types.Buy, types.StopMarketOrder, 10, 0, 15100)
+		capped.AvailableBalance = 155000
+		engine.SubmitOrder(capped)
+		engine.SubmitOrder(newStopOrder("stop3", "AAPL", types.Buy, types.StopLimitOrder, 10, 15200, 15100))
+
+		var placed [3]types.OrderPlacedEvent
+		for i := range placed {
+			if err := json.Unmarshal(streamer.events[i].Data, &placed[i]); err != nil {
+				t.Fatalf("failed to decode event: %v", err)
+			}
+		}
+		// 10 at 15100 is 151000, plus 5%
+		if placed[0].ReservedCash != 158550 {
+			t.Errorf("expected 158550 cents reserved for the stop-market buy, got %d", placed[0].ReservedCash)
+		}
+		if placed[1].ReservedCash != 155000 {
+			t.Errorf("expected the reserve held to the 155000 cent balance, got %d", placed[1].ReservedCash)
+		}
+		if placed[2].ReservedCash != 0 {
+			t.Errorf("expected nothing reserved for the stop-limit buy, got %d", placed[2].ReservedCash)
+		}
+	})
+
+	t.Run("should spend no more than the reserve once triggered", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+		engine.SubmitOrder(newStopOrder("stop1", "AAPL", types.Buy, types.StopMarketOrder, 10, 0, 15000))
+		engine.SubmitOrder(newOrder("ask", "AAPL", types.Sell, types.LimitOrder, 20, 16000))
+
+		// The trigger trade leaves only asks well above the trigger
+		tradeAt(engine, "t1", 1, 15000)
+		book := engine.getOrCreateOrderBook("AAPL")
+		if book.Stops.Len() != 0 {
+			t.Fatal("expected the stop to trigger")
+		}
+		// 150000 reserved buys 9 at 16000
+		if volume := book.SellSide.GetBestLevel().Volume(); volume != 11 {
+			t.Errorf("expected 9 bought and 11 left on the ask, got %d left", volume)
+		}
+	})
+}
+
+// Helper to create a trailing stop order
+func newTrailingStopOrder(id string, side types.OrderSide, qty, offset, bps int64) *types.Order {
+	order := newStopOrder(id, "AAPL", side, types.TrailingStopOrder, qty, 0, 0)
+	order.TrailingOffset = offset
+	order.TrailingBps = bps
+	return order
+}
+
+func TestTrailingStopOrders(t *testing.T) {
+	t.Run("should start trailing from the last trade price", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+		tradeAt(engine, "t1", 1, 10000)
+
+		sell := newTrailingStopOrder("stop1", types.Sell, 10, 500, 0)
+		engine.SubmitOrder(sell)
+		if sell.TriggerPrice != 9500 {
+			t.Errorf("expected initial trigger 9500, got %d", sell.TriggerPrice)
+		}
+
+		buy := newTrailingStopOrder("stop2", types.Buy, 10, 0, 100)
+		engine.SubmitOrder(buy)
+		if buy.TriggerPrice != 10100 {
+			t.Errorf("expected initial trigger 10100 for 100bps, got %d", buy.TriggerPrice)
+		}
+	})
+
+	t.Run("should trail at least one tick behind the price", func(t *testing.T) {
+		instruments := []types.Instrument{{Ticker: "AAPL", Spec: types.NewInstrumentSpec(5, 0, 0), Active: true}}
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithInstruments(instruments))
+		tradeAt(engine, "t1", 1, 50)
+
+		// 10bps of 50 cents rounds down to nothing
+		sell := newTrailingStopOrder("stop1", types.Sell, 10, 0, 10)
+		if _, _, err := engine.SubmitOrder(sell); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if sell.TriggerPrice != 45 {
+			t.Errorf("expected trigger one tick below at 45, got %d", sell.TriggerPrice)
+		}
+
+		tradeAt(engine, "t2", 1, 60)
+		if sell.TriggerPrice != 55 {
+			t.Errorf("expected trigger to trail one tick below at 55, got %d", sell.TriggerPrice)
+		}
+		if engine.getOrCreateOrderBook("AAPL").Stops.Len() != 1 {
+			t.Error("expected trailing stop to still be held")
+		}
+	})
+
+	t.Run("should ratchet sell trigger up but never down", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+		tradeAt(engine, "t1", 1, 10000)
+
+		sell := newTrailingStopOrder("stop1", types.Sell, 10, 500, 0)
+		engine.SubmitOrder(sell)
+
+		tradeAt(engine, "t2", 1, 11000)
+		if sell.TriggerPrice != 10500 {
+			t.Errorf("expected trigger to rise to 10500, got %d", sell.TriggerPrice)
+		}
+
+		tradeAt(engine, "t3", 1, 10800)
+		if sell.TriggerPrice != 10500 {
+			t.Errorf("expected trigger to stay at 10500, got %d", sell.TriggerPrice)
+		}
+		if engine.getOrCreateOrderBook("AAPL").Stops.Len() != 1 {
+			t.Error("expected trailing stop to still be held")
+		}
+	})
+
+	t.Run("should announce each move of the trigger", func(t *testing.T) {
+		streamer := &envelopeStreamer{}
+		engine := NewMatchingEngine(streamer)
+		tradeAt(engine, "t1", 1, 10000)
+		engine.SubmitOrder(newTrailingStopOrder("stop1", types.Sell, 10, 500, 0))
+
+		tradeAt(engine, "t2", 1, 11000)
+		tradeAt(engine, "t3", 1, 10800)
+		var moves []types.TrailingStopMovedEvent
+		for _, evt := range streamer.events {
+			if evt.Type != types.TrailingStopMoved {
+				continue
+			}
+			var moved types.TrailingStopMovedEvent
+			if err := json.Unmarshal(evt.Data, &moved); err != nil {
+				t.Fatal(err)
+			}
+			moves = append(moves, moved)
+		}
+		if len(moves) != 1 || moves[0].OrderID != "stop1" || moves[0].TriggerPriceCents != 10500 {
+			t.Errorf("expected one move of stop1 to 10500, got %+v", moves)
+		}
+	})
+
+	t.Run("should convert to a market order when hit", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+		tradeAt(engine, "t1", 1, 10000)
+
+		sell := newTrailingStopOrder("stop1", types.Sell, 10, 500, 0)
+		engine.SubmitOrder(sell)
+		tradeAt(engine, "t2", 1, 11000)
+
+		engine.SubmitOrder(newOrder("bid", "AAPL", types.Buy, types.LimitOrder, 10, 10000))
+		tradeAt(engine, "t3", 1, 10500)
+
+		book := engine.getOrCreateOrderBook("AAPL")
+		if book.Stops.Len() != 0 {
+			t.Error("expected trailing stop to be triggered")
+		}
+		if sell.OrderType != types.MarketOrder {
+			t.Errorf("expected trailing stop converted to market order, got %v", sell.OrderType)
+		}
+		if !book.BuySide.IsEmpty() {
+			t.Error("expected triggered market sell to fill the resting bid")
+		}
+	})
+
+	t.Run("should follow intermediate prices within a sweep", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+		tradeAt(engine, "t1", 1, 10000)
+
+		buy := newTrailingStopOrder("stop1", types.Buy, 10, 2000, 0)
+		engine.SubmitOrder(buy)
+
+		engine.SubmitOrder(newOrder("ask1", "AAPL", types.Sell, types.LimitOrder, 1, 9500))
+		engine.SubmitOrder(newOrder("ask2", "AAPL", types.Sell, types.LimitOrder, 1, 11000))
+		engine.SubmitOrder(newOrder("sweep", "AAPL", types.Buy, types.LimitOrder, 2, 11000))
+
+		if buy.TriggerPrice != 11500 {
+			t.Errorf("expected trigger to follow the 9500 low to 11500, got %d", buy.TriggerPrice)
+		}
+	})
+
+	t.Run("should reject without a price to trail", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+
+		_, _, err := engine.SubmitOrder(newTrailingStopOrder("stop1", types.Sell, 10, 500, 0))
+		var rejection *RejectionError
+		if !errors.As(err, &rejection) || rejection.Code != types.ErrorCodeInvalidPrice {
+			t.Errorf("expected invalid price rejection, got %v", err)
+		}
+	})
+
+	t.Run("should reject invalid offsets", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+		tradeAt(engine, "t1", 1, 10000)
+
+		_, _, err := engine.SubmitOrder(newTrailingStopOrder("stop1", types.Sell, 10, 500, 100))
+		var rejection *RejectionError
+		if !errors.As(err, &rejection) || rejection.Code != types.ErrorCodeInvalidPrice {
+			t.Errorf("expected rejection for two offsets, got %v", err)
+		}
+
+		_, _, err = engine.SubmitOrder(newTrailingStopOrder("stop2", types.Sell, 10, 0, 0))
+		if !errors.As(err, &rejection) || rejection.Code != types.ErrorCodeInvalidPrice {
+			t.Errorf("expected rejection for no offset, got %v", err)
+		}
+	})
+}
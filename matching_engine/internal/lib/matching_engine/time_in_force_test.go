@@ -0,0 +1,126 @@
+package matchingengine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/events/streaming_client/clients"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+// Helper to create a limit order with a time in force
+func newTIFOrder(id string, side types.OrderSide, qty, price int64, tif types.TimeInForce) *types.Order {
+	order := newOrder(id, "AAPL", side, types.LimitOrder, qty, price)
+	order.TimeInForce = tif
+	return order
+}
+
+func TestTimeInForce(t *testing.T) {
+	t.Run("should cancel unfilled remainder of IOC order", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 30, 15000))
+
+		matches, remaining, _ := engine.SubmitOrder(newTIFOrder("buy1", types.Buy, 50, 15000, types.ImmediateOrCancel))
+		if len(matches) != 1 || remaining != 20 {
+			t.Fatalf("expected 1 match and 20 remaining, got %d and %d", len(matches), remaining)
+		}
+		if !engine.getOrCreateOrderBook("AAPL").BuySide.IsEmpty() {
+			t.Error("expected IOC remainder not to rest on the book")
+		}
+	})
+
+	t.Run("should kill FOK order without trading when depth is insufficient", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 30, 15000))
+		engine.SubmitOrder(newOrder("sell2", "AAPL", types.Sell, types.LimitOrder, 30, 15200))
+
+		// Only 30 shares are available at or below 15100
+		matches, remaining, _ := engine.SubmitOrder(newTIFOrder("buy1", types.Buy, 50, 15100, types.FillOrKill))
+		if len(matches) != 0 || remaining != 50 {
+			t.Fatalf("expected no trades and 50 remaining, got %d and %d", len(matches), remaining)
+		}
+
+		level := engine.getOrCreateOrderBook("AAPL").SellSide.GetBestLevel()
+		if level.Front().OrderId != "sell1" || level.Volume() != 30 {
+			t.Error("expected resting sells to be untouched by killed FOK order")
+		}
+	})
+
+	t.Run("should fill FOK order across levels when depth is sufficient", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 30, 15000))
+		engine.SubmitOrder(newOrder("sell2", "AAPL", types.Sell, types.LimitOrder, 30, 15200))
+
+		matches, remaining, _ := engine.SubmitOrder(newTIFOrder("buy1", types.Buy, 50, 15200, types.FillOrKill))
+		if len(matches) != 2 || remaining != 0 {
+			t.Errorf("expected 2 matches and 0 remaining, got %d and %d", len(matches), remaining)
+		}
+	})
+
+	t.Run("should kill FOK market buy the buyer cannot afford", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 100, 15000))
+
+		buyOrder := newMarketBuyOrder("buy1", "AAPL", 10, 100_000) // Affords 6 shares
+		buyOrder.TimeInForce = types.FillOrKill
+		matches, remaining, _ := engine.SubmitOrder(buyOrder)
+		if len(matches) != 0 || remaining != 10 {
+			t.Errorf("expected no trades and 10 remaining, got %d and %d", len(matches), remaining)
+		}
+	})
+
+	t.Run("should reject GTD order with expiry in the past", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+
+		order := newTIFOrder("buy1", types.Buy, 10, 15000, types.GoodTillDate)
+		order.ExpireAt = time.Now().Add(-time.Minute)
+		if _, _, err := engine.SubmitOrder(order); err == nil {
+			t.Error("expected error for GTD order expiring in the past")
+		}
+	})
+
+	t.Run("should expire GTD and DAY orders", func(t *testing.T) {
+		now := time.Now()
+		engine := NewMatchingEngine(&clients.TestStreamingClient{}, WithDayClose(func(time.Time) time.Time {
+			return now.Add(2 * time.Hour)
+		}))
+
+		gtd := newTIFOrder("buy1", types.Buy, 10, 15000, types.GoodTillDate)
+		gtd.ExpireAt = now.Add(time.Hour)
+		engine.SubmitOrder(gtd)
+		engine.SubmitOrder(newTIFOrder("buy2", types.Buy, 10, 14900, types.Day))
+		engine.SubmitOrder(newOrder("buy3", "AAPL", types.Buy, types.LimitOrder, 10, 14800))
+
+		if expired := engine.ExpireOrders(now); expired != 0 {
+			t.Errorf("expected nothing to expire yet, got %d", expired)
+		}
+		if expired := engine.ExpireOrders(now.Add(time.Hour)); expired != 1 {
+			t.Errorf("expected GTD order to expire, got %d", expired)
+		}
+		if expired := engine.ExpireOrders(now.Add(3 * time.Hour)); expired != 1 {
+			t.Errorf("expected DAY order to expire, got %d", expired)
+		}
+
+		price, _ := engine.getOrCreateOrderBook("AAPL").BuySide.GetBestPrice()
+		if price != 14800 {
+			t.Errorf("expected only the GTC order to remain at 14800, got best bid %d", price)
+		}
+	})
+
+	t.Run("should skip expiry of orders that already filled", func(t *testing.T) {
+		engine := NewMatchingEngine(&clients.TestStreamingClient{})
+
+		gtd := newTIFOrder("buy1", types.Buy, 10, 15000, types.GoodTillDate)
+		gtd.ExpireAt = time.Now().Add(time.Hour)
+		engine.SubmitOrder(gtd)
+		engine.SubmitOrder(newOrder("sell1", "AAPL", types.Sell, types.LimitOrder, 10, 15000))
+
+		if expired := engine.ExpireOrders(time.Now().Add(2 * time.Hour)); expired != 0 {
+			t.Errorf("expected filled order not to expire, got %d", expired)
+		}
+	})
+}
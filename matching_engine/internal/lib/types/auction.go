@@ -0,0 +1,59 @@
+package types
+
+// EquilibriumPrice returns the auction price that maximises executable volume, and that volume.
+// Ties are broken by the smallest imbalance between the two sides, then by the price closest
+// to the last trade price, then by the lowest price. Returns 0, 0 if the book doesn't cross.
+func (b *StockOrderBook) EquilibriumPrice() (int64, int64) {
+	bids := b.BuySide.SortedLevels()
+	asks := b.SellSide.SortedLevels()
+	if len(bids) == 0 || len(asks) == 0 || bids[0].Price() < asks[0].Price() {
+		return 0, 0
+	}
+
+	candidates := make([]int64, 0, len(bids)+len(asks))
+	for _, level := range bids {
+		candidates = append(candidates, level.Price())
+	}
+	for _, level := range asks {
+		candidates = append(candidates, level.Price())
+	}
+
+	var bestPrice, bestVolume, bestImbalance int64
+	for _, price := range candidates {
+		var demand, supply int64
+		for _, bid := range bids {
+			if bid.Price() < price {
+				break
+			}
+			demand += bid.TotalVolume()
+		}
+		for _, ask := range asks {
+			if ask.Price() > price {
+				break
+			}
+			supply += ask.TotalVolume()
+		}
+
+		volume := min(demand, supply)
+		imbalance := max(demand-supply, supply-demand)
+		if volume == 0 {
+			continue
+		}
+		if bestVolume == 0 || volume > bestVolume ||
+			(volume == bestVolume && imbalance < bestImbalance) ||
+			(volume == bestVolume && imbalance == bestImbalance && b.closerToLastTrade(price, bestPrice)) {
+			bestPrice, bestVolume, bestImbalance = price, volume, imbalance
+		}
+	}
+	return bestPrice, bestVolume
+}
+
+// closerToLastTrade reports whether price is a better auction reference than current
+func (b *StockOrderBook) closerToLastTrade(price, current int64) bool {
+	distance := max(price-b.LastTradePrice, b.LastTradePrice-price)
+	currentDistance := max(current-b.LastTradePrice, b.LastTradePrice-current)
+	if b.LastTradePrice > 0 && distance != currentDistance {
+		return distance < currentDistance
+	}
+	return price < current
+}
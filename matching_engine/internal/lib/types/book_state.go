@@ -0,0 +1,115 @@
+package types
+
+import "time"
+
+// EngineStateVersion is the layout version of EngineState. Bump it when a change means older
+// saved states can no longer be restored.
+const EngineStateVersion = 1
+
+// EngineState is every book of the engine at one point of the command journal
+type EngineState struct {
+	Version         int          `json:"version"`
+	JournalSequence uint64       `json:"journal_sequence"` // Last journaled command reflected in the books
+	Phase           SessionPhase `json:"phase"`
+	EventSequence   int64        `json:"event_sequence"`        // Engine-wide sequence number of the last event published
+	Instruments     []Instrument `json:"instruments,omitempty"` // Registered instruments, ordered by ticker
+	Books           []BookState  `json:"books"`                 // Ordered by stock
+	TakenAt         time.Time    `json:"taken_at"`
+}
+
+// BookState is everything needed to rebuild a stock's book. The order lookup maps and the
+// expiry queue are derived from the orders; tick sizes come from the saved instruments and
+// bands from configuration.
+type BookState struct {
+	Stock          string       `json:"stock"`
+	Bids           []LevelState `json:"bids"`            // Best price first
+	Asks           []LevelState `json:"asks"`            // Best price first
+	Stops          []SavedOrder `json:"stops,omitempty"` // Held stop orders in trigger order
+	LastTradePrice int64        `json:"last_trade_price,omitempty"`
+	ReferencePrice int64        `json:"reference_price,omitempty"`
+	InAuction      bool         `json:"in_auction,omitempty"`
+	HaltedUntil    time.Time    `json:"halted_until"`
+	Sequence       int64        `json:"sequence,omitempty"` // Stock sequence number of the last event published
+	Policy         string       `json:"policy,omitempty"`   // Matching policy by configuration name
+}
+
+// LevelState is the orders resting at one price in time priority
+type LevelState struct {
+	PriceCents int64        `json:"price_cents"`
+	Orders     []SavedOrder `json:"orders"`
+}
+
+// SavedOrder is a copy of an order with the iceberg slice it is showing
+type SavedOrder struct {
+	Order
+	Displayed int64 `json:"displayed,omitempty"`
+}
+
+// State copies the book's orders and trading state.
+// Must be called with the book lock held; a read lock is enough.
+func (b *StockOrderBook) State() BookState {
+	state := BookState{
+		Stock:          b.stock,
+		Bids:           sideState(b.BuySide),
+		Asks:           sideState(b.SellSide),
+		LastTradePrice: b.LastTradePrice,
+		ReferencePrice: b.ReferencePrice,
+		InAuction:      b.InAuction,
+		HaltedUntil:    b.HaltedUntil,
+		Sequence:       b.Sequence,
+	}
+	state.Policy, _ = MatchingPolicyName(b.Policy)
+	for _, order := range b.Stops.ordered() {
+		state.Stops = append(state.Stops, saveOrder(order))
+	}
+	return state
+}
+
+// Restore loads a saved state into an empty book, keeping each level's queue order.
+// Must be called with the book lock held.
+func (b *StockOrderBook) Restore(state BookState) {
+	restoreSide(b.BuySide, b.Expiries, state.Bids)
+	restoreSide(b.SellSide, b.Expiries, state.Asks)
+	for _, saved := range state.Stops {
+		order := saved.Order
+		b.Stops.AddOrder(&order)
+		b.Expiries.Schedule(&order)
+	}
+	b.LastTradePrice = state.LastTradePrice
+	b.ReferencePrice = state.ReferencePrice
+	b.InAuction = state.InAuction
+	b.HaltedUntil = state.HaltedUntil
+	b.Sequence = state.Sequence
+	if policy, ok := ParseMatchingPolicy(state.Policy); ok {
+		b.Policy = policy
+	}
+}
+
+func sideState(side *OrderBookSide) []LevelState {
+	levels := side.SortedLevels()
+	states := make([]LevelState, 0, len(levels))
+	for _, level := range levels {
+		orders := level.Orders()
+		state := LevelState{PriceCents: level.Price(), Orders: make([]SavedOrder, 0, len(orders))}
+		for _, order := range orders {
+			state.Orders = append(state.Orders, saveOrder(order))
+		}
+		states = append(states, state)
+	}
+	return states
+}
+
+func restoreSide(side *OrderBookSide, expiries *ExpiryQueue, levels []LevelState) {
+	for _, level := range levels {
+		for _, saved := range level.Orders {
+			order := saved.Order
+			order.displayed = saved.Displayed
+			side.restoreOrder(&order)
+			expiries.Schedule(&order)
+		}
+	}
+}
+
+func saveOrder(order *Order) SavedOrder {
+	return SavedOrder{Order: *order, Displayed: order.displayed}
+}
@@ -0,0 +1,37 @@
+package types
+
+import "time"
+
+// CommandType - Kind of change to the engine's books recorded in the command journal
+type CommandType int
+
+const (
+	CommandSubmit         CommandType = iota + 1 // An accepted order, as it was validated
+	CommandCancel                                // Cancellation of a resting or held order
+	CommandAmend                                 // New quantity and limit price of a resting order
+	CommandExpire                                // Expiry sweep of one book
+	CommandStartAuction                          // Book enters auction mode
+	CommandUncross                               // Book's auction is executed
+	CommandResume                                // Book's trading halt ends
+	CommandSessionPhase                          // Market moves to another session phase
+	CommandReferencePrice                        // Book's static band reference is set
+	CommandAddInstrument                         // Stock is registered, or its spec and status replaced
+	CommandDeactivate                            // Stock stops accepting new orders
+)
+
+// Command is one journaled change to the engine's books. Time is when the engine accepted it,
+// so replaying reproduces time-dependent effects such as expiries and halts.
+type Command struct {
+	Sequence   uint64       `json:"sequence"` // Assigned by the journal, gap-free from 1
+	Type       CommandType  `json:"type"`
+	Time       time.Time    `json:"time"`
+	Stock      string       `json:"stock,omitempty"`
+	Order      *Order       `json:"order,omitempty"`       // Submit
+	OrderId    string       `json:"order_id,omitempty"`    // Cancel, Amend
+	Side       OrderSide    `json:"side,omitempty"`        // Cancel, Amend
+	Quantity   int64        `json:"quantity,omitempty"`    // Amend
+	PriceCents int64        `json:"price_cents,omitempty"` // Amend limit price, reference price
+	Closing    bool         `json:"closing,omitempty"`     // Uncross
+	Phase      SessionPhase `json:"phase,omitempty"`       // SessionPhase
+	Instrument *Instrument  `json:"instrument,omitempty"`  // AddInstrument
+}
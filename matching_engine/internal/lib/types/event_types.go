@@ -14,32 +14,103 @@ const (
 	OrderPartiallyFilled
 	OrderRejected
 	TradeExecuted
+	OrderTriggered
+	OrderAmended
+	SelfTradePrevented
+	AuctionUncrossed
+	TradingHalted
+	TradingResumed
+	SessionPhaseChanged
+	TrailingStopMoved
 )
 
+// ErrorCode - Machine-readable rejection reason, values match common.types.ErrorCode
+type ErrorCode int
+
+const (
+	ErrorCodeUnspecified ErrorCode = iota
+	ErrorCodeInsufficientFunds
+	ErrorCodeInsufficientShares
+	ErrorCodeInvalidQuantity
+	ErrorCodeInvalidPrice
+	ErrorCodeStockNotFound
+	ErrorCodeStockNotTrading
+	ErrorCodeOrderNotFound
+	ErrorCodeUnauthorized
+	ErrorCodeRateLimitExceeded
+	ErrorCodeMarketClosed
+	ErrorCodeInternalError
+	ErrorCodePostOnlyWouldCross
+	ErrorCodeInvalidOrderType
+)
+
+// CancelReason - Why an order left the engine without being fully filled
+type CancelReason string
+
+const (
+	CancelReasonUserRequested     CancelReason = "USER_REQUESTED"
+	CancelReasonImmediateOrCancel CancelReason = "IMMEDIATE_OR_CANCEL" // Unfilled remainder of a MARKET or IOC order
+	CancelReasonFillOrKill        CancelReason = "FILL_OR_KILL"        // Not enough depth to fill a FOK order completely
+	CancelReasonExpired           CancelReason = "EXPIRED"             // DAY/GTD order reached its expiry
+	CancelReasonSelfTrade         CancelReason = "SELF_TRADE_PREVENTION"
+)
+
+// HaltReason - Why trading in a stock was halted
+type HaltReason string
+
+const (
+	HaltReasonStaticBand  HaltReason = "STATIC_BAND"  // Trade would move too far from the reference price
+	HaltReasonDynamicBand HaltReason = "DYNAMIC_BAND" // Trade would move too far from the last trade price
+)
+
+// EventSequence places an event in the engine's event order. Both counters start at 1 and
+// have no gaps, so consumers can detect missing and duplicated events; a recovered engine
+// carries on from the numbers its snapshot and journal reach. The epoch is new for every run
+// of the engine, so consumers can tell a restart from a repeated event.
+type EventSequence struct {
+	Epoch         string `json:"epoch,omitempty"`          // Run of the engine that published the event
+	Sequence      int64  `json:"sequence"`                 // Engine-wide
+	StockTicker   string `json:"stock_ticker,omitempty"`   // Empty for events not tied to a book
+	StockSequence int64  `json:"stock_sequence,omitempty"` // Per stock, 0 for events not tied to a book
+}
+
 type Event struct {
-	EventID   string          `json:"event_id"`
-	Timestamp time.Time       `json:"timestamp"`
-	Type      EventType       `json:"type"`
-	Data      json.RawMessage `json:"data"`
+	EventID   string    `json:"event_id"`
+	Timestamp time.Time `json:"timestamp"`
+	Type      EventType `json:"type"`
+	EventSequence
+	Data json.RawMessage `json:"data"`
 }
 
 type OrderPlacedEvent struct {
-	OrderID         string    `json:"order_id"`
-	TraderID        int64     `json:"trader_id"`
-	StockTicker     string    `json:"stock_ticker"`
-	OrderType       OrderType `json:"order_type"`
-	OrderSide       OrderSide `json:"order_side"`
-	Quantity        int64     `json:"quantity"`
-	LimitPriceCents int64     `json:"limit_price_cents"`
+	OrderID           string              `json:"order_id"`
+	TraderID          int64               `json:"trader_id"`
+	StockTicker       string              `json:"stock_ticker"`
+	OrderType         OrderType           `json:"order_type"`
+	OrderSide         OrderSide           `json:"order_side"`
+	Quantity          int64               `json:"quantity"`
+	LimitPriceCents   int64               `json:"limit_price_cents"`
+	TriggerPriceCents int64               `json:"trigger_price_cents"`
+	TimeInForce       TimeInForce         `json:"time_in_force"`
+	ExpiresAt         time.Time           `json:"expires_at"`
+	DisplayQuantity   int64               `json:"display_quantity"`
+	TrailingOffset    int64               `json:"trailing_offset_cents"`
+	TrailingBps       int64               `json:"trailing_offset_bps"`
+	ClientOrderID     string              `json:"client_order_id,omitempty"`       // The trader's own ID for the order, if given
+	ReservedCash      int64               `json:"reserved_cash_cents,omitempty"`   // Cash a stop-market or trailing-stop buy may spend once triggered
+	SelfTrade         SelfTradePrevention `json:"self_trade_prevention,omitempty"` // The order's own mode, if it overrides the engine's
+	PostOnly          bool                `json:"post_only,omitempty"`
+	PostOnlyReprice   bool                `json:"post_only_reprice,omitempty"`
 }
 
 type OrderCancelledEvent struct {
-	OrderID           string    `json:"order_id"`
-	TraderID          int64     `json:"trader_id"`
-	OrderType         OrderType `json:"order_type"`
-	OrderSide         OrderSide `json:"order_side"`
-	StockTicker       string    `json:"stock_ticker"`
-	RemainingQuantity int64     `json:"remaining_quantity"`
+	OrderID           string       `json:"order_id"`
+	TraderID          int64        `json:"trader_id"`
+	OrderType         OrderType    `json:"order_type"`
+	OrderSide         OrderSide    `json:"order_side"`
+	StockTicker       string       `json:"stock_ticker"`
+	RemainingQuantity int64        `json:"remaining_quantity"`
+	Reason            CancelReason `json:"reason"`
 }
 
 type OrderFilledEvent struct {
@@ -58,10 +129,11 @@ type OrderPartiallyFilledEvent struct {
 }
 
 type OrderRejectedEvent struct {
-	OrderID      string `json:"order_id"`
-	TraderID     int64  `json:"trader_id"`
-	Reason       string `json:"reason"`
-	ErrorMessage string `json:"error_message"`
+	OrderID      string    `json:"order_id"`
+	TraderID     int64     `json:"trader_id"`
+	Reason       string    `json:"reason"`
+	ErrorMessage string    `json:"error_message"`
+	ErrorCode    ErrorCode `json:"error_code"`
 }
 
 type TradeExecutedEvent struct {
@@ -75,3 +147,88 @@ type TradeExecutedEvent struct {
 	PriceCents      int64     `json:"price_cents"`
 	TotalValueCents int64     `json:"total_value_cents"`
 }
+
+// OrderTriggeredEvent is emitted when a held stop order is released into the book.
+// OrderType is the type the order converted into (MarketOrder or LimitOrder).
+type OrderTriggeredEvent struct {
+	OrderID           string    `json:"order_id"`
+	TraderID          int64     `json:"trader_id"`
+	StockTicker       string    `json:"stock_ticker"`
+	OrderType         OrderType `json:"order_type"`
+	OrderSide         OrderSide `json:"order_side"`
+	Quantity          int64     `json:"quantity"`
+	TriggerPriceCents int64     `json:"trigger_price_cents"`
+	TradePriceCents   int64     `json:"trade_price_cents"`
+}
+
+// TrailingStopMovedEvent is emitted when a trade moves the trigger of a held trailing stop
+type TrailingStopMovedEvent struct {
+	OrderID           string    `json:"order_id"`
+	TraderID          int64     `json:"trader_id"`
+	StockTicker       string    `json:"stock_ticker"`
+	OrderSide         OrderSide `json:"order_side"`
+	TriggerPriceCents int64     `json:"trigger_price_cents"`
+}
+
+// OrderAmendedEvent is emitted when a resting order's quantity or limit price changes.
+// Quantities are remaining quantities before and after the amend.
+type OrderAmendedEvent struct {
+	OrderID            string    `json:"order_id"`
+	TraderID           int64     `json:"trader_id"`
+	StockTicker        string    `json:"stock_ticker"`
+	OrderType          OrderType `json:"order_type"`
+	OrderSide          OrderSide `json:"order_side"`
+	OldQuantity        int64     `json:"old_quantity"`
+	NewQuantity        int64     `json:"new_quantity"`
+	OldLimitPriceCents int64     `json:"old_limit_price_cents"`
+	NewLimitPriceCents int64     `json:"new_limit_price_cents"`
+	Requeued           bool      `json:"requeued"`
+	ReservedCash       int64     `json:"reserved_cash_cents,omitempty"` // Reserve of a stop-market or trailing-stop buy after the amend
+}
+
+// SelfTradePreventedEvent is emitted for every match skipped because both orders belong
+// to the same trader or owner group. Quantity is how much would have traded.
+type SelfTradePreventedEvent struct {
+	StockTicker      string              `json:"stock_ticker"`
+	IncomingOrderID  string              `json:"incoming_order_id"`
+	RestingOrderID   string              `json:"resting_order_id"`
+	IncomingTraderID int64               `json:"incoming_trader_id"`
+	RestingTraderID  int64               `json:"resting_trader_id"`
+	Mode             SelfTradePrevention `json:"mode"`
+	Quantity         int64               `json:"quantity"`
+	PriceCents       int64               `json:"price_cents"`
+}
+
+// AuctionUncrossedEvent is emitted when an opening or closing auction ends. PriceCents is the
+// uncrossing price, or 0 if nothing crossed.
+type AuctionUncrossedEvent struct {
+	StockTicker string `json:"stock_ticker"`
+	PriceCents  int64  `json:"price_cents"`
+	Volume      int64  `json:"volume"`
+	Closing     bool   `json:"closing"`
+}
+
+// TradingHaltedEvent is emitted when an order would have traded outside a price band.
+// Orders are rejected until the halt ends with a reopening auction.
+type TradingHaltedEvent struct {
+	StockTicker         string     `json:"stock_ticker"`
+	Reason              HaltReason `json:"reason"`
+	PriceCents          int64      `json:"price_cents"` // Price of the trade that was prevented
+	ReferencePriceCents int64      `json:"reference_price_cents"`
+	LastTradePriceCents int64      `json:"last_trade_price_cents"`
+	HaltedUntil         time.Time  `json:"halted_until"`
+}
+
+// TradingResumedEvent is emitted when a halt ends. PriceCents is the reopening auction price,
+// or the last trade price if nothing crossed.
+type TradingResumedEvent struct {
+	StockTicker string `json:"stock_ticker"`
+	PriceCents  int64  `json:"price_cents"`
+}
+
+// SessionPhaseChangedEvent is emitted when the market moves to another phase of its trading day
+type SessionPhaseChangedEvent struct {
+	Phase         SessionPhase `json:"phase"`
+	PreviousPhase SessionPhase `json:"previous_phase"`
+	ChangedAt     time.Time    `json:"changed_at"`
+}
@@ -0,0 +1,73 @@
+package types
+
+import (
+	"container/heap"
+	"time"
+)
+
+type expiryEntry struct {
+	expireAt time.Time
+	orderId  string
+}
+
+// expiryHeap implements heap.Interface as a min-heap on expiry time
+type expiryHeap []expiryEntry
+
+func (h expiryHeap) Len() int           { return len(h) }
+func (h expiryHeap) Less(i, j int) bool { return h[i].expireAt.Before(h[j].expireAt) }
+func (h expiryHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *expiryHeap) Push(x any) {
+	*h = append(*h, x.(expiryEntry))
+}
+
+func (h *expiryHeap) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[0 : n-1]
+	return x
+}
+
+// ExpiryQueue tracks when DAY/GTD orders must leave the book.
+// Entries are not removed when an order fills or is cancelled; like the price heap,
+// stale entries are dropped lazily when they reach the top and the order is gone.
+type ExpiryQueue struct {
+	entries *expiryHeap
+}
+
+// NewExpiryQueue creates an empty expiry queue
+func NewExpiryQueue() *ExpiryQueue {
+	h := &expiryHeap{}
+	heap.Init(h)
+	return &ExpiryQueue{entries: h}
+}
+
+// Schedule records the expiry of an order. Orders without an expiry are ignored.
+func (q *ExpiryQueue) Schedule(order *Order) {
+	if order.ExpireAt.IsZero() {
+		return
+	}
+	heap.Push(q.entries, expiryEntry{expireAt: order.ExpireAt, orderId: order.OrderId})
+}
+
+// PopExpired removes and returns the IDs of all orders whose expiry is at or before now.
+// Some IDs may belong to orders that already left the book.
+func (q *ExpiryQueue) PopExpired(now time.Time) []string {
+	var expired []string
+	for q.entries.Len() > 0 && !(*q.entries)[0].expireAt.After(now) {
+		entry := heap.Pop(q.entries).(expiryEntry)
+		expired = append(expired, entry.orderId)
+	}
+	return expired
+}
+
+// Due reports whether PopExpired would return anything at now
+func (q *ExpiryQueue) Due(now time.Time) bool {
+	return q.entries.Len() > 0 && !(*q.entries)[0].expireAt.After(now)
+}
+
+// Len returns the number of scheduled entries, including stale ones
+func (q *ExpiryQueue) Len() int {
+	return q.entries.Len()
+}
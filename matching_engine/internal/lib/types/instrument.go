@@ -0,0 +1,43 @@
+package types
+
+// InstrumentSpec is the price and quantity grid orders for a stock must sit on
+type InstrumentSpec struct {
+	TickSize    int64 `json:"tick_size"`    // Minimum price increment in cents
+	LotSize     int64 `json:"lot_size"`     // Quantities must be a multiple of this
+	MinQuantity int64 `json:"min_quantity"` // Smallest accepted order quantity
+}
+
+// DefaultInstrumentSpec accepts any whole-cent price and any positive quantity
+var DefaultInstrumentSpec = InstrumentSpec{TickSize: 1, LotSize: 1, MinQuantity: 1}
+
+// NewInstrumentSpec builds a spec, defaulting sizes that are not positive to 1
+func NewInstrumentSpec(tickSize, lotSize, minQuantity int64) InstrumentSpec {
+	spec := DefaultInstrumentSpec
+	if tickSize > 0 {
+		spec.TickSize = tickSize
+	}
+	if lotSize > 0 {
+		spec.LotSize = lotSize
+	}
+	if minQuantity > 0 {
+		spec.MinQuantity = minQuantity
+	}
+	return spec
+}
+
+// OnTick reports whether price is a multiple of the tick size
+func (s InstrumentSpec) OnTick(price int64) bool {
+	return s.TickSize <= 1 || price%s.TickSize == 0
+}
+
+// OnLot reports whether qty is a multiple of the lot size
+func (s InstrumentSpec) OnLot(qty int64) bool {
+	return s.LotSize <= 1 || qty%s.LotSize == 0
+}
+
+// Instrument is a stock the engine accepts orders for
+type Instrument struct {
+	Ticker string         `json:"ticker"`
+	Spec   InstrumentSpec `json:"spec"`
+	Active bool           `json:"active"` // Inactive instruments reject new orders; resting orders can still be cancelled
+}
@@ -0,0 +1,112 @@
+package types
+
+// Allocation is the quantity a resting order receives from an incoming order
+type Allocation struct {
+	Order    *Order
+	Quantity int64
+}
+
+// MatchingPolicy decides how an incoming quantity is shared among the resting orders
+// of a price level. Allocations never exceed an order's visible quantity and are
+// executed in the order returned; the matcher calls Allocate again for whatever is left.
+type MatchingPolicy interface {
+	Allocate(level *PriceLevel, qty int64) []Allocation
+}
+
+// FIFO fills the oldest order at the level first (price-time priority)
+type FIFO struct{}
+
+// ProRata shares the incoming quantity among all orders at the level in proportion
+// to their visible quantity. Rounding leftovers go to the oldest orders first.
+type ProRata struct{}
+
+// ProRataTopOrder fills the oldest order at the level first, then shares the rest pro-rata
+type ProRataTopOrder struct{}
+
+var matchingPolicyNames = map[string]MatchingPolicy{
+	"FIFO":         FIFO{},
+	"PRO_RATA":     ProRata{},
+	"PRO_RATA_TOP": ProRataTopOrder{},
+}
+
+// ParseMatchingPolicy maps a configuration name such as "PRO_RATA" to its policy
+func ParseMatchingPolicy(name string) (MatchingPolicy, bool) {
+	policy, ok := matchingPolicyNames[name]
+	return policy, ok
+}
+
+// MatchingPolicyName returns the configuration name of a policy, or false for a policy
+// ParseMatchingPolicy doesn't know
+func MatchingPolicyName(policy MatchingPolicy) (string, bool) {
+	for name, known := range matchingPolicyNames {
+		if known == policy {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+func (FIFO) Allocate(level *PriceLevel, qty int64) []Allocation {
+	front := level.Front()
+	if front == nil || qty <= 0 {
+		return nil
+	}
+	return []Allocation{{Order: front, Quantity: min(qty, front.VisibleQuantity())}}
+}
+
+func (ProRata) Allocate(level *PriceLevel, qty int64) []Allocation {
+	return proRata(level.Orders(), qty)
+}
+
+func (ProRataTopOrder) Allocate(level *PriceLevel, qty int64) []Allocation {
+	orders := level.Orders()
+	if len(orders) == 0 || qty <= 0 {
+		return nil
+	}
+	top := Allocation{Order: orders[0], Quantity: min(qty, orders[0].VisibleQuantity())}
+	return append([]Allocation{top}, proRata(orders[1:], qty-top.Quantity)...)
+}
+
+// proRata splits qty among orders in proportion to their visible quantity
+func proRata(orders []*Order, qty int64) []Allocation {
+	if len(orders) == 0 || qty <= 0 {
+		return nil
+	}
+
+	var total int64
+	for _, order := range orders {
+		total += order.VisibleQuantity()
+	}
+
+	shares := make([]int64, len(orders))
+	allocated := int64(0)
+	for i, order := range orders {
+		if qty >= total {
+			shares[i] = order.VisibleQuantity()
+		} else {
+			shares[i] = qty * order.VisibleQuantity() / total
+		}
+		allocated += shares[i]
+	}
+
+	// Hand out rounding leftovers one share at a time in time priority
+	for leftover := min(qty, total) - allocated; leftover > 0; {
+		for i, order := range orders {
+			if leftover == 0 {
+				break
+			}
+			if shares[i] < order.VisibleQuantity() {
+				shares[i]++
+				leftover--
+			}
+		}
+	}
+
+	allocations := make([]Allocation, 0, len(orders))
+	for i, order := range orders {
+		if shares[i] > 0 {
+			allocations = append(allocations, Allocation{Order: order, Quantity: shares[i]})
+		}
+	}
+	return allocations
+}
@@ -1,7 +1,6 @@
 package types
 
 import (
-	"container/heap"
 	"container/list"
 	"sync"
 	"time"
@@ -13,8 +12,16 @@ type OrderType int
 const (
 	MarketOrder OrderType = iota
 	LimitOrder
+	StopMarketOrder   // Held off-book until the trigger price trades, then becomes a MarketOrder
+	StopLimitOrder    // Held off-book until the trigger price trades, then becomes a LimitOrder
+	TrailingStopOrder // Like StopMarketOrder, with a trigger that follows the last trade price
 )
 
+// IsStop reports whether the order type waits for a trigger price before matching
+func (t OrderType) IsStop() bool {
+	return t == StopMarketOrder || t == StopLimitOrder || t == TrailingStopOrder
+}
+
 // OrderSide - On What side of the order the request falls
 type OrderSide int
 
@@ -23,6 +30,51 @@ const (
 	Sell
 )
 
+// Opposite returns the side orders with this side trade against
+func (s OrderSide) Opposite() OrderSide {
+	if s == Sell {
+		return Buy
+	}
+	return Sell
+}
+
+// TimeInForce - How long an order keeps working before the unfilled part is cancelled
+type TimeInForce int
+
+const (
+	GoodTillCancel    TimeInForce = iota // Rests until filled or cancelled
+	ImmediateOrCancel                    // Fills what it can immediately, cancels the rest
+	FillOrKill                           // Fills completely immediately or is cancelled without trading
+	Day                                  // Rests until the end of the trading day
+	GoodTillDate                         // Rests until ExpireAt
+)
+
+// SelfTradePrevention - What happens when an order would trade against its own trader or owner group
+type SelfTradePrevention int
+
+const (
+	SelfTradeDefault      SelfTradePrevention = iota // Use the engine's configured mode
+	SelfTradeAllow                                   // Let the orders trade
+	SelfTradeCancelNewest                            // Cancel the incoming order's remainder
+	SelfTradeCancelOldest                            // Cancel the resting order and keep matching
+	SelfTradeCancelBoth                              // Cancel both orders
+	SelfTradeDecrement                               // Reduce both by the smaller quantity, cancelling whichever reaches zero
+)
+
+var selfTradePreventionNames = map[string]SelfTradePrevention{
+	"ALLOW":         SelfTradeAllow,
+	"CANCEL_NEWEST": SelfTradeCancelNewest,
+	"CANCEL_OLDEST": SelfTradeCancelOldest,
+	"CANCEL_BOTH":   SelfTradeCancelBoth,
+	"DECREMENT":     SelfTradeDecrement,
+}
+
+// ParseSelfTradePrevention maps a configuration name such as "CANCEL_NEWEST" to its mode
+func ParseSelfTradePrevention(name string) (SelfTradePrevention, bool) {
+	mode, ok := selfTradePreventionNames[name]
+	return mode, ok
+}
+
 // Order - The order itself
 type Order struct {
 	OrderId          string
@@ -32,8 +84,50 @@ type Order struct {
 	OrderSide        OrderSide
 	Quantity         int64
 	LimitPrice       int64
-	AvailableBalance int64 // For MARKET BUY: buyer's available cash to cap spend
+	TriggerPrice     int64  // For STOP orders: last trade price that releases the order
+	AvailableBalance int64  // For MARKET BUY: buyer's available cash to cap spend
+	AvailableShares  *int64 // For SELL: seller's shares not already held by other orders; nil leaves the quantity uncapped
+	TimeInForce      TimeInForce
+	ExpireAt         time.Time // For DAY/GTD: when the unfilled part is cancelled
+	PostOnly         bool      // For LIMIT: only add liquidity, never take it
+	PostOnlyReprice  bool      // For POST-ONLY: reprice one tick behind the opposite best price instead of rejecting
+	DisplayQuantity  int64     // For ICEBERG: size of the visible slice, 0 shows the full quantity
+	OwnerTraderId    int64     // For bots: the owning trader, 0 if the trader is not a bot
+	TrailingOffset   int64     // For TRAILING STOP: distance of the trigger from the best price seen, in cents
+	TrailingBps      int64     // For TRAILING STOP: the same distance in basis points of the price, if TrailingOffset is 0
+	SelfTrade        SelfTradePrevention
+	ClientOrderId    string // The trader's own ID for the order, empty if not given
 	Timestamp        time.Time
+	displayed        int64 // For ICEBERG: what is left of the current visible slice while resting
+}
+
+// SelfTradeGroup returns the ID shared by a trader and the bots they own
+func (o *Order) SelfTradeGroup() int64 {
+	if o.OwnerTraderId > 0 {
+		return o.OwnerTraderId
+	}
+	return o.TraderId
+}
+
+// IsIceberg reports whether only part of the order's quantity is shown on the book
+func (o *Order) IsIceberg() bool {
+	return o.DisplayQuantity > 0 && o.DisplayQuantity < o.Quantity
+}
+
+// RestsOnBook reports whether an unfilled remainder of the order is added to the book
+func (o *Order) RestsOnBook() bool {
+	return o.OrderType == LimitOrder &&
+		o.TimeInForce != ImmediateOrCancel &&
+		o.TimeInForce != FillOrKill
+}
+
+// VisibleQuantity returns the quantity available to match against the resting order.
+// For icebergs this is the current slice, otherwise the full remaining quantity.
+func (o *Order) VisibleQuantity() int64 {
+	if o.DisplayQuantity > 0 {
+		return o.displayed
+	}
+	return o.Quantity
 }
 
 // MatchedEvent represents a successful trade between buyer and seller
@@ -49,7 +143,8 @@ type MatchedEvent struct {
 type PriceLevel struct {
 	price  int64
 	orders *list.List // Doubly-linked list for FIFO ordering
-	volume int64      // Total quantity at this price level
+	volume int64      // Total visible quantity at this price level
+	hidden int64      // Iceberg quantity not yet shown at this price level
 }
 
 // NewPriceLevel creates a new price level
@@ -63,10 +158,42 @@ func NewPriceLevel(price int64) *PriceLevel {
 
 // AddOrder adds an order to this price level (appends to end for FIFO)
 func (pl *PriceLevel) AddOrder(order *Order) *list.Element {
-	pl.volume += order.Quantity
+	if order.DisplayQuantity > 0 {
+		order.displayed = min(order.DisplayQuantity, order.Quantity)
+	}
+	return pl.push(order)
+}
+
+// push appends an order to this price level as it is, keeping its current iceberg slice
+func (pl *PriceLevel) push(order *Order) *list.Element {
+	pl.volume += order.VisibleQuantity()
+	pl.hidden += order.Quantity - order.VisibleQuantity()
 	return pl.orders.PushBack(order)
 }
 
+// Fill reduces an order at this level by qty, which must not exceed its visible quantity.
+// An iceberg whose visible slice is used up shows a new slice from the back of the queue,
+// losing its time priority.
+func (pl *PriceLevel) Fill(element *list.Element, qty int64) {
+	order, ok := element.Value.(*Order)
+	if !ok {
+		return
+	}
+	order.Quantity -= qty
+	pl.volume -= qty
+	if order.DisplayQuantity == 0 {
+		return
+	}
+
+	order.displayed -= qty
+	if order.displayed == 0 && order.Quantity > 0 {
+		order.displayed = min(order.DisplayQuantity, order.Quantity)
+		pl.volume += order.displayed
+		pl.hidden -= order.displayed
+		pl.orders.MoveToBack(element)
+	}
+}
+
 // Front returns the first order at this price level
 func (pl *PriceLevel) Front() *Order {
 	if pl.orders.Len() == 0 {
@@ -78,10 +205,27 @@ func (pl *PriceLevel) Front() *Order {
 	return nil
 }
 
+// Reduce lowers an order at this level to newQty without changing its queue position
+func (pl *PriceLevel) Reduce(element *list.Element, newQty int64) {
+	order, ok := element.Value.(*Order)
+	if !ok {
+		return
+	}
+	pl.volume -= order.VisibleQuantity()
+	pl.hidden -= order.Quantity - order.VisibleQuantity()
+	order.Quantity = newQty
+	if order.DisplayQuantity > 0 {
+		order.displayed = min(order.displayed, newQty)
+	}
+	pl.volume += order.VisibleQuantity()
+	pl.hidden += order.Quantity - order.VisibleQuantity()
+}
+
 // RemoveOrder removes an order from this price level
 func (pl *PriceLevel) RemoveOrder(element *list.Element) {
 	if order, ok := element.Value.(*Order); ok {
-		pl.volume -= order.Quantity
+		pl.volume -= order.VisibleQuantity()
+		pl.hidden -= order.Quantity - order.VisibleQuantity()
 	}
 	pl.orders.Remove(element)
 }
@@ -96,7 +240,8 @@ func (pl *PriceLevel) RemoveFront() *Order {
 	if !ok {
 		return nil
 	}
-	pl.volume -= order.Quantity
+	pl.volume -= order.VisibleQuantity()
+	pl.hidden -= order.Quantity - order.VisibleQuantity()
 	pl.orders.Remove(element)
 	return order
 }
@@ -106,81 +251,72 @@ func (pl *PriceLevel) IsEmpty() bool {
 	return pl.orders.Len() == 0
 }
 
-// PriceHeap implements heap.Interface for price levels
-// For buy side: max-heap (highest price first)
-// For sell side: min-heap (lowest price first)
-type PriceHeap struct {
-	prices    []int64
-	isBuySide bool
+// Price returns the price of this level
+func (pl *PriceLevel) Price() int64 {
+	return pl.price
 }
 
-func (h PriceHeap) Len() int { return len(h.prices) }
-
-func (h PriceHeap) Less(i, j int) bool {
-	if h.isBuySide {
-		return h.prices[i] > h.prices[j] // Max-heap for buy side
-	}
-	return h.prices[i] < h.prices[j] // Min-heap for sell side
+// Volume returns the total visible quantity resting at this level
+func (pl *PriceLevel) Volume() int64 {
+	return pl.volume
 }
 
-func (h PriceHeap) Swap(i, j int) { h.prices[i], h.prices[j] = h.prices[j], h.prices[i] }
-
-func (h *PriceHeap) Push(x any) {
-	h.prices = append(h.prices, x.(int64))
-}
-
-func (h *PriceHeap) Pop() any {
-	old := h.prices
-	n := len(old)
-	x := old[n-1]
-	h.prices = old[0 : n-1]
-	return x
+// TotalVolume returns the total quantity resting at this level, including hidden iceberg quantity
+func (pl *PriceLevel) TotalVolume() int64 {
+	return pl.volume + pl.hidden
 }
 
-// Peek returns the best price without removing it
-func (h *PriceHeap) Peek() (int64, bool) {
-	if len(h.prices) == 0 {
-		return 0, false
-	}
-	return h.prices[0], true
+// Len returns the number of orders resting at this level
+func (pl *PriceLevel) Len() int {
+	return pl.orders.Len()
 }
 
 // OrderBookSide represents one side of the order book (buy or sell)
 type OrderBookSide struct {
-	levels       map[int64]*PriceLevel    // price -> PriceLevel
-	priceHeap    *PriceHeap               // Heap for O(log n) best price access
+	levels       PriceLadder              // Price levels in price priority
 	orderLookup  map[string]*list.Element // orderId -> list element for O(1) cancellation
 	orderToPrice map[string]int64         // orderId -> price for lookup
-	isBuySide    bool                     // true for buy side, false for sell side
 }
 
-// NewOrderBookSide creates a new order book side
+// NewOrderBookSide creates a new order book side on an array ladder
 func NewOrderBookSide(isBuySide bool) *OrderBookSide {
-	h := &PriceHeap{prices: make([]int64, 0), isBuySide: isBuySide}
-	heap.Init(h)
+	return NewLadderSide(NewArrayLadder(isBuySide))
+}
+
+// NewLadderSide creates a new order book side that keeps its price levels in ladder
+func NewLadderSide(ladder PriceLadder) *OrderBookSide {
 	return &OrderBookSide{
-		levels:       make(map[int64]*PriceLevel),
-		priceHeap:    h,
+		levels:       ladder,
 		orderLookup:  make(map[string]*list.Element),
 		orderToPrice: make(map[string]int64),
-		isBuySide:    isBuySide,
 	}
 }
 
+// level returns the price level at price, creating it if it doesn't exist
+func (obs *OrderBookSide) level(price int64) *PriceLevel {
+	level := obs.levels.Level(price)
+	if level == nil {
+		level = NewPriceLevel(price)
+		obs.levels.Add(level)
+	}
+	return level
+}
+
 // AddOrder adds an order to the order book side
 func (obs *OrderBookSide) AddOrder(order *Order) {
-	price := order.LimitPrice
+	obs.index(order, obs.level(order.LimitPrice).AddOrder(order))
+}
 
-	// Create price level if it doesn't exist
-	if _, exists := obs.levels[price]; !exists {
-		obs.levels[price] = NewPriceLevel(price)
-		heap.Push(obs.priceHeap, price)
-	}
+// restoreOrder appends an order to the back of its price level as it was saved,
+// keeping its current iceberg slice
+func (obs *OrderBookSide) restoreOrder(order *Order) {
+	obs.index(order, obs.level(order.LimitPrice).push(order))
+}
 
-	// Add order to price level
-	element := obs.levels[price].AddOrder(order)
+// index records where a resting order sits for O(1) lookup
+func (obs *OrderBookSide) index(order *Order, element *list.Element) {
 	obs.orderLookup[order.OrderId] = element
-	obs.orderToPrice[order.OrderId] = price
+	obs.orderToPrice[order.OrderId] = order.LimitPrice
 }
 
 // RemoveOrder removes an order by ID and returns the removed order.
@@ -195,60 +331,136 @@ func (obs *OrderBookSide) RemoveOrder(orderId string) (*Order, bool) {
 		return nil, false
 	}
 	price := obs.orderToPrice[orderId]
-	level := obs.levels[price]
+	level := obs.levels.Level(price)
 
 	level.RemoveOrder(element)
 	delete(obs.orderLookup, orderId)
 	delete(obs.orderToPrice, orderId)
 
-	// Remove empty price level from map (lazy deletion in heap)
 	if level.IsEmpty() {
-		delete(obs.levels, price)
-		// Price remains in heap as stale entry - cleaned up lazily in GetBestPrice/PopBestPrice
+		obs.levels.Remove(price)
 	}
 
 	return order, true
 }
 
-// cleanStaleHeapTop removes stale prices from heap top (prices with no level)
-func (obs *OrderBookSide) cleanStaleHeapTop() {
-	for obs.priceHeap.Len() > 0 {
-		topPrice, _ := obs.priceHeap.Peek()
-		if _, exists := obs.levels[topPrice]; exists {
-			break // Top price is valid
+// GetOrder returns a resting order by ID
+func (obs *OrderBookSide) GetOrder(orderId string) (*Order, bool) {
+	element, exists := obs.orderLookup[orderId]
+	if !exists {
+		return nil, false
+	}
+	order, ok := element.Value.(*Order)
+	return order, ok
+}
+
+// OrdersOf returns the resting orders placed by traderId, in no particular order
+func (obs *OrderBookSide) OrdersOf(traderId int64) []*Order {
+	var orders []*Order
+	for _, element := range obs.orderLookup {
+		if order, ok := element.Value.(*Order); ok && order.TraderId == traderId {
+			orders = append(orders, order)
 		}
-		// Remove stale price
-		heap.Pop(obs.priceHeap)
 	}
+	return orders
+}
+
+// ReduceOrder lowers a resting order's quantity to newQty, keeping its time priority.
+// newQty must be positive and below the order's current quantity.
+func (obs *OrderBookSide) ReduceOrder(orderId string, newQty int64) bool {
+	element, exists := obs.orderLookup[orderId]
+	if !exists {
+		return false
+	}
+	obs.levels.Level(obs.orderToPrice[orderId]).Reduce(element, newQty)
+	return true
+}
+
+// FillOrder reduces a resting order by qty, which must not exceed its visible quantity.
+// Fully filled orders are removed from the book. Returns true if the order was fully filled.
+func (obs *OrderBookSide) FillOrder(order *Order, qty int64) bool {
+	element, exists := obs.orderLookup[order.OrderId]
+	if !exists {
+		return false
+	}
+	obs.levels.Level(obs.orderToPrice[order.OrderId]).Fill(element, qty)
+	if order.Quantity > 0 {
+		return false
+	}
+	obs.RemoveOrder(order.OrderId)
+	return true
 }
 
 // GetBestPrice returns the best price on this side (highest for buy, lowest for sell)
-// Uses lazy deletion to skip stale prices
 func (obs *OrderBookSide) GetBestPrice() (int64, bool) {
-	obs.cleanStaleHeapTop()
-	return obs.priceHeap.Peek()
+	level := obs.levels.Best()
+	if level == nil {
+		return 0, false
+	}
+	return level.price, true
 }
 
 // GetBestLevel returns the price level at the best price
 func (obs *OrderBookSide) GetBestLevel() *PriceLevel {
-	price, ok := obs.GetBestPrice()
-	if !ok {
-		return nil
+	return obs.levels.Best()
+}
+
+// Orders returns the orders at this level in time priority
+func (pl *PriceLevel) Orders() []*Order {
+	orders := make([]*Order, 0, pl.orders.Len())
+	for e := pl.orders.Front(); e != nil; e = e.Next() {
+		if order, ok := e.Value.(*Order); ok {
+			orders = append(orders, order)
+		}
 	}
-	return obs.levels[price]
+	return orders
 }
 
 // IsEmpty returns true if there are no orders on this side
 func (obs *OrderBookSide) IsEmpty() bool {
-	return len(obs.levels) == 0
+	return obs.levels.Len() == 0
+}
+
+// SortedLevels returns the live price levels ordered best price first
+func (obs *OrderBookSide) SortedLevels() []*PriceLevel {
+	return obs.TopLevels(0)
+}
+
+// TopLevels returns the best depth price levels, best price first, or every level if depth <= 0
+func (obs *OrderBookSide) TopLevels(depth int) []*PriceLevel {
+	n := obs.levels.Len()
+	if depth > 0 {
+		n = min(n, depth)
+	}
+	levels := make([]*PriceLevel, 0, n)
+	obs.levels.Each(func(level *PriceLevel) bool {
+		levels = append(levels, level)
+		return len(levels) < n
+	})
+	return levels
 }
 
 // StockOrderBook represents the order book for a single stock
 type StockOrderBook struct {
-	stock    string
-	BuySide  *OrderBookSide // Bid side: buyers
-	SellSide *OrderBookSide // Ask side: sellers
-	Mu       sync.RWMutex   // Per-stock lock for concurrent access
+	stock          string
+	BuySide        *OrderBookSide // Bid side: buyers
+	SellSide       *OrderBookSide // Ask side: sellers
+	Stops          *StopBook      // Untriggered stop orders, not visible on either side
+	Expiries       *ExpiryQueue   // Expiry times of DAY/GTD orders, soonest first
+	LastTradePrice int64          // Price of the most recent execution, 0 if none yet
+	Policy         MatchingPolicy // How incoming quantity is shared among the orders at a level
+	Spec           InstrumentSpec // Tick and lot sizes orders must respect
+	InAuction      bool           // Orders are collected without matching until the book is uncrossed
+	Bands          PriceBands     // Limits on how far a trade may move the price
+	ReferencePrice int64          // Static band reference: the last auction price, 0 if none yet
+	HaltedUntil    time.Time      // End of the current trading halt, zero when trading
+	Sequence       int64          // Sequence number of the last event published for the stock
+	Mu             sync.RWMutex   // Per-stock lock for concurrent access
+}
+
+// Stock returns the ticker of the stock the book belongs to
+func (b *StockOrderBook) Stock() string {
+	return b.stock
 }
 
 // NewStockOrderBook creates a new order book for a stock
@@ -257,5 +469,48 @@ func NewStockOrderBook(stock string) *StockOrderBook {
 		stock:    stock,
 		BuySide:  NewOrderBookSide(true),
 		SellSide: NewOrderBookSide(false),
+		Stops:    NewStopBook(),
+		Expiries: NewExpiryQueue(),
+		Policy:   FIFO{},
+		Spec:     DefaultInstrumentSpec,
 	}
 }
+
+// Side returns the side of the book orders with the given side rest on
+func (b *StockOrderBook) Side(side OrderSide) *OrderBookSide {
+	if side == Sell {
+		return b.SellSide
+	}
+	return b.BuySide
+}
+
+// Rest adds an order to its side of the book and schedules its expiry
+func (b *StockOrderBook) Rest(order *Order) {
+	b.Side(order.OrderSide).AddOrder(order)
+	b.Expiries.Schedule(order)
+}
+
+// RecordTrade notes a trade at price: it becomes the last trade price and trailing stops
+// follow it. Returns the trailing stops whose trigger moved.
+func (b *StockOrderBook) RecordTrade(price int64) []*Order {
+	b.LastTradePrice = price
+	return b.Stops.Trail(price, b.Spec.TickSize)
+}
+
+// EndAuction returns the book to continuous matching after an auction that traded volume at
+// price. An auction that traded moves the static price band reference to its price.
+// Returns the trailing stops whose trigger the auction price moved.
+func (b *StockOrderBook) EndAuction(price, volume int64) []*Order {
+	b.InAuction = false
+	if volume == 0 {
+		return nil
+	}
+	b.ReferencePrice = price
+	return b.RecordTrade(price)
+}
+
+// Halt stops trading until the given time; orders are collected as in an auction meanwhile
+func (b *StockOrderBook) Halt(until time.Time) {
+	b.HaltedUntil = until
+	b.InAuction = true
+}
@@ -0,0 +1,36 @@
+package types
+
+import "time"
+
+// PriceBands limits how far a single trade may move a stock's price before trading is halted
+type PriceBands struct {
+	StaticBps    int64         // Max distance from the reference price in basis points, 0 disables
+	DynamicBps   int64         // Max distance from the last trade price in basis points, 0 disables
+	HaltDuration time.Duration // How long trading stops after a breach
+}
+
+// BandBreach returns which band a trade at price would break, or "" if it is allowed
+func (b *StockOrderBook) BandBreach(price int64) HaltReason {
+	if outsideBand(price, b.ReferencePrice, b.Bands.StaticBps) {
+		return HaltReasonStaticBand
+	}
+	if outsideBand(price, b.LastTradePrice, b.Bands.DynamicBps) {
+		return HaltReasonDynamicBand
+	}
+	return ""
+}
+
+// IsHalted reports whether trading in the stock is halted after a band breach
+func (b *StockOrderBook) IsHalted() bool {
+	return !b.HaltedUntil.IsZero()
+}
+
+// outsideBand reports whether price is more than bps basis points away from reference.
+// A band without a reference price or width never applies.
+func outsideBand(price, reference, bps int64) bool {
+	if reference <= 0 || bps <= 0 {
+		return false
+	}
+	distance := max(price-reference, reference-price)
+	return distance*10000 > reference*bps
+}
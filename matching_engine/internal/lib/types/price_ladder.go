@@ -0,0 +1,199 @@
+package types
+
+import (
+	"container/heap"
+	"slices"
+	"sort"
+)
+
+// PriceLadder keeps the price levels of one side of a book in price priority:
+// highest price first for bids, lowest first for asks
+type PriceLadder interface {
+	// Level returns the level at price, or nil if there is none
+	Level(price int64) *PriceLevel
+	// Add inserts a level for a price that has none yet
+	Add(level *PriceLevel)
+	// Remove drops the level at price
+	Remove(price int64)
+	// Best returns the level with the best price, or nil if the ladder is empty
+	Best() *PriceLevel
+	// Each calls fn for every level, best price first, until fn returns false
+	Each(fn func(*PriceLevel) bool)
+	// Len returns the number of levels
+	Len() int
+}
+
+// NewArrayLadder creates the default ladder: levels are kept in a slice sorted worst price
+// first, so the best level is the last element. Prices near the top of the book, where most
+// orders arrive and leave, only shift a few elements, and removed prices leave nothing behind.
+func NewArrayLadder(isBuySide bool) PriceLadder {
+	return &arrayLadder{
+		byPrice:   make(map[int64]*PriceLevel),
+		isBuySide: isBuySide,
+	}
+}
+
+type arrayLadder struct {
+	levels    []*PriceLevel         // Worst price first
+	byPrice   map[int64]*PriceLevel // price -> level for O(1) lookup
+	isBuySide bool
+}
+
+// better reports whether price a has priority over price b
+func (l *arrayLadder) better(a, b int64) bool {
+	if l.isBuySide {
+		return a > b
+	}
+	return a < b
+}
+
+// search returns the index of the first level whose price is not worse than price
+func (l *arrayLadder) search(price int64) int {
+	return sort.Search(len(l.levels), func(i int) bool {
+		return !l.better(price, l.levels[i].price)
+	})
+}
+
+func (l *arrayLadder) Level(price int64) *PriceLevel {
+	return l.byPrice[price]
+}
+
+func (l *arrayLadder) Add(level *PriceLevel) {
+	l.levels = slices.Insert(l.levels, l.search(level.price), level)
+	l.byPrice[level.price] = level
+}
+
+func (l *arrayLadder) Remove(price int64) {
+	if _, exists := l.byPrice[price]; !exists {
+		return
+	}
+	delete(l.byPrice, price)
+	i := l.search(price)
+	l.levels = slices.Delete(l.levels, i, i+1)
+}
+
+func (l *arrayLadder) Best() *PriceLevel {
+	if len(l.levels) == 0 {
+		return nil
+	}
+	return l.levels[len(l.levels)-1]
+}
+
+func (l *arrayLadder) Each(fn func(*PriceLevel) bool) {
+	for i := len(l.levels) - 1; i >= 0; i-- {
+		if !fn(l.levels[i]) {
+			return
+		}
+	}
+}
+
+func (l *arrayLadder) Len() int {
+	return len(l.levels)
+}
+
+// NewHeapLadder creates a ladder that keeps prices in a heap with lazy deletion: removed
+// prices stay in the heap until they reach its top, and ordered iteration sorts every level.
+// Kept to compare against the array ladder.
+func NewHeapLadder(isBuySide bool) PriceLadder {
+	h := &PriceHeap{prices: make([]int64, 0), isBuySide: isBuySide}
+	heap.Init(h)
+	return &heapLadder{
+		levels:    make(map[int64]*PriceLevel),
+		priceHeap: h,
+		isBuySide: isBuySide,
+	}
+}
+
+type heapLadder struct {
+	levels    map[int64]*PriceLevel // price -> PriceLevel
+	priceHeap *PriceHeap            // Heap for O(log n) best price access
+	isBuySide bool
+}
+
+func (l *heapLadder) Level(price int64) *PriceLevel {
+	return l.levels[price]
+}
+
+func (l *heapLadder) Add(level *PriceLevel) {
+	l.levels[level.price] = level
+	heap.Push(l.priceHeap, level.price)
+}
+
+// Remove drops the level; its price remains in the heap as a stale entry until Best reaches it
+func (l *heapLadder) Remove(price int64) {
+	delete(l.levels, price)
+}
+
+// Best cleans stale prices off the top of the heap, so it changes the ladder
+func (l *heapLadder) Best() *PriceLevel {
+	for l.priceHeap.Len() > 0 {
+		topPrice, _ := l.priceHeap.Peek()
+		if level, exists := l.levels[topPrice]; exists {
+			return level
+		}
+		heap.Pop(l.priceHeap)
+	}
+	return nil
+}
+
+// Each sorts every live level, so it is O(n log n) in the number of levels
+func (l *heapLadder) Each(fn func(*PriceLevel) bool) {
+	levels := make([]*PriceLevel, 0, len(l.levels))
+	for _, level := range l.levels {
+		levels = append(levels, level)
+	}
+	sort.Slice(levels, func(i, j int) bool {
+		if l.isBuySide {
+			return levels[i].price > levels[j].price
+		}
+		return levels[i].price < levels[j].price
+	})
+	for _, level := range levels {
+		if !fn(level) {
+			return
+		}
+	}
+}
+
+func (l *heapLadder) Len() int {
+	return len(l.levels)
+}
+
+// PriceHeap implements heap.Interface for price levels
+// For buy side: max-heap (highest price first)
+// For sell side: min-heap (lowest price first)
+type PriceHeap struct {
+	prices    []int64
+	isBuySide bool
+}
+
+func (h PriceHeap) Len() int { return len(h.prices) }
+
+func (h PriceHeap) Less(i, j int) bool {
+	if h.isBuySide {
+		return h.prices[i] > h.prices[j] // Max-heap for buy side
+	}
+	return h.prices[i] < h.prices[j] // Min-heap for sell side
+}
+
+func (h PriceHeap) Swap(i, j int) { h.prices[i], h.prices[j] = h.prices[j], h.prices[i] }
+
+func (h *PriceHeap) Push(x any) {
+	h.prices = append(h.prices, x.(int64))
+}
+
+func (h *PriceHeap) Pop() any {
+	old := h.prices
+	n := len(old)
+	x := old[n-1]
+	h.prices = old[0 : n-1]
+	return x
+}
+
+// Peek returns the best price without removing it
+func (h *PriceHeap) Peek() (int64, bool) {
+	if len(h.prices) == 0 {
+		return 0, false
+	}
+	return h.prices[0], true
+}
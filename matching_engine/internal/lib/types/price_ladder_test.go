@@ -0,0 +1,146 @@
+package types
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// Helper to create a limit order
+func newOrder(id, stock string, side OrderSide, orderType OrderType, qty, price int64) *Order {
+	return &Order{
+		OrderId:     id,
+		StockTicker: stock,
+		OrderType:   orderType,
+		OrderSide:   side,
+		Quantity:    qty,
+		LimitPrice:  price,
+		Timestamp:   time.Now(),
+	}
+}
+
+func TestPriceLadders(t *testing.T) {
+	ladders := map[string]func(bool) PriceLadder{
+		"array": NewArrayLadder,
+		"heap":  NewHeapLadder,
+	}
+
+	for name, newLadder := range ladders {
+		t.Run(name+" ladder should keep levels in price order", func(t *testing.T) {
+			bids := NewLadderSide(newLadder(true))
+			asks := NewLadderSide(newLadder(false))
+			prices := []int64{15000, 14900, 15200, 15100, 14800, 15300, 15000}
+			for i, price := range prices {
+				bids.AddOrder(newOrder(fmt.Sprintf("buy%d", i), "AAPL", Buy, LimitOrder, 10, price))
+				asks.AddOrder(newOrder(fmt.Sprintf("sell%d", i), "AAPL", Sell, LimitOrder, 10, price))
+			}
+			bids.RemoveOrder("buy5")  // Best bid at 15300
+			asks.RemoveOrder("sell4") // Best ask at 14800
+
+			var got []int64
+			for _, level := range bids.TopLevels(3) {
+				got = append(got, level.Price())
+			}
+			if fmt.Sprint(got) != "[15200 15100 15000]" {
+				t.Errorf("expected the three best bids, got %v", got)
+			}
+			got = nil
+			for _, level := range asks.SortedLevels() {
+				got = append(got, level.Price())
+			}
+			if fmt.Sprint(got) != "[14900 15000 15100 15200 15300]" {
+				t.Errorf("expected every ask lowest first, got %v", got)
+			}
+			if best, _ := bids.GetBestPrice(); best != 15200 {
+				t.Errorf("expected best bid 15200, got %d", best)
+			}
+			if level := asks.GetBestLevel(); level == nil || level.Price() != 14900 {
+				t.Errorf("expected best ask level 14900, got %v", level)
+			}
+		})
+	}
+
+	t.Run("array ladder should drop emptied prices", func(t *testing.T) {
+		ladder := NewArrayLadder(true)
+		side := NewLadderSide(ladder)
+		side.AddOrder(newOrder("best", "AAPL", Buy, LimitOrder, 10, 15000))
+		for i := range 100 {
+			id := fmt.Sprintf("buy%d", i)
+			side.AddOrder(newOrder(id, "AAPL", Buy, LimitOrder, 10, int64(14000+i)))
+			side.RemoveOrder(id)
+		}
+		if ladder.Len() != 1 || ladder.Level(14000) != nil {
+			t.Errorf("expected only the best level left, got %d levels", ladder.Len())
+		}
+		side.RemoveOrder("best")
+		if !side.IsEmpty() || side.GetBestLevel() != nil {
+			t.Error("expected an empty side")
+		}
+	})
+
+}
+
+// BenchmarkPriceLadder compares the array ladder with the lazy-deletion heap on one side of a book:
+//   - churn adds and cancels orders at prices behind the best bid, which the heap never cleans up
+//   - depth reads the best ten levels of a side with 2000 levels, as a depth snapshot does
+//   - sweep fills the best level and adds a new worst one, as an aggressive order walking the book
+func BenchmarkPriceLadder(b *testing.B) {
+	ladders := []struct {
+		name      string
+		newLadder func(bool) PriceLadder
+	}{
+		{"array", NewArrayLadder},
+		{"heap", NewHeapLadder},
+	}
+	for _, ladder := range ladders {
+		b.Run("churn/"+ladder.name, func(b *testing.B) {
+			side := NewLadderSide(ladder.newLadder(true))
+			side.AddOrder(newOrder("best", "AAPL", Buy, LimitOrder, 10, 20000))
+			orders := make([]*Order, 4000)
+			for i := range orders {
+				orders[i] = newOrder(fmt.Sprintf("buy%d", i), "AAPL", Buy, LimitOrder, 10, int64(10000+i))
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := range b.N {
+				order := orders[i%len(orders)]
+				side.AddOrder(order)
+				side.GetBestPrice()
+				side.RemoveOrder(order.OrderId)
+			}
+		})
+
+		b.Run("depth/"+ladder.name, func(b *testing.B) {
+			side := NewLadderSide(ladder.newLadder(false))
+			for i := range 2000 {
+				side.AddOrder(newOrder(fmt.Sprintf("sell%d", i), "AAPL", Sell, LimitOrder, 10, int64(15000+i*7%2000)))
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for range b.N {
+				side.TopLevels(10)
+			}
+		})
+
+		b.Run("sweep/"+ladder.name, func(b *testing.B) {
+			side := NewLadderSide(ladder.newLadder(false))
+			for i := range 1000 {
+				side.AddOrder(newOrder(fmt.Sprintf("sell%d", i), "AAPL", Sell, LimitOrder, 10, int64(15000+i)))
+			}
+			orders := make([]*Order, 1000)
+			for i := range orders {
+				orders[i] = newOrder(fmt.Sprintf("new%d", i), "AAPL", Sell, LimitOrder, 10, 0)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := range b.N {
+				best := side.GetBestLevel()
+				side.FillOrder(best.Front(), 10)
+				order := orders[i%len(orders)]
+				order.LimitPrice = int64(16000 + i)
+				order.Quantity = 10
+				side.AddOrder(order)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package types
+
+import (
+	"sort"
+	"time"
+)
+
+// SessionPhase - Stage of the trading day
+type SessionPhase string
+
+const (
+	PhasePreOpen    SessionPhase = "PRE_OPEN"   // Orders are collected for the opening auction
+	PhaseContinuous SessionPhase = "CONTINUOUS" // Orders match as they arrive
+	PhaseClose      SessionPhase = "CLOSE"      // Orders are collected for the closing auction
+	PhaseClosed     SessionPhase = "CLOSED"     // New orders are rejected
+)
+
+// CollectsOrders reports whether books gather orders for an auction during the phase
+func (p SessionPhase) CollectsOrders() bool {
+	return p == PhasePreOpen || p == PhaseClose
+}
+
+// PhaseStart is the time of day, measured from midnight, at which a phase begins
+type PhaseStart struct {
+	Phase SessionPhase
+	At    time.Duration
+}
+
+// SessionCalendar is the weekly trading schedule of the market in one time zone.
+// Days without a schedule and holidays are closed, as is any time before a day's first phase.
+type SessionCalendar struct {
+	Location *time.Location
+	Days     map[time.Weekday][]PhaseStart // Sorted by start time
+	Holidays map[string]bool               // Dates in YYYY-MM-DD form
+}
+
+// NewSessionCalendar creates a calendar, sorting each day's phases by start time
+func NewSessionCalendar(location *time.Location, days map[time.Weekday][]PhaseStart, holidays []string) *SessionCalendar {
+	calendar := &SessionCalendar{
+		Location: location,
+		Days:     make(map[time.Weekday][]PhaseStart, len(days)),
+		Holidays: make(map[string]bool, len(holidays)),
+	}
+	for day, phases := range days {
+		sorted := append([]PhaseStart(nil), phases...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i].At < sorted[j].At })
+		calendar.Days[day] = sorted
+	}
+	for _, date := range holidays {
+		calendar.Holidays[date] = true
+	}
+	return calendar
+}
+
+// PhaseAt returns the phase the market is in at t
+func (c *SessionCalendar) PhaseAt(t time.Time) SessionPhase {
+	local := t.In(c.Location)
+	phase := PhaseClosed
+	for _, start := range c.schedule(local) {
+		if start.At > sinceMidnight(local) {
+			break
+		}
+		phase = start.Phase
+	}
+	return phase
+}
+
+// NextClose returns the first time after t at which the market enters the closed phase,
+// or the zero time if none is scheduled in the coming weeks
+func (c *SessionCalendar) NextClose(t time.Time) time.Time {
+	local := t.In(c.Location)
+	for offset := 0; offset < 14; offset++ {
+		day := local.AddDate(0, 0, offset)
+		midnight := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, c.Location)
+		for _, start := range c.schedule(day) {
+			at := midnight.Add(start.At)
+			if start.Phase == PhaseClosed && at.After(t) {
+				return at
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// schedule returns the phases of the given local day, none for holidays
+func (c *SessionCalendar) schedule(local time.Time) []PhaseStart {
+	if c.Holidays[local.Format(time.DateOnly)] {
+		return nil
+	}
+	return c.Days[local.Weekday()]
+}
+
+func sinceMidnight(local time.Time) time.Duration {
+	h, m, s := local.Clock()
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second
+}
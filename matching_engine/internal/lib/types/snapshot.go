@@ -0,0 +1,60 @@
+package types
+
+import "time"
+
+// LevelSnapshot is the visible quantity and number of orders at one price
+type LevelSnapshot struct {
+	PriceCents int64
+	Quantity   int64
+	OrderCount int
+}
+
+// BookSnapshot is a point-in-time view of a stock's visible book, best prices first.
+// Hidden iceberg quantity and untriggered stop orders are not included.
+type BookSnapshot struct {
+	StockTicker         string
+	Bids                []LevelSnapshot
+	Asks                []LevelSnapshot
+	BestBidCents        int64 // 0 if there are no bids
+	BestAskCents        int64 // 0 if there are no asks
+	SpreadCents         int64 // 0 unless both sides have orders
+	LastTradePriceCents int64
+	Timestamp           time.Time
+}
+
+// Snapshot aggregates the book's best depth levels on each side, or every level if depth <= 0.
+// Must be called with the book lock held; a read lock is enough.
+func (b *StockOrderBook) Snapshot(depth int) BookSnapshot {
+	snapshot := BookSnapshot{
+		StockTicker:         b.stock,
+		Bids:                snapshotLevels(b.BuySide, depth),
+		Asks:                snapshotLevels(b.SellSide, depth),
+		LastTradePriceCents: b.LastTradePrice,
+		Timestamp:           time.Now(),
+	}
+	// Best prices come from the levels read: a ladder may tidy itself in GetBestPrice, which
+	// isn't safe under a read lock
+	if len(snapshot.Bids) > 0 {
+		snapshot.BestBidCents = snapshot.Bids[0].PriceCents
+	}
+	if len(snapshot.Asks) > 0 {
+		snapshot.BestAskCents = snapshot.Asks[0].PriceCents
+	}
+	if snapshot.BestBidCents > 0 && snapshot.BestAskCents > 0 {
+		snapshot.SpreadCents = snapshot.BestAskCents - snapshot.BestBidCents
+	}
+	return snapshot
+}
+
+func snapshotLevels(side *OrderBookSide, depth int) []LevelSnapshot {
+	levels := side.TopLevels(depth)
+	snapshots := make([]LevelSnapshot, 0, len(levels))
+	for _, level := range levels {
+		snapshots = append(snapshots, LevelSnapshot{
+			PriceCents: level.Price(),
+			Quantity:   level.Volume(),
+			OrderCount: level.Len(),
+		})
+	}
+	return snapshots
+}
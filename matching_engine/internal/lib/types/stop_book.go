@@ -0,0 +1,192 @@
+package types
+
+import "sort"
+
+// StopBook holds untriggered stop orders for a single stock.
+// Buy stops trigger when the last trade price rises to their trigger price,
+// sell stops trigger when it falls to theirs.
+// Trailing stops move their trigger with the last trade price, so they are kept
+// unsorted in arrival order and scanned on every trade.
+type StopBook struct {
+	buyStops  []*Order          // Sorted by trigger price ascending (nearest trigger first)
+	sellStops []*Order          // Sorted by trigger price descending (nearest trigger first)
+	trailing  []*Order          // Trailing stops of both sides in arrival order
+	orders    map[string]*Order // orderId -> order for O(1) lookup
+}
+
+// NewStopBook creates an empty stop book
+func NewStopBook() *StopBook {
+	return &StopBook{
+		buyStops:  make([]*Order, 0),
+		sellStops: make([]*Order, 0),
+		trailing:  make([]*Order, 0),
+		orders:    make(map[string]*Order),
+	}
+}
+
+// AddOrder holds a stop order until its trigger price is reached.
+// Orders with the same trigger price keep their arrival order.
+func (sb *StopBook) AddOrder(order *Order) {
+	if order.OrderType == TrailingStopOrder {
+		sb.trailing = append(sb.trailing, order)
+	} else if order.OrderSide == Buy {
+		i := sort.Search(len(sb.buyStops), func(i int) bool {
+			return sb.buyStops[i].TriggerPrice > order.TriggerPrice
+		})
+		sb.buyStops = insertOrderAt(sb.buyStops, i, order)
+	} else {
+		i := sort.Search(len(sb.sellStops), func(i int) bool {
+			return sb.sellStops[i].TriggerPrice < order.TriggerPrice
+		})
+		sb.sellStops = insertOrderAt(sb.sellStops, i, order)
+	}
+	sb.orders[order.OrderId] = order
+}
+
+// GetOrder returns a held stop order by ID
+func (sb *StopBook) GetOrder(orderId string) (*Order, bool) {
+	order, exists := sb.orders[orderId]
+	return order, exists
+}
+
+// OrdersOf returns the held stop orders placed by traderId, in no particular order
+func (sb *StopBook) OrdersOf(traderId int64) []*Order {
+	var orders []*Order
+	for _, order := range sb.orders {
+		if order.TraderId == traderId {
+			orders = append(orders, order)
+		}
+	}
+	return orders
+}
+
+// RemoveOrder removes a stop order by ID and returns the removed order.
+func (sb *StopBook) RemoveOrder(orderId string) (*Order, bool) {
+	order, exists := sb.orders[orderId]
+	if !exists {
+		return nil, false
+	}
+	delete(sb.orders, orderId)
+
+	if order.OrderType == TrailingStopOrder {
+		sb.trailing = removeOrderFrom(sb.trailing, orderId)
+	} else if order.OrderSide == Buy {
+		sb.buyStops = removeOrderFrom(sb.buyStops, orderId)
+	} else {
+		sb.sellStops = removeOrderFrom(sb.sellStops, orderId)
+	}
+	return order, true
+}
+
+// PopTriggered removes and returns every stop order whose trigger price has
+// been crossed by lastPrice, in the order they were triggered.
+func (sb *StopBook) PopTriggered(lastPrice int64) []*Order {
+	if lastPrice <= 0 {
+		return nil
+	}
+
+	var triggered []*Order
+
+	n := 0
+	for n < len(sb.buyStops) && sb.buyStops[n].TriggerPrice <= lastPrice {
+		n++
+	}
+	triggered = append(triggered, sb.buyStops[:n]...)
+	sb.buyStops = sb.buyStops[n:]
+
+	n = 0
+	for n < len(sb.sellStops) && sb.sellStops[n].TriggerPrice >= lastPrice {
+		n++
+	}
+	triggered = append(triggered, sb.sellStops[:n]...)
+	sb.sellStops = sb.sellStops[n:]
+
+	remaining := sb.trailing[:0]
+	for _, order := range sb.trailing {
+		if IsTriggeredBy(order, lastPrice) {
+			triggered = append(triggered, order)
+		} else {
+			remaining = append(remaining, order)
+		}
+	}
+	clear(sb.trailing[len(remaining):])
+	sb.trailing = remaining
+
+	for _, order := range triggered {
+		delete(sb.orders, order.OrderId)
+	}
+	return triggered
+}
+
+// Trail ratchets every trailing stop's trigger towards a trade at price and returns the
+// stops whose trigger moved. Sell triggers only ever rise and buy triggers only ever fall.
+func (sb *StopBook) Trail(price, tickSize int64) []*Order {
+	var moved []*Order
+	for _, order := range sb.trailing {
+		trigger := TrailingTrigger(order, price, tickSize)
+		if order.OrderSide == Sell {
+			trigger = max(order.TriggerPrice, trigger)
+		} else {
+			trigger = min(order.TriggerPrice, trigger)
+		}
+		if trigger != order.TriggerPrice {
+			order.TriggerPrice = trigger
+			moved = append(moved, order)
+		}
+	}
+	return moved
+}
+
+// TrailingTrigger returns the trigger price of a trailing stop that last saw a trade at price.
+// An offset in basis points is at least one tick, so a low price can't round it down to nothing.
+func TrailingTrigger(order *Order, price, tickSize int64) int64 {
+	offset := order.TrailingOffset
+	if offset == 0 {
+		offset = max(price*order.TrailingBps/10000, tickSize)
+	}
+	if order.OrderSide == Sell {
+		return price - offset
+	}
+	return price + offset
+}
+
+// IsTriggeredBy reports whether a stop order would be released at lastPrice
+func IsTriggeredBy(order *Order, lastPrice int64) bool {
+	if lastPrice <= 0 {
+		return false
+	}
+	if order.OrderSide == Buy {
+		return lastPrice >= order.TriggerPrice
+	}
+	return lastPrice <= order.TriggerPrice
+}
+
+// ordered returns the held stop orders in an order that AddOrder rebuilds the book from:
+// buy stops, then sell stops, each nearest trigger first, then trailing stops by arrival
+func (sb *StopBook) ordered() []*Order {
+	orders := make([]*Order, 0, len(sb.orders))
+	orders = append(orders, sb.buyStops...)
+	orders = append(orders, sb.sellStops...)
+	return append(orders, sb.trailing...)
+}
+
+// Len returns the number of held stop orders
+func (sb *StopBook) Len() int {
+	return len(sb.orders)
+}
+
+func insertOrderAt(orders []*Order, i int, order *Order) []*Order {
+	orders = append(orders, nil)
+	copy(orders[i+1:], orders[i:])
+	orders[i] = order
+	return orders
+}
+
+func removeOrderFrom(orders []*Order, orderId string) []*Order {
+	for i, o := range orders {
+		if o.OrderId == orderId {
+			return append(orders[:i], orders[i+1:]...)
+		}
+	}
+	return orders
+}
@@ -0,0 +1,219 @@
+// Package rebuild loads the open orders recorded in Postgres so the engine can put its books
+// back together at start-up without a local journal
+package rebuild
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/Marwan051/tradding_platform_game/matching_engine/internal/db/postgres/out"
+	matchingengine "github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/matching_engine"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var timesInForce = map[string]types.TimeInForce{
+	"GTC": types.GoodTillCancel,
+	"DAY": types.Day,
+	"GTD": types.GoodTillDate,
+	"IOC": types.ImmediateOrCancel,
+	"FOK": types.FillOrKill,
+}
+
+// LoadPostgres reads every PENDING or PARTIAL order from the orders table in the order it
+// joined its queue, with its remaining quantity, together with each stock's last and reference
+// price and the totals the books are reconciled against: the open quantity of each side of each
+// stock and the cash and shares held for each trader. Everything is read from one snapshot of
+// the database.
+func LoadPostgres(ctx context.Context, databaseURL string) ([]*types.Order, map[string]matchingengine.BookPrices, matchingengine.RebuildTotals, error) {
+	var totals matchingengine.RebuildTotals
+	conn, err := pgx.Connect(ctx, databaseURL)
+	if err != nil {
+		return nil, nil, totals, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer conn.Close(ctx)
+
+	tx, err := conn.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, nil, totals, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	queries := db.New(tx)
+
+	rows, err := queries.ListOpenOrders(ctx)
+	if err != nil {
+		return nil, nil, totals, fmt.Errorf("failed to list open orders: %w", err)
+	}
+	orders := make([]*types.Order, 0, len(rows))
+	for _, row := range rows {
+		order, err := toOrder(row)
+		if err != nil {
+			return nil, nil, totals, err
+		}
+		orders = append(orders, order)
+	}
+
+	prices, err := loadPrices(ctx, queries)
+	if err != nil {
+		return nil, nil, totals, err
+	}
+	totals, err = loadTotals(ctx, queries)
+	if err != nil {
+		return nil, nil, totals, err
+	}
+	return orders, prices, totals, tx.Commit(ctx)
+}
+
+// loadPrices reads each stock's current price, which the event listener moves on every trade,
+// and its previous close, which static price bands are measured from
+func loadPrices(ctx context.Context, queries *db.Queries) (map[string]matchingengine.BookPrices, error) {
+	rows, err := queries.ListStockPrices(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock prices: %w", err)
+	}
+	prices := make(map[string]matchingengine.BookPrices, len(rows))
+	for _, row := range rows {
+		prices[row.Ticker] = matchingengine.BookPrices{
+			LastTrade: row.CurrentPriceCents,
+			Reference: row.PreviousCloseCents.Int64,
+		}
+	}
+	return prices, nil
+}
+
+func loadTotals(ctx context.Context, queries *db.Queries) (matchingengine.RebuildTotals, error) {
+	totals := matchingengine.RebuildTotals{
+		Quantities: make(map[matchingengine.StockSide]int64),
+		CashHolds:  make(map[int64]int64),
+		ShareHolds: make(map[matchingengine.TraderStock]int64),
+	}
+
+	quantities, err := queries.SumOpenOrderQuantities(ctx)
+	if err != nil {
+		return totals, fmt.Errorf("failed to sum open orders: %w", err)
+	}
+	for _, row := range quantities {
+		side, err := toSide(row.Side)
+		if err != nil {
+			return totals, err
+		}
+		totals.Quantities[matchingengine.StockSide{Stock: row.StockTicker, Side: side}] = row.RemainingQuantity
+	}
+
+	cashHolds, err := queries.ListCashHolds(ctx)
+	if err != nil {
+		return totals, fmt.Errorf("failed to list cash holds: %w", err)
+	}
+	for _, row := range cashHolds {
+		totals.CashHolds[row.ID] = row.CashHoldCents.Int64
+	}
+
+	shareHolds, err := queries.ListShareHolds(ctx)
+	if err != nil {
+		return totals, fmt.Errorf("failed to list share holds: %w", err)
+	}
+	for _, row := range shareHolds {
+		totals.ShareHolds[matchingengine.TraderStock{TraderId: row.TraderID, Stock: row.StockTicker}] = row.QuantityHold.Int64
+	}
+	return totals, nil
+}
+
+func toOrder(row db.ListOpenOrdersRow) (*types.Order, error) {
+	id := uuid.UUID(row.ID.Bytes).String()
+	orderType, err := toOrderType(id, row)
+	if err != nil {
+		return nil, err
+	}
+	side, err := toSide(row.Side)
+	if err != nil {
+		return nil, err
+	}
+	timeInForce, ok := timesInForce[row.TimeInForce]
+	if !ok {
+		return nil, fmt.Errorf("order %s has unknown time in force %s", id, row.TimeInForce)
+	}
+	selfTrade := types.SelfTradeDefault
+	if row.SelfTradePrevention.Valid {
+		if selfTrade, ok = types.ParseSelfTradePrevention(row.SelfTradePrevention.String); !ok {
+			return nil, fmt.Errorf("order %s has unknown self-trade prevention mode %s", id, row.SelfTradePrevention.String)
+		}
+	}
+	// IOC and FOK orders never rest, so an open one is a bookkeeping error; held stops keep
+	// theirs for when they trigger
+	if orderType == types.LimitOrder && (timeInForce == types.ImmediateOrCancel || timeInForce == types.FillOrKill) {
+		return nil, fmt.Errorf("order %s has time in force %s, which cannot rest on the book", id, row.TimeInForce)
+	}
+
+	order := &types.Order{
+		OrderId:         id,
+		TraderId:        row.TraderID,
+		OwnerTraderId:   row.OwnerTraderID.Int64,
+		StockTicker:     row.StockTicker,
+		OrderType:       orderType,
+		OrderSide:       side,
+		Quantity:        row.RemainingQuantity,
+		LimitPrice:      row.LimitPriceCents.Int64,
+		TriggerPrice:    row.TriggerPriceCents.Int64,
+		TrailingOffset:  row.TrailingOffsetCents.Int64,
+		TrailingBps:     row.TrailingOffsetBps.Int64,
+		TimeInForce:     timeInForce,
+		ClientOrderId:   row.ClientOrderID.String,
+		SelfTrade:       selfTrade,
+		PostOnly:        row.PostOnly,
+		PostOnlyReprice: row.PostOnlyReprice,
+		Timestamp:       row.QueuedAt.Time,
+	}
+	if orderType == types.LimitOrder {
+		order.TriggerPrice, order.TrailingOffset, order.TrailingBps = 0, 0, 0
+	}
+	// Stop-market and trailing-stop buys spend the cash reserved for them when they trigger
+	if side == types.Buy && (orderType == types.StopMarketOrder || orderType == types.TrailingStopOrder) {
+		order.AvailableBalance = row.CashReservedCents
+	}
+	if row.ExpiresAt.Valid {
+		order.ExpireAt = row.ExpiresAt.Time
+	}
+	if row.DisplayQuantity.Valid {
+		order.DisplayQuantity = row.DisplayQuantity.Int64
+	}
+	return order, nil
+}
+
+// toOrderType maps an open order row to the type it has in the engine. A triggered stop-limit
+// order rests on the book as a limit order. A triggered stop-market or trailing-stop order and
+// a market order never stay open, so one of them means the event listener has not caught up.
+func toOrderType(id string, row db.ListOpenOrdersRow) (types.OrderType, error) {
+	triggered := row.TriggeredAt.Valid
+	switch row.OrderType {
+	case "LIMIT":
+		return types.LimitOrder, nil
+	case "STOP_LIMIT":
+		if triggered {
+			return types.LimitOrder, nil
+		}
+		return types.StopLimitOrder, nil
+	case "STOP_MARKET":
+		if !triggered {
+			return types.StopMarketOrder, nil
+		}
+	case "TRAILING_STOP":
+		if !triggered {
+			return types.TrailingStopOrder, nil
+		}
+	case "MARKET":
+	default:
+		return 0, fmt.Errorf("order %s has unknown order type %s", id, row.OrderType)
+	}
+	return 0, fmt.Errorf("%s order %s is still open after it could have traded", row.OrderType, id)
+}
+
+func toSide(side string) (types.OrderSide, error) {
+	switch side {
+	case "BUY":
+		return types.Buy, nil
+	case "SELL":
+		return types.Sell, nil
+	}
+	return 0, fmt.Errorf("unknown order side %s", side)
+}
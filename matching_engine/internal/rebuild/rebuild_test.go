@@ -0,0 +1,100 @@
+package rebuild
+
+import (
+	"testing"
+	"time"
+
+	db "github.com/Marwan051/tradding_platform_game/matching_engine/internal/db/postgres/out"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func openOrderRow() db.ListOpenOrdersRow {
+	created := time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC)
+	return db.ListOpenOrdersRow{
+		ID:                pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		TraderID:          1,
+		StockTicker:       "AAPL",
+		OrderType:         "LIMIT",
+		Side:              "BUY",
+		RemainingQuantity: 10,
+		LimitPriceCents:   pgtype.Int8{Int64: 15000, Valid: true},
+		TimeInForce:       "GTC",
+		CreatedAt:         pgtype.Timestamptz{Time: created, Valid: true},
+		QueuedAt:          pgtype.Timestamptz{Time: created, Valid: true},
+	}
+}
+
+func TestToOrder(t *testing.T) {
+	t.Run("should keep the order's own flags", func(t *testing.T) {
+		row := openOrderRow()
+		row.ClientOrderID = pgtype.Text{String: "my-order-1", Valid: true}
+		row.SelfTradePrevention = pgtype.Text{String: "CANCEL_OLDEST", Valid: true}
+		row.PostOnly = true
+		row.PostOnlyReprice = true
+
+		order, err := toOrder(row)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if order.ClientOrderId != "my-order-1" {
+			t.Errorf("expected client order ID my-order-1, got %q", order.ClientOrderId)
+		}
+		if order.SelfTrade != types.SelfTradeCancelOldest {
+			t.Errorf("expected CANCEL_OLDEST, got %v", order.SelfTrade)
+		}
+		if !order.PostOnly || !order.PostOnlyReprice {
+			t.Error("expected the post-only flags to be kept")
+		}
+	})
+
+	t.Run("should use the engine's self-trade prevention mode when the order has none", func(t *testing.T) {
+		order, err := toOrder(openOrderRow())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if order.SelfTrade != types.SelfTradeDefault {
+			t.Errorf("expected the engine default, got %v", order.SelfTrade)
+		}
+	})
+
+	t.Run("should refuse an unknown self-trade prevention mode", func(t *testing.T) {
+		row := openOrderRow()
+		row.SelfTradePrevention = pgtype.Text{String: "SOMETIMES", Valid: true}
+		if _, err := toOrder(row); err == nil {
+			t.Error("expected an error")
+		}
+	})
+
+	t.Run("should take time priority from when the order last joined its queue", func(t *testing.T) {
+		row := openOrderRow()
+		amended := row.CreatedAt.Time.Add(time.Hour)
+		row.QueuedAt = pgtype.Timestamptz{Time: amended, Valid: true}
+
+		order, err := toOrder(row)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !order.Timestamp.Equal(amended) {
+			t.Errorf("expected the re-queued time %v, got %v", amended, order.Timestamp)
+		}
+	})
+
+	t.Run("should hold a trailing stop at the trigger it last moved to", func(t *testing.T) {
+		row := openOrderRow()
+		row.OrderType = "TRAILING_STOP"
+		row.Side = "SELL"
+		row.LimitPriceCents = pgtype.Int8{}
+		row.TriggerPriceCents = pgtype.Int8{Int64: 10500, Valid: true}
+		row.TrailingOffsetCents = pgtype.Int8{Int64: 500, Valid: true}
+
+		order, err := toOrder(row)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if order.OrderType != types.TrailingStopOrder || order.TriggerPrice != 10500 {
+			t.Errorf("expected a trailing stop triggering at 10500, got %v at %d", order.OrderType, order.TriggerPrice)
+		}
+	})
+}
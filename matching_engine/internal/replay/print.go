@@ -0,0 +1,104 @@
+package replay
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+// PrintBook writes a book's levels best price first, with the orders queued at each level in
+// time priority, followed by the held stop orders
+func PrintBook(w io.Writer, book *types.StockOrderBook) {
+	state := book.State()
+	fmt.Fprintf(w, "%s  last trade %d  reference %d", state.Stock, state.LastTradePrice, state.ReferencePrice)
+	if state.InAuction {
+		fmt.Fprint(w, "  in auction")
+	}
+	if !state.HaltedUntil.IsZero() {
+		fmt.Fprintf(w, "  halted until %s", state.HaltedUntil.Format("15:04:05"))
+	}
+	fmt.Fprintln(w)
+
+	// Asks are printed worst first so the spread sits in the middle
+	fmt.Fprintln(w, "ASKS")
+	for i := len(state.Asks) - 1; i >= 0; i-- {
+		printLevel(w, state.Asks[i])
+	}
+	fmt.Fprintln(w, "BIDS")
+	for _, level := range state.Bids {
+		printLevel(w, level)
+	}
+	if len(state.Stops) > 0 {
+		fmt.Fprintln(w, "STOPS")
+		for _, order := range state.Stops {
+			fmt.Fprintf(w, "  %-36s  trader %-6d  %-4s  qty %-6d  trigger %d\n",
+				order.OrderId, order.TraderId, sideName(order.OrderSide), order.Quantity, order.TriggerPrice)
+		}
+	}
+}
+
+func printLevel(w io.Writer, level types.LevelState) {
+	var visible, total int64
+	for _, order := range level.Orders {
+		visible += visibleQuantity(order)
+		total += order.Quantity
+	}
+	fmt.Fprintf(w, "  %8d  qty %-6d", level.PriceCents, visible)
+	if total != visible {
+		fmt.Fprintf(w, " (%d with hidden)", total)
+	}
+	fmt.Fprintf(w, "  %d orders\n", len(level.Orders))
+	for _, order := range level.Orders {
+		fmt.Fprintf(w, "            %-36s  trader %-6d  qty %d", order.OrderId, order.TraderId, order.Quantity)
+		if order.DisplayQuantity > 0 {
+			fmt.Fprintf(w, "  showing %d", visibleQuantity(order))
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// Diff compares two snapshots of a book level by level and describes each difference.
+// Returns nothing if they match.
+func Diff(replayed, live types.BookSnapshot) []string {
+	var diffs []string
+	if replayed.LastTradePriceCents != live.LastTradePriceCents {
+		diffs = append(diffs, fmt.Sprintf("last trade price: replayed %d, live %d", replayed.LastTradePriceCents, live.LastTradePriceCents))
+	}
+	diffs = append(diffs, diffLevels("bid", replayed.Bids, live.Bids)...)
+	return append(diffs, diffLevels("ask", replayed.Asks, live.Asks)...)
+}
+
+func diffLevels(name string, replayed, live []types.LevelSnapshot) []string {
+	var diffs []string
+	for i := 0; i < max(len(replayed), len(live)); i++ {
+		switch {
+		case i >= len(replayed):
+			diffs = append(diffs, fmt.Sprintf("%s level %d: missing from replay, live %s", name, i+1, describeLevel(live[i])))
+		case i >= len(live):
+			diffs = append(diffs, fmt.Sprintf("%s level %d: replayed %s, missing from live", name, i+1, describeLevel(replayed[i])))
+		case replayed[i] != live[i]:
+			diffs = append(diffs, fmt.Sprintf("%s level %d: replayed %s, live %s", name, i+1, describeLevel(replayed[i]), describeLevel(live[i])))
+		}
+	}
+	return diffs
+}
+
+func describeLevel(level types.LevelSnapshot) string {
+	return fmt.Sprintf("%d x %d (%d orders)", level.PriceCents, level.Quantity, level.OrderCount)
+}
+
+// visibleQuantity is the part of a saved order showing on the book
+func visibleQuantity(order types.SavedOrder) int64 {
+	if order.DisplayQuantity > 0 {
+		return order.Displayed
+	}
+	return order.Quantity
+}
+
+func sideName(side types.OrderSide) string {
+	if side == types.Sell {
+		return "SELL"
+	}
+	return "BUY"
+}
@@ -0,0 +1,292 @@
+// Package replay rebuilds order books from the engine's event stream, so a disputed fill can be
+// reproduced step by step. Events are applied through the same types.StockOrderBook operations
+// the engine matches with: orders rest, fill, record trades and go through auctions and halts
+// exactly as in production, so queue positions and iceberg slices come out the same.
+package replay
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+// Replayer applies events to a set of books. An order that was just placed, amended with a
+// requeue or triggered is held aside while its fills arrive, as the engine matches it before it
+// rests; it joins the book once the next event about something else arrives.
+type Replayer struct {
+	books    map[string]*types.StockOrderBook
+	incoming map[string]*types.Order // stock symbol -> order still matching
+	phase    types.SessionPhase
+	applied  int
+}
+
+// New creates a replayer with no books, in continuous trading
+func New() *Replayer {
+	return &Replayer{
+		books:    make(map[string]*types.StockOrderBook),
+		incoming: make(map[string]*types.Order),
+		phase:    types.PhaseContinuous,
+	}
+}
+
+// Applied returns the number of events applied so far
+func (r *Replayer) Applied() int {
+	return r.applied
+}
+
+// Stocks returns the tickers of every book seen so far, in order
+func (r *Replayer) Stocks() []string {
+	stocks := make([]string, 0, len(r.books))
+	for stock := range r.books {
+		stocks = append(stocks, stock)
+	}
+	sort.Strings(stocks)
+	return stocks
+}
+
+// Book returns a stock's book as it stands after the events applied so far, or nil if the stock
+// has not been seen
+func (r *Replayer) Book(stock string) *types.StockOrderBook {
+	r.rest(stock)
+	return r.books[stock]
+}
+
+// Apply applies one event. An error means the event does not fit the books, for example a fill
+// of an order that is not resting; the books are left as they were before the event.
+func (r *Replayer) Apply(evt types.Event) error {
+	if err := r.apply(evt); err != nil {
+		return fmt.Errorf("event %s (sequence %d): %w", evt.EventID, evt.Sequence, err)
+	}
+	r.applied++
+	return nil
+}
+
+func (r *Replayer) apply(evt types.Event) error {
+	switch evt.Type {
+	case types.OrderPlaced:
+		var e types.OrderPlacedEvent
+		if err := json.Unmarshal(evt.Data, &e); err != nil {
+			return err
+		}
+		book := r.book(e.StockTicker)
+		r.rest(e.StockTicker)
+		order := &types.Order{
+			OrderId:         e.OrderID,
+			TraderId:        e.TraderID,
+			StockTicker:     e.StockTicker,
+			OrderType:       e.OrderType,
+			OrderSide:       e.OrderSide,
+			Quantity:        e.Quantity,
+			LimitPrice:      e.LimitPriceCents,
+			TriggerPrice:    e.TriggerPriceCents,
+			TimeInForce:     e.TimeInForce,
+			ExpireAt:        e.ExpiresAt,
+			DisplayQuantity: e.DisplayQuantity,
+			TrailingOffset:  e.TrailingOffset,
+			TrailingBps:     e.TrailingBps,
+			ClientOrderId:   e.ClientOrderID,
+			Timestamp:       evt.Timestamp,
+		}
+		// Stop orders wait off-book; one that triggers at once is followed by OrderTriggered
+		if order.OrderType.IsStop() {
+			book.Stops.AddOrder(order)
+			return nil
+		}
+		r.incoming[e.StockTicker] = order
+
+	case types.OrderTriggered:
+		var e types.OrderTriggeredEvent
+		if err := json.Unmarshal(evt.Data, &e); err != nil {
+			return err
+		}
+		book := r.book(e.StockTicker)
+		order, found := book.Stops.GetOrder(e.OrderID)
+		if !found {
+			return fmt.Errorf("triggered order %s is not held", e.OrderID)
+		}
+		r.rest(e.StockTicker)
+		book.Stops.RemoveOrder(e.OrderID)
+		order.OrderType = e.OrderType
+		r.incoming[e.StockTicker] = order
+
+	case types.TradeExecuted:
+		var e types.TradeExecutedEvent
+		if err := json.Unmarshal(evt.Data, &e); err != nil {
+			return err
+		}
+		book := r.book(e.StockTicker)
+		incoming := r.incoming[e.StockTicker]
+		// Check both orders before changing either
+		if incoming == nil || incoming.OrderId != e.BuyerOrderID {
+			if err := checkFill(book.BuySide, e.BuyerOrderID, e.Quantity); err != nil {
+				return err
+			}
+		}
+		if incoming == nil || incoming.OrderId != e.SellerOrderID {
+			if err := checkFill(book.SellSide, e.SellerOrderID, e.Quantity); err != nil {
+				return err
+			}
+		}
+		r.fill(book.BuySide, incoming, e.BuyerOrderID, e.Quantity)
+		r.fill(book.SellSide, incoming, e.SellerOrderID, e.Quantity)
+		book.RecordTrade(e.PriceCents)
+
+	case types.SelfTradePrevented:
+		var e types.SelfTradePreventedEvent
+		if err := json.Unmarshal(evt.Data, &e); err != nil {
+			return err
+		}
+		book := r.book(e.StockTicker)
+		incoming := r.incoming[e.StockTicker]
+		if e.Mode != types.SelfTradeDecrement || incoming == nil || incoming.OrderId != e.IncomingOrderID {
+			return nil // The cancellations and amendments that follow do the rest
+		}
+		resting, found := book.Side(incoming.OrderSide.Opposite()).GetOrder(e.RestingOrderID)
+		if !found {
+			return fmt.Errorf("self-trade against order %s, which is not resting", e.RestingOrderID)
+		}
+		incoming.Quantity -= min(incoming.Quantity, resting.Quantity)
+
+	case types.OrderCancelled:
+		var e types.OrderCancelledEvent
+		if err := json.Unmarshal(evt.Data, &e); err != nil {
+			return err
+		}
+		book := r.book(e.StockTicker)
+		if incoming := r.incoming[e.StockTicker]; incoming != nil && incoming.OrderId == e.OrderID {
+			delete(r.incoming, e.StockTicker)
+			return nil
+		}
+		if _, removed := book.Side(e.OrderSide).RemoveOrder(e.OrderID); removed {
+			return nil
+		}
+		if _, removed := book.Stops.RemoveOrder(e.OrderID); !removed {
+			return fmt.Errorf("cancelled order %s is not on the book", e.OrderID)
+		}
+
+	case types.OrderAmended:
+		var e types.OrderAmendedEvent
+		if err := json.Unmarshal(evt.Data, &e); err != nil {
+			return err
+		}
+		book := r.book(e.StockTicker)
+		// An order amended right after it was placed has finished matching; a resting order
+		// amended by self-trade prevention is being matched against
+		if incoming := r.incoming[e.StockTicker]; incoming != nil && incoming.OrderId == e.OrderID {
+			r.rest(e.StockTicker)
+		}
+		bookSide := book.Side(e.OrderSide)
+		order, found := bookSide.GetOrder(e.OrderID)
+		if stop, held := book.Stops.GetOrder(e.OrderID); !found && held {
+			// Held stops are amended where they wait
+			stop.Quantity = e.NewQuantity
+			stop.LimitPrice = e.NewLimitPriceCents
+			return nil
+		}
+		if !found {
+			return fmt.Errorf("amended order %s is not resting", e.OrderID)
+		}
+		if !e.Requeued {
+			bookSide.ReduceOrder(e.OrderID, e.NewQuantity)
+			return nil
+		}
+		// A requeued order goes to the back of its new level after matching
+		bookSide.RemoveOrder(e.OrderID)
+		order.Quantity = e.NewQuantity
+		order.LimitPrice = e.NewLimitPriceCents
+		order.Timestamp = evt.Timestamp
+		r.incoming[e.StockTicker] = order
+
+	case types.AuctionUncrossed:
+		var e types.AuctionUncrossedEvent
+		if err := json.Unmarshal(evt.Data, &e); err != nil {
+			return err
+		}
+		book := r.book(e.StockTicker)
+		r.rest(e.StockTicker)
+		book.EndAuction(e.PriceCents, e.Volume)
+
+	case types.TradingHalted:
+		var e types.TradingHaltedEvent
+		if err := json.Unmarshal(evt.Data, &e); err != nil {
+			return err
+		}
+		book := r.book(e.StockTicker)
+		book.Halt(e.HaltedUntil)
+
+	case types.TradingResumed:
+		var e types.TradingResumedEvent
+		if err := json.Unmarshal(evt.Data, &e); err != nil {
+			return err
+		}
+		book := r.book(e.StockTicker)
+		book.HaltedUntil = time.Time{}
+		book.InAuction = r.phase.CollectsOrders()
+
+	case types.SessionPhaseChanged:
+		var e types.SessionPhaseChangedEvent
+		if err := json.Unmarshal(evt.Data, &e); err != nil {
+			return err
+		}
+		r.phase = e.Phase
+		for stock, book := range r.books {
+			r.rest(stock)
+			if book.HaltedUntil.IsZero() {
+				book.InAuction = e.Phase.CollectsOrders()
+			}
+		}
+
+	default:
+		// Fill notices repeat what TradeExecuted says, trailing stops follow the trades on their
+		// own and rejections never touch a book
+	}
+	return nil
+}
+
+// book returns a stock's book, opening it in the current session phase
+func (r *Replayer) book(stock string) *types.StockOrderBook {
+	book, exists := r.books[stock]
+	if !exists {
+		book = types.NewStockOrderBook(stock)
+		book.InAuction = r.phase.CollectsOrders()
+		r.books[stock] = book
+	}
+	return book
+}
+
+// rest puts what is left of a stock's incoming order on the book, unless it cannot rest
+func (r *Replayer) rest(stock string) {
+	order := r.incoming[stock]
+	if order == nil {
+		return
+	}
+	delete(r.incoming, stock)
+	if order.Quantity > 0 && order.RestsOnBook() {
+		r.books[stock].Rest(order)
+	}
+}
+
+// fill takes qty off the incoming order, or off the resting order on bookSide
+func (r *Replayer) fill(bookSide *types.OrderBookSide, incoming *types.Order, orderId string, qty int64) {
+	if incoming != nil && incoming.OrderId == orderId {
+		incoming.Quantity -= qty
+		return
+	}
+	order, _ := bookSide.GetOrder(orderId)
+	bookSide.FillOrder(order, qty)
+}
+
+// checkFill reports whether a resting order can be filled by qty
+func checkFill(bookSide *types.OrderBookSide, orderId string, qty int64) error {
+	order, found := bookSide.GetOrder(orderId)
+	if !found {
+		return fmt.Errorf("filled order %s is not resting", orderId)
+	}
+	if qty > order.VisibleQuantity() {
+		return fmt.Errorf("fill of %d exceeds the %d visible on order %s", qty, order.VisibleQuantity(), orderId)
+	}
+	return nil
+}
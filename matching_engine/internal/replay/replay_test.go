@@ -0,0 +1,124 @@
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/events"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/events/streaming_client/clients"
+	matchingengine "github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/matching_engine"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+// envelopeStreamer keeps every published event as it reaches the stream
+type envelopeStreamer struct {
+	clients.TestStreamingClient
+	mu     sync.Mutex
+	events []types.Event
+}
+
+func (s *envelopeStreamer) Publish(ctx context.Context, eventData any, eventType types.EventType, seq types.EventSequence) error {
+	data, err := events.MarshalEvent(eventData, eventType, seq)
+	if err != nil {
+		return err
+	}
+	var evt types.Event
+	if err := json.Unmarshal(data, &evt); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, evt)
+	return nil
+}
+
+// Helper to create a limit order on AAPL for a trader
+func newOrder(id string, traderId int64, side types.OrderSide, qty, price int64) *types.Order {
+	return &types.Order{
+		OrderId:     id,
+		TraderId:    traderId,
+		StockTicker: "AAPL",
+		OrderType:   types.LimitOrder,
+		OrderSide:   side,
+		Quantity:    qty,
+		LimitPrice:  price,
+		Timestamp:   time.Now(),
+	}
+}
+
+// queueOrder lists a book's resting orders level by level with their quantities
+func queueOrder(state types.BookState) string {
+	var out string
+	for _, levels := range [][]types.LevelState{state.Bids, state.Asks} {
+		for _, level := range levels {
+			out += fmt.Sprintf("%d:", level.PriceCents)
+			for _, order := range level.Orders {
+				out += fmt.Sprintf(" %s/%d/%d", order.OrderId, order.Quantity, order.Displayed)
+			}
+			out += "\n"
+		}
+	}
+	for _, order := range state.Stops {
+		out += fmt.Sprintf("stop %s/%d\n", order.OrderId, order.Quantity)
+	}
+	return out
+}
+
+func TestReplay(t *testing.T) {
+	t.Run("should rebuild the book the engine published", func(t *testing.T) {
+		streamer := &envelopeStreamer{}
+		engine := matchingengine.NewMatchingEngine(streamer, matchingengine.WithSelfTradePrevention(types.SelfTradeDecrement))
+		iceberg := newOrder("sell1", 1, types.Sell, 30, 15000)
+		iceberg.DisplayQuantity = 10
+		engine.SubmitOrder(iceberg)
+		engine.SubmitOrder(newOrder("sell2", 2, types.Sell, 10, 15000))
+		engine.SubmitOrder(newOrder("sell3", 3, types.Sell, 5, 15100))
+		engine.SubmitOrder(newOrder("buy1", 4, types.Buy, 12, 15000))
+		stop := newOrder("stop1", 5, types.Sell, 3, 0)
+		stop.OrderType = types.StopMarketOrder
+		stop.TriggerPrice = 14900
+		engine.SubmitOrder(stop)
+		engine.AmendOrder("stop1", 5, 2, 0, 0, 0)
+		engine.SubmitOrder(newOrder("buy2", 6, types.Buy, 8, 14900))
+		engine.AmendOrder("buy2", 6, 8, 14950, 0, 0)
+		engine.SubmitOrder(newOrder("buy3", 2, types.Buy, 5, 15000))
+		engine.CancelOrder("sell3", 3)
+		engine.SubmitOrder(newOrder("sell4", 7, types.Sell, 20, 14900))
+		engine.SubmitOrder(newOrder("buy4", 8, types.Buy, 2, 14900))
+
+		replayer := New()
+		for _, evt := range streamer.events {
+			if err := replayer.Apply(evt); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if replayer.Applied() != len(streamer.events) {
+			t.Errorf("expected %d events applied, got %d", len(streamer.events), replayer.Applied())
+		}
+
+		books := engine.State().Books
+		replayed := replayer.Book("AAPL")
+		if len(books) != 1 || replayed == nil {
+			t.Fatalf("expected one AAPL book, got %d books", len(books))
+		}
+		if want, got := queueOrder(books[0]), queueOrder(replayed.State()); want != got {
+			t.Errorf("expected queues\n%s\ngot\n%s", want, got)
+		}
+		want, _ := engine.Snapshot("AAPL", 0)
+		if diffs := Diff(replayed.Snapshot(0), want); len(diffs) > 0 {
+			t.Errorf("unexpected differences %v", diffs)
+		}
+	})
+
+	t.Run("should report a fill of an order that is not resting", func(t *testing.T) {
+		data, _ := json.Marshal(types.TradeExecutedEvent{StockTicker: "AAPL", BuyerOrderID: "buy1", SellerOrderID: "sell1", Quantity: 1, PriceCents: 15000})
+		err := New().Apply(types.Event{EventID: "evt1", Type: types.TradeExecuted, Data: data})
+		if err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
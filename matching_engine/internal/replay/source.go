@@ -0,0 +1,67 @@
+package replay
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+	glide "github.com/valkey-io/valkey-glide/go/v2"
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+const streamPageSize = 1000
+
+// ReadJSONL calls fn for each event envelope in r, one JSON envelope per line as the engine
+// writes them to the stream's data field. Blank lines are skipped.
+func ReadJSONL(r io.Reader, fn func(types.Event) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16<<20)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var evt types.Event
+		if err := json.Unmarshal(scanner.Bytes(), &evt); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		if err := fn(evt); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// ReadStream calls fn for each event in a Valkey stream between two entry IDs, both inclusive.
+// Use "-" and "+" for the start and end of the stream.
+func ReadStream(ctx context.Context, client *glide.Client, stream, from, to string, fn func(types.Event) error) error {
+	start := options.StreamBoundary(from)
+	for {
+		entries, err := client.XRangeWithOptions(ctx, stream, start, options.StreamBoundary(to), *options.NewXRangeOptions().SetCount(streamPageSize))
+		if err != nil {
+			return fmt.Errorf("failed to read stream: %w", err)
+		}
+		for _, entry := range entries {
+			for _, field := range entry.Fields {
+				if field.Field != "data" {
+					continue
+				}
+				var evt types.Event
+				if err := json.Unmarshal([]byte(field.Value), &evt); err != nil {
+					return fmt.Errorf("stream entry %s: %w", entry.ID, err)
+				}
+				if err := fn(evt); err != nil {
+					return err
+				}
+			}
+		}
+		if len(entries) < streamPageSize {
+			return nil
+		}
+		start = options.NewStreamBoundary(entries[len(entries)-1].ID, false)
+	}
+}
@@ -2,17 +2,26 @@ package server
 
 import (
 	"context"
+	"log"
 	"log/slog"
 	"net"
+	"strings"
 	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 
 	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/config"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/instruments"
 	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/interceptors"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/journal"
 	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/events/streaming_client/clients"
+	matchingengine "github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/matching_engine"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/rebuild"
 	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/service"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/session"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/snapshots"
 	pb "github.com/Marwan051/tradding_platform_game/proto/gen/go/v1/matching_engine"
 )
 
@@ -21,6 +30,7 @@ type Server struct {
 	listener          net.Listener
 	logger            *slog.Logger
 	matchingEngineSVC *service.MatchingEngineService
+	journal           *journal.Journal
 	cfg               *config.Config
 }
 
@@ -33,14 +43,73 @@ func New(cfg *config.Config, logger *slog.Logger) *Server {
 		),
 	)
 
+	// Rebuilding from the database replaces local recovery; doing both would rest orders twice
+	if cfg.RebuildFromDatabase && (cfg.DatabaseURL == "" || cfg.JournalDir != "" || cfg.SnapshotDir != "") {
+		log.Fatal("REBUILD_FROM_DATABASE needs DATABASE_URL and cannot be combined with JOURNAL_DIR or SNAPSHOT_DIR")
+	}
+
+	// Without a journal directory the books are lost on restart
+	opts := engineOptions(cfg, logger)
+	var commandJournal *journal.Journal
+	if cfg.JournalDir != "" {
+		var err error
+		commandJournal, err = journal.Open(cfg.JournalDir, journal.Options{SegmentSize: int64(cfg.JournalSegmentBytes)})
+		if err != nil {
+			log.Fatalf("Could not open command journal with error: %s", err)
+		}
+		opts = append(opts, matchingengine.WithJournal(commandJournal))
+		logger.Info("opened command journal", "dir", cfg.JournalDir, "last_sequence", commandJournal.LastSequence())
+	}
+
+	// Snapshots let a restart skip the journal up to the latest of them
+	if cfg.SnapshotDir != "" {
+		store, err := snapshots.Open(cfg.SnapshotDir, snapshots.Options{Keep: cfg.SnapshotKeep})
+		if err != nil {
+			log.Fatalf("Could not open snapshot directory with error: %s", err)
+		}
+		opts = append(opts, matchingengine.WithSnapshots(store))
+	}
+
 	// Register services
 	matchingService := service.NewMatchingEngineService(logger, clients.ValkeyOptions{
 		ValkeyHost:             cfg.ValkeyHost,
 		ValkeyPort:             cfg.ValkeyPort,
 		ValkeyStreamName:       cfg.ValkeyStreamName,
 		ValkeyRequestTimeoutMs: cfg.ValkeyRequestTimeout,
-	})
+	}, opts...)
+	// The listener only opens once New returns, so no orders arrive before the books are rebuilt
+	if cfg.RebuildFromDatabase {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		orders, prices, totals, err := rebuild.LoadPostgres(ctx, cfg.DatabaseURL)
+		cancel()
+		if err != nil {
+			log.Fatalf("Could not load open orders with error: %s", err)
+		}
+		if err := matchingService.Rebuild(orders, prices, totals); err != nil {
+			log.Fatalf("Could not rebuild order books with error: %s", err)
+		}
+	}
+	// Static price bands are measured from the previous close until an auction moves them; a
+	// rebuild has already loaded it with the open orders
+	if cfg.DatabaseURL != "" && !cfg.RebuildFromDatabase {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		closes, err := instruments.LoadPreviousCloses(ctx, cfg.DatabaseURL)
+		cancel()
+		if err != nil {
+			log.Fatalf("Could not load previous closes with error: %s", err)
+		}
+		matchingService.SetReferencePrices(closes)
+	}
+	// Retried requests with the same client order ID get the original response within the window
+	if cfg.ClientOrderIDWindow > 0 {
+		matchingService.DeduplicateOrders(cfg.ClientOrderIDWindow)
+	}
+	// Sessions and expiries only run on the books once they are back
+	matchingService.Start()
 	pb.RegisterMatchingEngineServer(grpcServer, matchingService)
+	if cfg.SnapshotDir != "" && cfg.SnapshotInterval > 0 {
+		matchingService.StartSnapshots(cfg.SnapshotInterval)
+	}
 
 	// Enable reflection for development (grpcurl, grpcui)
 	if cfg.Environment == "development" {
@@ -52,9 +121,79 @@ func New(cfg *config.Config, logger *slog.Logger) *Server {
 		logger:            logger,
 		cfg:               cfg,
 		matchingEngineSVC: matchingService,
+		journal:           commandJournal,
 	}
 }
 
+// engineOptions translates configuration into matching engine options
+func engineOptions(cfg *config.Config, logger *slog.Logger) []matchingengine.Option {
+	selfTrade, ok := types.ParseSelfTradePrevention(cfg.SelfTradePrevention)
+	if !ok {
+		logger.Warn("unknown self-trade prevention mode, using ALLOW", "mode", cfg.SelfTradePrevention)
+		selfTrade = types.SelfTradeAllow
+	}
+	opts := []matchingengine.Option{
+		matchingengine.WithSelfTradePrevention(selfTrade),
+		matchingengine.WithOwnerGroupSelfTrade(cfg.SelfTradeOwnerGroups),
+		matchingengine.WithPriceBands(types.PriceBands{
+			StaticBps:    int64(cfg.PriceBandStaticBps),
+			DynamicBps:   int64(cfg.PriceBandDynamicBps),
+			HaltDuration: cfg.HaltDuration,
+		}),
+		matchingengine.WithStopReserveSlippage(int64(cfg.StopReserveSlippage)),
+		matchingengine.WithSequencers(cfg.SequencerQueueSize),
+	}
+
+	// MATCHING_POLICIES lists per-stock policies, e.g. "AAPL=PRO_RATA,MSFT=PRO_RATA_TOP"
+	for _, entry := range strings.Split(cfg.MatchingPolicies, ",") {
+		stock, name, found := strings.Cut(strings.TrimSpace(entry), "=")
+		if !found {
+			continue
+		}
+		policy, ok := types.ParseMatchingPolicy(strings.TrimSpace(name))
+		if !ok {
+			logger.Warn("unknown matching policy, using FIFO", "stock", stock, "policy", name)
+			continue
+		}
+		opts = append(opts, matchingengine.WithMatchingPolicy(strings.TrimSpace(stock), policy))
+	}
+	// Instruments come from the instruments file if set, otherwise from the stocks table
+	instrumentList, err := loadInstruments(cfg)
+	if err != nil {
+		log.Fatalf("Could not load instruments with error: %s", err)
+	}
+	if instrumentList != nil {
+		opts = append(opts, matchingengine.WithInstruments(instrumentList))
+		logger.Info("loaded instruments", "count", len(instrumentList))
+	} else {
+		logger.Warn("no instrument source configured, accepting orders for any ticker")
+	}
+	// Without a session calendar the market never closes
+	if cfg.SessionCalendarFile != "" {
+		calendar, err := session.LoadFile(cfg.SessionCalendarFile)
+		if err != nil {
+			log.Fatalf("Could not load session calendar with error: %s", err)
+		}
+		opts = append(opts, matchingengine.WithSessionCalendar(calendar))
+		logger.Info("loaded session calendar", "time_zone", calendar.Location.String())
+	}
+
+	return opts
+}
+
+// loadInstruments reads the instrument list from the configured source, if any
+func loadInstruments(cfg *config.Config) ([]types.Instrument, error) {
+	if cfg.InstrumentsFile != "" {
+		return instruments.LoadFile(cfg.InstrumentsFile)
+	}
+	if cfg.DatabaseURL != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return instruments.LoadPostgres(ctx, cfg.DatabaseURL)
+	}
+	return nil, nil
+}
+
 func (s *Server) Start() error {
 	listener, err := net.Listen("tcp", s.cfg.GRPCAddr)
 	if err != nil {
@@ -90,4 +229,11 @@ func (s *Server) Shutdown(ctx context.Context) {
 			s.logger.Warn("matching engine service close returned error", "err", err)
 		}
 	}
+
+	// The journal goes last, once nothing can append to it
+	if s.journal != nil {
+		if err := s.journal.Close(); err != nil {
+			s.logger.Warn("command journal close returned error", "err", err)
+		}
+	}
 }
@@ -5,20 +5,30 @@ import (
 	"errors"
 	"log"
 	"log/slog"
+	"maps"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/dedup"
 	streamingclient "github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/events/streaming_client"
 	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/events/streaming_client/clients"
 	matchingengine "github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/matching_engine"
 	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+	common "github.com/Marwan051/tradding_platform_game/proto/gen/go/v1/common"
+	marketdata "github.com/Marwan051/tradding_platform_game/proto/gen/go/v1/market_data"
 	pb "github.com/Marwan051/tradding_platform_game/proto/gen/go/v1/matching_engine"
 	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// Requests are rejected with these while the event stream is down
+const degradedMessage = "Service is in degraded mode and can't accept new requests"
+
+var errDegraded = errors.New("Engine is in degraded mode")
+
 type MatchingEngineService struct {
 	pb.UnimplementedMatchingEngineServer
 	logger         *slog.Logger
@@ -27,12 +37,13 @@ type MatchingEngineService struct {
 	ctx            context.Context
 	cancel         context.CancelFunc
 	streamer       streamingclient.StreamingClient
+	snapshotting   bool                                 // Save a final snapshot on Close
+	placed         *dedup.Cache[*pb.PlaceOrderResponse] // Responses by client order ID, nil to place every request
+	clock          func() time.Time                     // Current time, for order timestamps and the client order ID window
 	wg             sync.WaitGroup
 }
 
-func NewMatchingEngineService(logger *slog.Logger, valkeyOptions clients.ValkeyOptions) *MatchingEngineService {
-	ctx, cancel := context.WithCancel(context.Background())
-
+func NewMatchingEngineService(logger *slog.Logger, valkeyOptions clients.ValkeyOptions, engineOptions ...matchingengine.Option) *MatchingEngineService {
 	valkeyStreamingClient, err := clients.NewValkeyClient(
 		valkeyOptions.ValkeyHost, valkeyOptions.ValkeyPort, valkeyOptions.ValkeyStreamName, 10000, valkeyOptions.ValkeyRequestTimeoutMs,
 	)
@@ -40,48 +51,145 @@ func NewMatchingEngineService(logger *slog.Logger, valkeyOptions clients.ValkeyO
 		log.Fatalf("Could not connect to event streaming client with error: %s", err)
 	}
 
-	svc := &MatchingEngineService{
-		logger:   logger,
-		engine:   matchingengine.NewMatchingEngine(valkeyStreamingClient),
-		ctx:      ctx,
-		cancel:   cancel,
-		streamer: valkeyStreamingClient,
+	svc := newMatchingEngineService(logger, matchingengine.NewMatchingEngine(valkeyStreamingClient, engineOptions...), valkeyStreamingClient)
+
+	// Rebuild the books from the journal before taking any orders
+	replayed, err := svc.engine.Recover()
+	if err != nil {
+		log.Fatalf("Could not replay command journal with error: %s", err)
+	}
+	if replayed > 0 {
+		logger.Info("replayed command journal", "commands", replayed)
 	}
 
 	// initial probe (short timeout)
-	probeCtx, probeCancel := context.WithTimeout(ctx, 2*time.Second)
+	probeCtx, probeCancel := context.WithTimeout(svc.ctx, 2*time.Second)
 	ok, _ := svc.engine.IsEventStreamerHealthy(probeCtx)
 	probeCancel()
 	svc.inDegradedMode.Store(!ok)
 
+	return svc
+}
+
+// newMatchingEngineService serves an engine that publishes to streamer
+func newMatchingEngineService(logger *slog.Logger, engine *matchingengine.MatchingEngine, streamer streamingclient.StreamingClient) *MatchingEngineService {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &MatchingEngineService{
+		logger:   logger,
+		engine:   engine,
+		ctx:      ctx,
+		cancel:   cancel,
+		streamer: streamer,
+		clock:    time.Now,
+	}
+}
+
+// Start runs the background health poller and the sweeper. The sweeper runs session auctions and
+// expires orders, so it must only start once the books are recovered or rebuilt.
+func (s *MatchingEngineService) Start() {
 	// start background poller
-	svc.wg.Add(1)
+	s.wg.Add(1)
 	go func() {
-		defer svc.wg.Done()
+		defer s.wg.Done()
 		ticker := time.NewTicker(5 * time.Second)
 		defer ticker.Stop()
 		for {
 			select {
-			case <-svc.ctx.Done():
+			case <-s.ctx.Done():
 				return
 			case <-ticker.C:
 				checkCtx, c := context.WithTimeout(context.Background(), 1*time.Second)
-				ok, _ := svc.engine.IsEventStreamerHealthy(checkCtx)
+				ok, _ := s.engine.IsEventStreamerHealthy(checkCtx)
 				c()
-				svc.inDegradedMode.Store(!ok)
+				s.inDegradedMode.Store(!ok)
 			}
 		}
 	}()
 
-	return svc
+	// start background sweeper for session phase changes, expired DAY/GTD orders and ended trading halts
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(1 * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.ctx.Done():
+				return
+			case now := <-ticker.C:
+				// Runs first so DAY orders take part in the closing auction before they expire
+				s.engine.AdvanceSession(now)
+				if expired := s.engine.ExpireOrders(now); expired > 0 {
+					s.logger.Info("expired orders", "count", expired)
+				}
+				if resumed := s.engine.ResumeHalted(now); resumed > 0 {
+					s.logger.Info("resumed halted stocks", "count", resumed)
+				}
+			}
+		}
+	}()
+}
+
+// Rebuild puts open orders loaded from the database back on the engine's books, starting each
+// book from its stock's prices, and reconciles them against the database's totals
+func (s *MatchingEngineService) Rebuild(orders []*types.Order, prices map[string]matchingengine.BookPrices, totals matchingengine.RebuildTotals) error {
+	if err := s.engine.Rebuild(orders, prices, totals); err != nil {
+		return err
+	}
+	s.logger.Info("rebuilt books from open orders", "orders", len(orders))
+	return nil
+}
+
+// SetReferencePrices measures each stock's static price band from the given price, usually
+// its previous close. Stocks are set in ticker order, so a journal records them the same way.
+func (s *MatchingEngineService) SetReferencePrices(prices map[string]int64) {
+	for _, stock := range slices.Sorted(maps.Keys(prices)) {
+		s.engine.SetReferencePrice(stock, prices[stock])
+	}
+	s.logger.Info("set reference prices", "stocks", len(prices))
+}
+
+// StartSnapshots saves the engine's books every interval until the service is closed
+func (s *MatchingEngineService) StartSnapshots(interval time.Duration) {
+	s.snapshotting = true
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-ticker.C:
+				s.saveSnapshot()
+			}
+		}
+	}()
+}
+
+// DeduplicateOrders makes PlaceOrder idempotent: a request repeating a trader's client order ID
+// within window of the first gets the first response back instead of placing another order
+func (s *MatchingEngineService) DeduplicateOrders(window time.Duration) {
+	s.placed = dedup.New[*pb.PlaceOrderResponse](window, dedup.Options{Clock: s.clock})
+}
+
+func (s *MatchingEngineService) saveSnapshot() {
+	start := time.Now()
+	sequence, err := s.engine.SaveSnapshot()
+	if err != nil {
+		s.logger.Error("failed to save snapshot", "err", err)
+		return
+	}
+	s.logger.Info("saved snapshot", "journal_sequence", sequence, "duration", time.Since(start))
 }
 
 func (s *MatchingEngineService) Close(ctx context.Context) error {
 	if s.cancel != nil {
-		s.cancel() // stop poller
+		s.cancel() // stop poller and sweeper
 	}
 
-	// wait for background poller to finish
+	// wait for background goroutines to finish
 	done := make(chan struct{})
 	go func() {
 		s.wg.Wait()
@@ -92,6 +200,12 @@ func (s *MatchingEngineService) Close(ctx context.Context) error {
 	case <-ctx.Done():
 		return ctx.Err()
 	case <-done:
+		// Nothing submits commands any more, so the book sequencers can drain and stop
+		s.engine.Close()
+		// A final snapshot leaves nothing to replay on the next start
+		if s.snapshotting {
+			s.saveSnapshot()
+		}
 		if s.streamer != nil {
 			return s.streamer.Close(ctx)
 		}
@@ -99,6 +213,21 @@ func (s *MatchingEngineService) Close(ctx context.Context) error {
 	}
 }
 
+// degraded reports whether requests must be rejected because the event stream is down.
+// While in degraded mode it tries one short health probe first, leaving degraded mode if it passes.
+func (s *MatchingEngineService) degraded(ctx context.Context) bool {
+	if !s.inDegradedMode.Load() {
+		return false
+	}
+	probeCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+	ok, _ := s.engine.IsEventStreamerHealthy(probeCtx)
+	cancel()
+	if ok {
+		s.inDegradedMode.Store(false)
+	}
+	return !ok
+}
+
 func (s *MatchingEngineService) HealthCheck(ctx context.Context, req *pb.HealthCheckRequest) (*pb.HealthCheckResponse, error) {
 	healthCheck, err := s.engine.IsEventStreamerHealthy(ctx)
 	if err != nil {
@@ -124,20 +253,27 @@ func (s *MatchingEngineService) HealthCheck(ctx context.Context, req *pb.HealthC
 }
 
 func (s *MatchingEngineService) PlaceOrder(ctx context.Context, req *pb.PlaceOrderRequest) (*pb.PlaceOrderResponse, error) {
-	if s.inDegradedMode.Load() {
-		// try one short health probe before rejecting
-		probeCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
-		ok, _ := s.engine.IsEventStreamerHealthy(probeCtx)
-		cancel()
-		if ok {
-			s.inDegradedMode.Store(false)
-		} else {
-			return &pb.PlaceOrderResponse{
-				Success:      false,
-				ErrorMessage: "Service is in degraded mode and can't accept new requests",
-				ErrorCode:    2,
-			}, errors.New("Engine is in degraded mode")
-		}
+	if s.placed == nil || req.ClientOrderId == "" {
+		return s.placeOrder(ctx, req)
+	}
+	// Only responses are remembered; errors, such as degraded mode, leave the retry free to place
+	key := dedup.Key{TraderID: req.TraderId, ClientOrderID: req.ClientOrderId}
+	resp, duplicate, err := s.placed.Do(ctx, key, func() (*pb.PlaceOrderResponse, error) {
+		return s.placeOrder(ctx, req)
+	})
+	if duplicate {
+		s.logger.Info("duplicate order request", "trader_id", req.TraderId, "client_order_id", req.ClientOrderId, "order_id", resp.OrderId)
+	}
+	return resp, err
+}
+
+func (s *MatchingEngineService) placeOrder(ctx context.Context, req *pb.PlaceOrderRequest) (*pb.PlaceOrderResponse, error) {
+	if s.degraded(ctx) {
+		return &pb.PlaceOrderResponse{
+			Success:      false,
+			ErrorMessage: degradedMessage,
+			ErrorCode:    2,
+		}, errDegraded
 	}
 
 	orderID := uuid.New().String()
@@ -151,12 +287,38 @@ func (s *MatchingEngineService) PlaceOrder(ctx context.Context, req *pb.PlaceOrd
 	}
 
 	var orderType types.OrderType
-	if req.OrderType == 1 {
+	switch req.OrderType {
+	case 1:
 		orderType = types.MarketOrder
-	} else {
+	case 3:
+		orderType = types.StopMarketOrder
+	case 4:
+		orderType = types.StopLimitOrder
+	case 5:
+		orderType = types.TrailingStopOrder
+	default:
 		orderType = types.LimitOrder
 	}
 
+	var timeInForce types.TimeInForce
+	switch req.TimeInForce {
+	case 2:
+		timeInForce = types.ImmediateOrCancel
+	case 3:
+		timeInForce = types.FillOrKill
+	case 4:
+		timeInForce = types.Day
+	case 5:
+		timeInForce = types.GoodTillDate
+	default:
+		timeInForce = types.GoodTillCancel
+	}
+
+	var expireAt time.Time
+	if timeInForce == types.GoodTillDate {
+		expireAt = time.UnixMilli(req.ExpiresAtMs)
+	}
+
 	order := &types.Order{
 		OrderId:          orderID,
 		TraderId:         req.TraderId,
@@ -165,10 +327,31 @@ func (s *MatchingEngineService) PlaceOrder(ctx context.Context, req *pb.PlaceOrd
 		OrderSide:        orderSide,
 		Quantity:         int64(req.Quantity),
 		LimitPrice:       int64(req.LimitPriceCents),
+		TriggerPrice:     req.TriggerPriceCents,
 		AvailableBalance: int64(req.AvailableBalanceCents),
-		Timestamp:        time.Now(),
+		AvailableShares:  req.AvailableShares,
+		TimeInForce:      timeInForce,
+		ExpireAt:         expireAt,
+		PostOnly:         req.PostOnly,
+		PostOnlyReprice:  req.PostOnlyReprice,
+		DisplayQuantity:  req.DisplayQuantity,
+		OwnerTraderId:    req.OwnerTraderId,
+		TrailingOffset:   req.TrailingOffsetCents,
+		TrailingBps:      req.TrailingOffsetBps,
+		SelfTrade:        types.SelfTradePrevention(req.SelfTradePrevention), // Proto values match the Go enum
+		ClientOrderId:    req.ClientOrderId,
+		Timestamp:        s.clock(),
 	}
 	matches, remainingQty, err := s.engine.SubmitOrder(order)
+	var rejection *matchingengine.RejectionError
+	if errors.As(err, &rejection) {
+		return &pb.PlaceOrderResponse{
+			Success:      false,
+			OrderId:      orderID,
+			ErrorMessage: rejection.Message,
+			ErrorCode:    common.ErrorCode(rejection.Code),
+		}, nil
+	}
 	if err != nil {
 		s.logger.Error("Failed to submit order", "error", err, "order_id", orderID)
 		return nil, status.Errorf(codes.InvalidArgument, "failed to place order: %v", err)
@@ -184,37 +367,33 @@ func (s *MatchingEngineService) PlaceOrder(ctx context.Context, req *pb.PlaceOrd
 }
 
 func (s *MatchingEngineService) CancelOrder(ctx context.Context, req *pb.CancelOrderRequest) (*pb.CancelOrderResponse, error) {
-	if s.inDegradedMode.Load() {
-		// try one short health probe before rejecting
-		probeCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
-		ok, _ := s.engine.IsEventStreamerHealthy(probeCtx)
-		cancel()
-		if ok {
-			s.inDegradedMode.Store(false)
-		} else {
-			return &pb.CancelOrderResponse{
-				Success:      false,
-				ErrorMessage: "Service is in degraded mode and can't accept new requests",
-			}, errors.New("Engine is in degraded mode")
-		}
+	if s.degraded(ctx) {
+		return &pb.CancelOrderResponse{
+			Success:      false,
+			ErrorMessage: degradedMessage,
+		}, errDegraded
 	}
 
-	// Convert protobuf enum (1-indexed) to Go enum (0-indexed)
-	var orderSide types.OrderSide
-	if req.Side == 1 {
-		orderSide = types.Buy
-	} else {
-		orderSide = types.Sell
+	found, err := s.engine.CancelOrder(req.OrderId, req.TraderId)
+	var rejection *matchingengine.RejectionError
+	if errors.As(err, &rejection) {
+		if rejection.Code == types.ErrorCodeUnauthorized {
+			s.logger.Warn("Order cancellation by another trader", "order_id", req.OrderId, "trader_id", req.TraderId)
+		}
+		return &pb.CancelOrderResponse{
+			Success:      false,
+			OrderId:      req.OrderId,
+			ErrorMessage: rejection.Message,
+			ErrorCode:    common.ErrorCode(rejection.Code),
+		}, nil
 	}
-
-	found, err := s.engine.CancelOrder(req.StockTicker, req.OrderId, orderSide)
 	if err != nil {
 		s.logger.Error("Failed to cancel order", "error", err, "order_id", req.OrderId)
 		return nil, status.Errorf(codes.InvalidArgument, "failed to cancel order: %v", err)
 	}
 
 	if !found {
-		s.logger.Warn("Order not found for cancellation", "order_id", req.OrderId, "stock", req.StockTicker)
+		s.logger.Warn("Order not found for cancellation", "order_id", req.OrderId)
 		return nil, status.Errorf(codes.NotFound, "order not found: %s", req.OrderId)
 	}
 
@@ -223,3 +402,210 @@ func (s *MatchingEngineService) CancelOrder(ctx context.Context, req *pb.CancelO
 		OrderId: req.OrderId,
 	}, nil
 }
+
+func (s *MatchingEngineService) MassCancel(ctx context.Context, req *pb.MassCancelRequest) (*pb.MassCancelResponse, error) {
+	if s.degraded(ctx) {
+		return &pb.MassCancelResponse{
+			Success:      false,
+			ErrorMessage: degradedMessage,
+		}, errDegraded
+	}
+
+	filter := matchingengine.MassCancelFilter{TraderId: req.TraderId, Stock: req.StockTicker}
+	// Convert protobuf enum (1-indexed) to Go enum (0-indexed); unspecified covers both sides
+	switch req.Side {
+	case 1:
+		side := types.Buy
+		filter.Side = &side
+	case 2:
+		side := types.Sell
+		filter.Side = &side
+	}
+
+	orderIds, err := s.engine.MassCancel(filter)
+	s.logger.Info("orders mass cancelled", "trader_id", req.TraderId, "stock", req.StockTicker, "side", req.Side, "cancelled", len(orderIds))
+	var rejection *matchingengine.RejectionError
+	if errors.As(err, &rejection) {
+		return &pb.MassCancelResponse{
+			Success:        false,
+			CancelledCount: int64(len(orderIds)),
+			OrderIds:       orderIds,
+			ErrorMessage:   rejection.Message,
+			ErrorCode:      common.ErrorCode(rejection.Code),
+		}, nil
+	}
+	if err != nil {
+		s.logger.Error("Failed to mass cancel", "error", err, "trader_id", req.TraderId)
+		return nil, status.Errorf(codes.InvalidArgument, "failed to mass cancel: %v", err)
+	}
+
+	return &pb.MassCancelResponse{
+		Success:        true,
+		CancelledCount: int64(len(orderIds)),
+		OrderIds:       orderIds,
+	}, nil
+}
+
+func (s *MatchingEngineService) AmendOrder(ctx context.Context, req *pb.AmendOrderRequest) (*pb.AmendOrderResponse, error) {
+	if s.degraded(ctx) {
+		return &pb.AmendOrderResponse{
+			Success:      false,
+			ErrorMessage: degradedMessage,
+		}, errDegraded
+	}
+
+	matches, found, err := s.engine.AmendOrder(req.OrderId, req.TraderId, req.Quantity, req.LimitPriceCents, req.AvailableShares, req.AvailableBalanceCents)
+	var rejection *matchingengine.RejectionError
+	if errors.As(err, &rejection) {
+		if rejection.Code == types.ErrorCodeUnauthorized {
+			s.logger.Warn("Order amendment by another trader", "order_id", req.OrderId, "trader_id", req.TraderId)
+		}
+		return &pb.AmendOrderResponse{
+			Success:      false,
+			OrderId:      req.OrderId,
+			ErrorMessage: rejection.Message,
+			ErrorCode:    common.ErrorCode(rejection.Code),
+		}, nil
+	}
+	if err != nil {
+		s.logger.Error("Failed to amend order", "error", err, "order_id", req.OrderId)
+		return nil, status.Errorf(codes.InvalidArgument, "failed to amend order: %v", err)
+	}
+
+	if !found {
+		s.logger.Warn("Order not found for amendment", "order_id", req.OrderId)
+		return nil, status.Errorf(codes.NotFound, "order not found: %s", req.OrderId)
+	}
+
+	var filledQty int64
+	for _, match := range matches {
+		filledQty += match.Quantity
+	}
+
+	return &pb.AmendOrderResponse{
+		Success:        true,
+		OrderId:        req.OrderId,
+		FilledQuantity: filledQty,
+	}, nil
+}
+
+func (s *MatchingEngineService) StartAuction(ctx context.Context, req *pb.StartAuctionRequest) (*pb.StartAuctionResponse, error) {
+	if req.StockTicker == "" {
+		return nil, status.Error(codes.InvalidArgument, "stock ticker is required")
+	}
+	if _, ok := s.engine.LookupInstrument(req.StockTicker); !ok {
+		return nil, status.Errorf(codes.NotFound, "stock not found: %s", req.StockTicker)
+	}
+	s.engine.StartAuction(req.StockTicker)
+	s.logger.Info("auction started", "stock", req.StockTicker)
+	return &pb.StartAuctionResponse{Success: true}, nil
+}
+
+func (s *MatchingEngineService) UncrossAuction(ctx context.Context, req *pb.UncrossAuctionRequest) (*pb.UncrossAuctionResponse, error) {
+	if req.StockTicker == "" {
+		return nil, status.Error(codes.InvalidArgument, "stock ticker is required")
+	}
+	if _, ok := s.engine.LookupInstrument(req.StockTicker); !ok {
+		return nil, status.Errorf(codes.NotFound, "stock not found: %s", req.StockTicker)
+	}
+
+	price, matches := s.engine.Uncross(req.StockTicker, req.Closing)
+	var volume int64
+	for _, match := range matches {
+		volume += match.Quantity
+	}
+	s.logger.Info("auction uncrossed", "stock", req.StockTicker, "price_cents", price, "volume", volume, "closing", req.Closing)
+
+	return &pb.UncrossAuctionResponse{
+		Success:    true,
+		PriceCents: price,
+		Volume:     volume,
+	}, nil
+}
+
+func (s *MatchingEngineService) ListInstruments(ctx context.Context, req *pb.ListInstrumentsRequest) (*pb.ListInstrumentsResponse, error) {
+	instruments := s.engine.Instruments()
+	resp := &pb.ListInstrumentsResponse{Instruments: make([]*pb.Instrument, 0, len(instruments))}
+	for _, instrument := range instruments {
+		resp.Instruments = append(resp.Instruments, &pb.Instrument{
+			StockTicker:   instrument.Ticker,
+			TickSizeCents: instrument.Spec.TickSize,
+			LotSize:       instrument.Spec.LotSize,
+			MinQuantity:   instrument.Spec.MinQuantity,
+			IsActive:      instrument.Active,
+		})
+	}
+	return resp, nil
+}
+
+func (s *MatchingEngineService) AddInstrument(ctx context.Context, req *pb.AddInstrumentRequest) (*pb.AddInstrumentResponse, error) {
+	instrument := req.GetInstrument()
+	if instrument.GetStockTicker() == "" {
+		return nil, status.Error(codes.InvalidArgument, "stock ticker is required")
+	}
+	if instrument.TickSizeCents < 0 || instrument.LotSize < 0 || instrument.MinQuantity < 0 {
+		return nil, status.Error(codes.InvalidArgument, "tick size, lot size and minimum quantity must not be negative")
+	}
+
+	err := s.engine.AddInstrument(types.Instrument{
+		Ticker: instrument.StockTicker,
+		Spec:   types.NewInstrumentSpec(instrument.TickSizeCents, instrument.LotSize, instrument.MinQuantity),
+		Active: instrument.IsActive,
+	})
+	if err != nil {
+		s.logger.Error("Failed to add instrument", "error", err, "stock", instrument.StockTicker)
+		return nil, status.Errorf(codes.Internal, "failed to add instrument: %v", err)
+	}
+	s.logger.Info("instrument added", "stock", instrument.StockTicker, "active", instrument.IsActive)
+
+	return &pb.AddInstrumentResponse{Success: true}, nil
+}
+
+func (s *MatchingEngineService) DeactivateInstrument(ctx context.Context, req *pb.DeactivateInstrumentRequest) (*pb.DeactivateInstrumentResponse, error) {
+	found, err := s.engine.DeactivateInstrument(req.StockTicker)
+	if err != nil {
+		s.logger.Error("Failed to deactivate instrument", "error", err, "stock", req.StockTicker)
+		return nil, status.Errorf(codes.Internal, "failed to deactivate instrument: %v", err)
+	}
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "stock not found: %s", req.StockTicker)
+	}
+	s.logger.Info("instrument deactivated", "stock", req.StockTicker)
+	return &pb.DeactivateInstrumentResponse{Success: true}, nil
+}
+
+func (s *MatchingEngineService) GetOrderBook(ctx context.Context, req *marketdata.GetOrderBookRequest) (*marketdata.OrderBook, error) {
+	if req.StockTicker == "" {
+		return nil, status.Error(codes.InvalidArgument, "stock ticker is required")
+	}
+	if req.Depth < 0 {
+		return nil, status.Error(codes.InvalidArgument, "depth must not be negative")
+	}
+
+	snapshot, ok := s.engine.Snapshot(req.StockTicker, int(req.Depth))
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "stock not found: %s", req.StockTicker)
+	}
+	return &marketdata.OrderBook{
+		StockTicker:         snapshot.StockTicker,
+		Bids:                toPriceLevels(snapshot.Bids),
+		Asks:                toPriceLevels(snapshot.Asks),
+		TimestampMs:         snapshot.Timestamp.UnixMilli(),
+		LastTradePriceCents: snapshot.LastTradePriceCents,
+		SpreadCents:         snapshot.SpreadCents,
+		BestBidCents:        snapshot.BestBidCents,
+		BestAskCents:        snapshot.BestAskCents,
+	}, nil
+}
+
+func toPriceLevels(levels []types.LevelSnapshot) []*marketdata.PriceLevel {
+	result := make([]*marketdata.PriceLevel, 0, len(levels))
+	for _, level := range levels {
+		result = append(result, &marketdata.PriceLevel{
+			PriceCents: level.PriceCents,
+			Quantity:   level.Quantity,
+			OrderCount: int32(level.OrderCount),
+		})
+	}
+	return result
+}
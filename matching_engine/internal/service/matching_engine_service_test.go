@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/events/streaming_client/clients"
+	matchingengine "github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/matching_engine"
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+	common "github.com/Marwan051/tradding_platform_game/proto/gen/go/v1/common"
+	pb "github.com/Marwan051/tradding_platform_game/proto/gen/go/v1/matching_engine"
+)
+
+// testClock is a clock that only moves when told to
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// newTestService serves an engine without a stream behind it, on clock
+func newTestService(clock *testClock) *MatchingEngineService {
+	streamer := &clients.TestStreamingClient{}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := newMatchingEngineService(logger, matchingengine.NewMatchingEngine(streamer), streamer)
+	svc.clock = clock.Now
+	return svc
+}
+
+// Helper to create a limit buy request on AAPL
+func newPlaceRequest(traderId int64, clientOrderId string) *pb.PlaceOrderRequest {
+	return &pb.PlaceOrderRequest{
+		TraderId:        traderId,
+		StockTicker:     "AAPL",
+		OrderType:       common.OrderType_LIMIT,
+		Side:            common.OrderSide_BUY,
+		Quantity:        10,
+		LimitPriceCents: 15000,
+		ClientOrderId:   clientOrderId,
+	}
+}
+
+// restingOrders returns the number of orders resting on AAPL's bids
+func restingOrders(t *testing.T, svc *MatchingEngineService) int {
+	t.Helper()
+	snapshot, ok := svc.engine.Snapshot("AAPL", 0)
+	if !ok {
+		t.Fatal("expected an AAPL book")
+	}
+	count := 0
+	for _, level := range snapshot.Bids {
+		count += level.OrderCount
+	}
+	return count
+}
+
+func TestPlaceOrderClientOrderIDs(t *testing.T) {
+	t.Run("should return the original response for a repeated client order ID", func(t *testing.T) {
+		svc := newTestService(&testClock{now: time.Now()})
+		svc.DeduplicateOrders(time.Minute)
+		ctx := context.Background()
+
+		first, err := svc.PlaceOrder(ctx, newPlaceRequest(1, "a"))
+		if err != nil || !first.Success {
+			t.Fatalf("expected the first request to place, got %+v (%v)", first, err)
+		}
+		again, err := svc.PlaceOrder(ctx, newPlaceRequest(1, "a"))
+		if err != nil || again.OrderId != first.OrderId {
+			t.Errorf("expected the original order %s back, got %+v (%v)", first.OrderId, again, err)
+		}
+		// Client order IDs belong to their trader
+		other, _ := svc.PlaceOrder(ctx, newPlaceRequest(2, "a"))
+		if other.OrderId == first.OrderId {
+			t.Error("expected another trader's order with the same client order ID to be placed")
+		}
+		if n := restingOrders(t, svc); n != 2 {
+			t.Errorf("expected 2 orders on the book, got %d", n)
+		}
+	})
+
+	t.Run("should place concurrent duplicates once", func(t *testing.T) {
+		svc := newTestService(&testClock{now: time.Now()})
+		svc.DeduplicateOrders(time.Minute)
+		ids := make([]string, 20)
+		var wg sync.WaitGroup
+		for i := range ids {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				resp, err := svc.PlaceOrder(context.Background(), newPlaceRequest(1, "a"))
+				if err == nil {
+					ids[i] = resp.OrderId
+				}
+			}()
+		}
+		wg.Wait()
+
+		for _, id := range ids {
+			if id != ids[0] {
+				t.Fatalf("expected every request to get %s, got %s", ids[0], id)
+			}
+		}
+		if n := restingOrders(t, svc); n != 1 {
+			t.Errorf("expected 1 order on the book, got %d", n)
+		}
+	})
+
+	t.Run("should place again once the window has passed", func(t *testing.T) {
+		clock := &testClock{now: time.Now()}
+		svc := newTestService(clock)
+		svc.DeduplicateOrders(time.Minute)
+		ctx := context.Background()
+
+		first, _ := svc.PlaceOrder(ctx, newPlaceRequest(1, "a"))
+		clock.Advance(59 * time.Second)
+		if again, _ := svc.PlaceOrder(ctx, newPlaceRequest(1, "a")); again.OrderId != first.OrderId {
+			t.Error("expected the response to be remembered within the window")
+		}
+		clock.Advance(time.Second)
+		if later, _ := svc.PlaceOrder(ctx, newPlaceRequest(1, "a")); later.OrderId == first.OrderId {
+			t.Error("expected a new order once the window passed")
+		}
+		if n := restingOrders(t, svc); n != 2 {
+			t.Errorf("expected 2 orders on the book, got %d", n)
+		}
+	})
+
+	t.Run("should place every request without a client order ID", func(t *testing.T) {
+		svc := newTestService(&testClock{now: time.Now()})
+		svc.DeduplicateOrders(time.Minute)
+		svc.PlaceOrder(context.Background(), newPlaceRequest(1, ""))
+		svc.PlaceOrder(context.Background(), newPlaceRequest(1, ""))
+		if n := restingOrders(t, svc); n != 2 {
+			t.Errorf("expected 2 orders on the book, got %d", n)
+		}
+	})
+}
+
+func TestSetReferencePrices(t *testing.T) {
+	t.Run("should measure the static band from the reference price", func(t *testing.T) {
+		streamer := &clients.TestStreamingClient{}
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+		bands := types.PriceBands{StaticBps: 1000, HaltDuration: time.Minute}
+		svc := newMatchingEngineService(logger, matchingengine.NewMatchingEngine(streamer, matchingengine.WithPriceBands(bands)), streamer)
+		svc.SetReferencePrices(map[string]int64{"AAPL": 10000, "MSFT": 30000})
+		ctx := context.Background()
+
+		// 11000 is the top of the 10% band around 10000
+		sell := newPlaceRequest(1, "")
+		sell.Side = common.OrderSide_SELL
+		sell.LimitPriceCents = 11100
+		svc.PlaceOrder(ctx, sell)
+		buy := newPlaceRequest(2, "")
+		buy.LimitPriceCents = 11100
+		resp, err := svc.PlaceOrder(ctx, buy)
+		if err != nil || resp.FilledQuantity != 0 {
+			t.Fatalf("expected no trade outside the band, got %+v (%v)", resp, err)
+		}
+		if resp, _ := svc.PlaceOrder(ctx, newPlaceRequest(3, "")); resp.Success || resp.ErrorCode != common.ErrorCode_STOCK_NOT_TRADING {
+			t.Errorf("expected the halted stock to reject new orders, got %+v", resp)
+		}
+	})
+}
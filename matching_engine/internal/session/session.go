@@ -0,0 +1,90 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	_ "time/tzdata" // The engine's image may not ship a zoneinfo database
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+// filePhase is the start of one phase in a session calendar file
+type filePhase struct {
+	Phase types.SessionPhase `json:"phase"`
+	At    string             `json:"at"` // Local time of day, HH:MM
+}
+
+// fileCalendar is the layout of a session calendar file
+type fileCalendar struct {
+	TimeZone string                 `json:"time_zone"`
+	Days     map[string][]filePhase `json:"days"` // Lower-case weekday name -> phases
+	Holidays []string               `json:"holidays"`
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// LoadFile reads a session calendar from a JSON file
+func LoadFile(path string) (*types.SessionCalendar, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session calendar file: %w", err)
+	}
+	var file fileCalendar
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse session calendar file: %w", err)
+	}
+
+	location, err := time.LoadLocation(file.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown session time zone %q: %w", file.TimeZone, err)
+	}
+
+	days := make(map[time.Weekday][]types.PhaseStart, len(file.Days))
+	for name, phases := range file.Days {
+		day, ok := weekdays[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown weekday %q in session calendar", name)
+		}
+		for _, phase := range phases {
+			switch phase.Phase {
+			case types.PhasePreOpen, types.PhaseContinuous, types.PhaseClose, types.PhaseClosed:
+			default:
+				return nil, fmt.Errorf("unknown session phase %q on %s", phase.Phase, name)
+			}
+			at, err := time.Parse("15:04", phase.At)
+			if err != nil {
+				return nil, fmt.Errorf("invalid time %q on %s: %w", phase.At, name, err)
+			}
+			days[day] = append(days[day], types.PhaseStart{
+				Phase: phase.Phase,
+				At:    time.Duration(at.Hour())*time.Hour + time.Duration(at.Minute())*time.Minute,
+			})
+		}
+	}
+
+	for _, date := range file.Holidays {
+		if _, err := time.Parse(time.DateOnly, date); err != nil {
+			return nil, fmt.Errorf("invalid holiday %q: %w", date, err)
+		}
+	}
+
+	calendar := types.NewSessionCalendar(location, days, file.Holidays)
+	for day, phases := range calendar.Days {
+		// A day that doesn't close would leave the market open into the next one
+		if len(phases) > 0 && phases[len(phases)-1].Phase != types.PhaseClosed {
+			return nil, fmt.Errorf("session calendar for %s must end with %s", day, types.PhaseClosed)
+		}
+	}
+	return calendar, nil
+}
@@ -0,0 +1,164 @@
+// Package snapshots stores copies of the matching engine's books on local disk, so a restart
+// loads the latest one and only replays the journal commands after it.
+//
+// Each snapshot is a JSON file named after the journal sequence number it covers and the time
+// it was taken. Files are written to a temporary name and renamed into place, so a crash never
+// leaves a partial snapshot behind.
+package snapshots
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+const (
+	snapshotSuffix = ".snap"
+	tempPattern    = ".snapshot-*.tmp"
+	DefaultKeep    = 3
+)
+
+// Options tunes a snapshot store
+type Options struct {
+	Keep int // Number of snapshots kept, newest first; DefaultKeep if 0
+}
+
+// Store saves engine states to a directory and prunes old ones
+type Store struct {
+	dir  string
+	keep int
+}
+
+// Open opens the snapshot store in dir, creating the directory if needed. Temporary files left
+// by a crash mid-save are removed.
+func Open(dir string, opts Options) (*Store, error) {
+	if opts.Keep <= 0 {
+		opts.Keep = DefaultKeep
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	leftovers, err := filepath.Glob(filepath.Join(dir, tempPattern))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range leftovers {
+		os.Remove(path)
+	}
+	return &Store{dir: dir, keep: opts.Keep}, nil
+}
+
+// Save writes a state atomically and removes the snapshots beyond the newest Keep
+func (s *Store) Save(state types.EngineState) error {
+	file, err := os.CreateTemp(s.dir, tempPattern)
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot file: %w", err)
+	}
+	temp := file.Name()
+	defer os.Remove(temp) // No-op once renamed
+
+	if err := json.NewEncoder(file).Encode(state); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to sync snapshot: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close snapshot: %w", err)
+	}
+
+	name := fmt.Sprintf("%020d-%019d%s", state.JournalSequence, state.TakenAt.UnixNano(), snapshotSuffix)
+	if err := os.Rename(temp, filepath.Join(s.dir, name)); err != nil {
+		return fmt.Errorf("failed to rename snapshot: %w", err)
+	}
+	if err := syncDir(s.dir); err != nil {
+		return err
+	}
+	return s.prune()
+}
+
+// Latest returns the newest snapshot that can be read, or nil if there is none.
+// Unreadable snapshots are skipped in favour of older ones.
+func (s *Store) Latest() (*types.EngineState, error) {
+	paths, err := s.list()
+	if err != nil {
+		return nil, err
+	}
+	for i := len(paths) - 1; i >= 0; i-- {
+		state, err := load(paths[i])
+		if err != nil {
+			log.Printf("skipping snapshot %s: %v", filepath.Base(paths[i]), err)
+			continue
+		}
+		return state, nil
+	}
+	return nil, nil
+}
+
+// prune removes every snapshot but the newest Keep
+func (s *Store) prune() error {
+	paths, err := s.list()
+	if err != nil {
+		return err
+	}
+	for len(paths) > s.keep {
+		if err := os.Remove(paths[0]); err != nil {
+			return fmt.Errorf("failed to remove old snapshot: %w", err)
+		}
+		paths = paths[1:]
+	}
+	return nil
+}
+
+// list returns the paths of the saved snapshots, oldest first
+func (s *Store) list() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot directory: %w", err)
+	}
+	var paths []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), snapshotSuffix) {
+			paths = append(paths, filepath.Join(s.dir, entry.Name()))
+		}
+	}
+	// Names are fixed width, so they sort by journal sequence and then by time taken
+	sort.Strings(paths)
+	return paths, nil
+}
+
+func load(path string) (*types.EngineState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var state types.EngineState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+	if state.Version != types.EngineStateVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d", state.Version)
+	}
+	return &state, nil
+}
+
+// syncDir fsyncs a directory so renames in it survive a crash
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot directory: %w", err)
+	}
+	defer d.Close()
+	if err := d.Sync(); err != nil {
+		return fmt.Errorf("failed to sync snapshot directory: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package snapshots
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Marwan051/tradding_platform_game/matching_engine/internal/lib/types"
+)
+
+func TestSnapshots(t *testing.T) {
+	t.Run("should keep only the newest snapshots", func(t *testing.T) {
+		dir := t.TempDir()
+		store, err := Open(dir, Options{Keep: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for sequence := uint64(1); sequence <= 4; sequence++ {
+			state := types.EngineState{Version: types.EngineStateVersion, JournalSequence: sequence * 10, TakenAt: time.Now()}
+			if err := store.Save(state); err != nil {
+				t.Fatal(err)
+			}
+		}
+		files, _ := filepath.Glob(filepath.Join(dir, "*"))
+		if len(files) != 2 {
+			t.Errorf("expected 2 snapshot files, got %v", files)
+		}
+		latest, err := store.Latest()
+		if err != nil || latest == nil || latest.JournalSequence != 40 {
+			t.Errorf("expected the snapshot at 40, got %+v (%v)", latest, err)
+		}
+	})
+
+	t.Run("should have no latest snapshot in an empty directory", func(t *testing.T) {
+		store, err := Open(t.TempDir(), Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if latest, err := store.Latest(); err != nil || latest != nil {
+			t.Errorf("expected no snapshot, got %+v (%v)", latest, err)
+		}
+	})
+}
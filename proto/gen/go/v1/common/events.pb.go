@@ -32,18 +32,34 @@ const (
 	EventType_ORDER_PARTIALLY_FILLED EventType = 4
 	EventType_ORDER_REJECTED         EventType = 5
 	EventType_TRADE_EXECUTED         EventType = 6
+	EventType_ORDER_TRIGGERED        EventType = 7
+	EventType_ORDER_AMENDED          EventType = 8
+	EventType_SELF_TRADE_PREVENTED   EventType = 9
+	EventType_AUCTION_UNCROSSED      EventType = 10
+	EventType_TRADING_HALTED         EventType = 11
+	EventType_TRADING_RESUMED        EventType = 12
+	EventType_SESSION_PHASE_CHANGED  EventType = 13
+	EventType_TRAILING_STOP_MOVED    EventType = 14
 )
 
 // Enum value maps for EventType.
 var (
 	EventType_name = map[int32]string{
-		0: "EVENT_TYPE_UNSPECIFIED",
-		1: "ORDER_PLACED",
-		2: "ORDER_CANCELLED",
-		3: "ORDER_FILLED",
-		4: "ORDER_PARTIALLY_FILLED",
-		5: "ORDER_REJECTED",
-		6: "TRADE_EXECUTED",
+		0:  "EVENT_TYPE_UNSPECIFIED",
+		1:  "ORDER_PLACED",
+		2:  "ORDER_CANCELLED",
+		3:  "ORDER_FILLED",
+		4:  "ORDER_PARTIALLY_FILLED",
+		5:  "ORDER_REJECTED",
+		6:  "TRADE_EXECUTED",
+		7:  "ORDER_TRIGGERED",
+		8:  "ORDER_AMENDED",
+		9:  "SELF_TRADE_PREVENTED",
+		10: "AUCTION_UNCROSSED",
+		11: "TRADING_HALTED",
+		12: "TRADING_RESUMED",
+		13: "SESSION_PHASE_CHANGED",
+		14: "TRAILING_STOP_MOVED",
 	}
 	EventType_value = map[string]int32{
 		"EVENT_TYPE_UNSPECIFIED": 0,
@@ -53,6 +69,14 @@ var (
 		"ORDER_PARTIALLY_FILLED": 4,
 		"ORDER_REJECTED":         5,
 		"TRADE_EXECUTED":         6,
+		"ORDER_TRIGGERED":        7,
+		"ORDER_AMENDED":          8,
+		"SELF_TRADE_PREVENTED":   9,
+		"AUCTION_UNCROSSED":      10,
+		"TRADING_HALTED":         11,
+		"TRADING_RESUMED":        12,
+		"SESSION_PHASE_CHANGED":  13,
+		"TRAILING_STOP_MOVED":    14,
 	}
 )
 
@@ -83,12 +107,182 @@ func (EventType) EnumDescriptor() ([]byte, []int) {
 	return file_proto_v1_common_events_proto_rawDescGZIP(), []int{0}
 }
 
+// CancelReason describes why an order left the engine without being fully filled.
+type CancelReason int32
+
+const (
+	CancelReason_CANCEL_REASON_UNSPECIFIED CancelReason = 0
+	CancelReason_USER_REQUESTED            CancelReason = 1
+	CancelReason_IMMEDIATE_OR_CANCEL       CancelReason = 2 // Unfilled remainder of a MARKET or IOC order
+	CancelReason_FILL_OR_KILL              CancelReason = 3 // Not enough depth to fill a FOK order completely
+	CancelReason_EXPIRED                   CancelReason = 4 // DAY or GTD order reached its expiry
+	CancelReason_SELF_TRADE_PREVENTION     CancelReason = 5
+)
+
+// Enum value maps for CancelReason.
+var (
+	CancelReason_name = map[int32]string{
+		0: "CANCEL_REASON_UNSPECIFIED",
+		1: "USER_REQUESTED",
+		2: "IMMEDIATE_OR_CANCEL",
+		3: "FILL_OR_KILL",
+		4: "EXPIRED",
+		5: "SELF_TRADE_PREVENTION",
+	}
+	CancelReason_value = map[string]int32{
+		"CANCEL_REASON_UNSPECIFIED": 0,
+		"USER_REQUESTED":            1,
+		"IMMEDIATE_OR_CANCEL":       2,
+		"FILL_OR_KILL":              3,
+		"EXPIRED":                   4,
+		"SELF_TRADE_PREVENTION":     5,
+	}
+)
+
+func (x CancelReason) Enum() *CancelReason {
+	p := new(CancelReason)
+	*p = x
+	return p
+}
+
+func (x CancelReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CancelReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_v1_common_events_proto_enumTypes[1].Descriptor()
+}
+
+func (CancelReason) Type() protoreflect.EnumType {
+	return &file_proto_v1_common_events_proto_enumTypes[1]
+}
+
+func (x CancelReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CancelReason.Descriptor instead.
+func (CancelReason) EnumDescriptor() ([]byte, []int) {
+	return file_proto_v1_common_events_proto_rawDescGZIP(), []int{1}
+}
+
+// HaltReason explains why trading in a stock was halted.
+type HaltReason int32
+
+const (
+	HaltReason_HALT_REASON_UNSPECIFIED HaltReason = 0
+	HaltReason_STATIC_BAND             HaltReason = 1 // Trade would move too far from the reference price
+	HaltReason_DYNAMIC_BAND            HaltReason = 2 // Trade would move too far from the last trade price
+)
+
+// Enum value maps for HaltReason.
+var (
+	HaltReason_name = map[int32]string{
+		0: "HALT_REASON_UNSPECIFIED",
+		1: "STATIC_BAND",
+		2: "DYNAMIC_BAND",
+	}
+	HaltReason_value = map[string]int32{
+		"HALT_REASON_UNSPECIFIED": 0,
+		"STATIC_BAND":             1,
+		"DYNAMIC_BAND":            2,
+	}
+)
+
+func (x HaltReason) Enum() *HaltReason {
+	p := new(HaltReason)
+	*p = x
+	return p
+}
+
+func (x HaltReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HaltReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_v1_common_events_proto_enumTypes[2].Descriptor()
+}
+
+func (HaltReason) Type() protoreflect.EnumType {
+	return &file_proto_v1_common_events_proto_enumTypes[2]
+}
+
+func (x HaltReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use HaltReason.Descriptor instead.
+func (HaltReason) EnumDescriptor() ([]byte, []int) {
+	return file_proto_v1_common_events_proto_rawDescGZIP(), []int{2}
+}
+
+// SessionPhase is a stage of the market's trading day.
+type SessionPhase int32
+
+const (
+	SessionPhase_SESSION_PHASE_UNSPECIFIED SessionPhase = 0
+	SessionPhase_PRE_OPEN                  SessionPhase = 1 // Orders are collected for the opening auction
+	SessionPhase_CONTINUOUS                SessionPhase = 2 // Orders match as they arrive
+	SessionPhase_CLOSE                     SessionPhase = 3 // Orders are collected for the closing auction
+	SessionPhase_CLOSED                    SessionPhase = 4 // New orders are rejected
+)
+
+// Enum value maps for SessionPhase.
+var (
+	SessionPhase_name = map[int32]string{
+		0: "SESSION_PHASE_UNSPECIFIED",
+		1: "PRE_OPEN",
+		2: "CONTINUOUS",
+		3: "CLOSE",
+		4: "CLOSED",
+	}
+	SessionPhase_value = map[string]int32{
+		"SESSION_PHASE_UNSPECIFIED": 0,
+		"PRE_OPEN":                  1,
+		"CONTINUOUS":                2,
+		"CLOSE":                     3,
+		"CLOSED":                    4,
+	}
+)
+
+func (x SessionPhase) Enum() *SessionPhase {
+	p := new(SessionPhase)
+	*p = x
+	return p
+}
+
+func (x SessionPhase) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SessionPhase) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_v1_common_events_proto_enumTypes[3].Descriptor()
+}
+
+func (SessionPhase) Type() protoreflect.EnumType {
+	return &file_proto_v1_common_events_proto_enumTypes[3]
+}
+
+func (x SessionPhase) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SessionPhase.Descriptor instead.
+func (SessionPhase) EnumDescriptor() ([]byte, []int) {
+	return file_proto_v1_common_events_proto_rawDescGZIP(), []int{3}
+}
+
 // EngineEvent is the envelope for all events emitted by the matching engine.
 type EngineEvent struct {
 	state       protoimpl.MessageState `protogen:"open.v1"`
 	EventId     string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
 	TimestampMs int64                  `protobuf:"varint,2,opt,name=timestamp_ms,json=timestampMs,proto3" json:"timestamp_ms,omitempty"`
 	EventType   EventType              `protobuf:"varint,3,opt,name=event_type,json=eventType,proto3,enum=common.events.EventType" json:"event_type,omitempty"`
+	// Sequence numbers start at 1 and have no gaps, so consumers can detect lost or repeated events
+	Sequence      int64  `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`                                // Engine-wide
+	StockTicker   string `protobuf:"bytes,5,opt,name=stock_ticker,json=stockTicker,proto3" json:"stock_ticker,omitempty"`        // Empty for events not tied to a stock's book
+	StockSequence int64  `protobuf:"varint,6,opt,name=stock_sequence,json=stockSequence,proto3" json:"stock_sequence,omitempty"` // Per stock, 0 for events not tied to a stock's book
+	Epoch         string `protobuf:"bytes,7,opt,name=epoch,proto3" json:"epoch,omitempty"`                                       // New for every run of the engine, so a restart isn't taken for repeated events
 	// One of these will be set based on event_type
 	OrderPlaced          *OrderPlacedEvent          `protobuf:"bytes,10,opt,name=order_placed,json=orderPlaced,proto3" json:"order_placed,omitempty"`
 	OrderCancelled       *OrderCancelledEvent       `protobuf:"bytes,11,opt,name=order_cancelled,json=orderCancelled,proto3" json:"order_cancelled,omitempty"`
@@ -96,6 +290,14 @@ type EngineEvent struct {
 	OrderPartiallyFilled *OrderPartiallyFilledEvent `protobuf:"bytes,13,opt,name=order_partially_filled,json=orderPartiallyFilled,proto3" json:"order_partially_filled,omitempty"`
 	OrderRejected        *OrderRejectedEvent        `protobuf:"bytes,14,opt,name=order_rejected,json=orderRejected,proto3" json:"order_rejected,omitempty"`
 	TradeExecuted        *TradeExecutedEvent        `protobuf:"bytes,15,opt,name=trade_executed,json=tradeExecuted,proto3" json:"trade_executed,omitempty"`
+	OrderTriggered       *OrderTriggeredEvent       `protobuf:"bytes,16,opt,name=order_triggered,json=orderTriggered,proto3" json:"order_triggered,omitempty"`
+	OrderAmended         *OrderAmendedEvent         `protobuf:"bytes,17,opt,name=order_amended,json=orderAmended,proto3" json:"order_amended,omitempty"`
+	SelfTradePrevented   *SelfTradePreventedEvent   `protobuf:"bytes,18,opt,name=self_trade_prevented,json=selfTradePrevented,proto3" json:"self_trade_prevented,omitempty"`
+	AuctionUncrossed     *AuctionUncrossedEvent     `protobuf:"bytes,19,opt,name=auction_uncrossed,json=auctionUncrossed,proto3" json:"auction_uncrossed,omitempty"`
+	TradingHalted        *TradingHaltedEvent        `protobuf:"bytes,20,opt,name=trading_halted,json=tradingHalted,proto3" json:"trading_halted,omitempty"`
+	TradingResumed       *TradingResumedEvent       `protobuf:"bytes,21,opt,name=trading_resumed,json=tradingResumed,proto3" json:"trading_resumed,omitempty"`
+	SessionPhaseChanged  *SessionPhaseChangedEvent  `protobuf:"bytes,22,opt,name=session_phase_changed,json=sessionPhaseChanged,proto3" json:"session_phase_changed,omitempty"`
+	TrailingStopMoved    *TrailingStopMovedEvent    `protobuf:"bytes,23,opt,name=trailing_stop_moved,json=trailingStopMoved,proto3" json:"trailing_stop_moved,omitempty"`
 	unknownFields        protoimpl.UnknownFields
 	sizeCache            protoimpl.SizeCache
 }
@@ -151,6 +353,34 @@ func (x *EngineEvent) GetEventType() EventType {
 	return EventType_EVENT_TYPE_UNSPECIFIED
 }
 
+func (x *EngineEvent) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *EngineEvent) GetStockTicker() string {
+	if x != nil {
+		return x.StockTicker
+	}
+	return ""
+}
+
+func (x *EngineEvent) GetStockSequence() int64 {
+	if x != nil {
+		return x.StockSequence
+	}
+	return 0
+}
+
+func (x *EngineEvent) GetEpoch() string {
+	if x != nil {
+		return x.Epoch
+	}
+	return ""
+}
+
 func (x *EngineEvent) GetOrderPlaced() *OrderPlacedEvent {
 	if x != nil {
 		return x.OrderPlaced
@@ -193,18 +423,84 @@ func (x *EngineEvent) GetTradeExecuted() *TradeExecutedEvent {
 	return nil
 }
 
+func (x *EngineEvent) GetOrderTriggered() *OrderTriggeredEvent {
+	if x != nil {
+		return x.OrderTriggered
+	}
+	return nil
+}
+
+func (x *EngineEvent) GetOrderAmended() *OrderAmendedEvent {
+	if x != nil {
+		return x.OrderAmended
+	}
+	return nil
+}
+
+func (x *EngineEvent) GetSelfTradePrevented() *SelfTradePreventedEvent {
+	if x != nil {
+		return x.SelfTradePrevented
+	}
+	return nil
+}
+
+func (x *EngineEvent) GetAuctionUncrossed() *AuctionUncrossedEvent {
+	if x != nil {
+		return x.AuctionUncrossed
+	}
+	return nil
+}
+
+func (x *EngineEvent) GetTradingHalted() *TradingHaltedEvent {
+	if x != nil {
+		return x.TradingHalted
+	}
+	return nil
+}
+
+func (x *EngineEvent) GetTradingResumed() *TradingResumedEvent {
+	if x != nil {
+		return x.TradingResumed
+	}
+	return nil
+}
+
+func (x *EngineEvent) GetSessionPhaseChanged() *SessionPhaseChangedEvent {
+	if x != nil {
+		return x.SessionPhaseChanged
+	}
+	return nil
+}
+
+func (x *EngineEvent) GetTrailingStopMoved() *TrailingStopMovedEvent {
+	if x != nil {
+		return x.TrailingStopMoved
+	}
+	return nil
+}
+
 // OrderPlacedEvent is emitted when a new order is accepted.
 type OrderPlacedEvent struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	OrderId         string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	TraderId        int64                  `protobuf:"varint,2,opt,name=trader_id,json=traderId,proto3" json:"trader_id,omitempty"`
-	StockTicker     string                 `protobuf:"bytes,4,opt,name=stock_ticker,json=stockTicker,proto3" json:"stock_ticker,omitempty"`
-	OrderType       OrderType              `protobuf:"varint,5,opt,name=order_type,json=orderType,proto3,enum=common.types.OrderType" json:"order_type,omitempty"`
-	Side            OrderSide              `protobuf:"varint,6,opt,name=side,proto3,enum=common.types.OrderSide" json:"side,omitempty"`
-	Quantity        int64                  `protobuf:"varint,7,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	LimitPriceCents int64                  `protobuf:"varint,8,opt,name=limit_price_cents,json=limitPriceCents,proto3" json:"limit_price_cents,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	OrderId             string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	TraderId            int64                  `protobuf:"varint,2,opt,name=trader_id,json=traderId,proto3" json:"trader_id,omitempty"`
+	StockTicker         string                 `protobuf:"bytes,3,opt,name=stock_ticker,json=stockTicker,proto3" json:"stock_ticker,omitempty"`
+	OrderType           OrderType              `protobuf:"varint,4,opt,name=order_type,json=orderType,proto3,enum=common.types.OrderType" json:"order_type,omitempty"`
+	Side                OrderSide              `protobuf:"varint,5,opt,name=side,proto3,enum=common.types.OrderSide" json:"side,omitempty"`
+	Quantity            int64                  `protobuf:"varint,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	LimitPriceCents     int64                  `protobuf:"varint,7,opt,name=limit_price_cents,json=limitPriceCents,proto3" json:"limit_price_cents,omitempty"`
+	TriggerPriceCents   int64                  `protobuf:"varint,8,opt,name=trigger_price_cents,json=triggerPriceCents,proto3" json:"trigger_price_cents,omitempty"`
+	TimeInForce         TimeInForce            `protobuf:"varint,9,opt,name=time_in_force,json=timeInForce,proto3,enum=common.types.TimeInForce" json:"time_in_force,omitempty"`
+	ExpiresAtMs         int64                  `protobuf:"varint,10,opt,name=expires_at_ms,json=expiresAtMs,proto3" json:"expires_at_ms,omitempty"`
+	DisplayQuantity     int64                  `protobuf:"varint,11,opt,name=display_quantity,json=displayQuantity,proto3" json:"display_quantity,omitempty"`
+	TrailingOffsetCents int64                  `protobuf:"varint,12,opt,name=trailing_offset_cents,json=trailingOffsetCents,proto3" json:"trailing_offset_cents,omitempty"`
+	TrailingOffsetBps   int64                  `protobuf:"varint,13,opt,name=trailing_offset_bps,json=trailingOffsetBps,proto3" json:"trailing_offset_bps,omitempty"`
+	ReservedCashCents   int64                  `protobuf:"varint,14,opt,name=reserved_cash_cents,json=reservedCashCents,proto3" json:"reserved_cash_cents,omitempty"`                                             // Cash a stop-market or trailing-stop buy may spend once triggered
+	SelfTradePrevention SelfTradePrevention    `protobuf:"varint,15,opt,name=self_trade_prevention,json=selfTradePrevention,proto3,enum=common.types.SelfTradePrevention" json:"self_trade_prevention,omitempty"` // Unspecified if the order uses the engine's mode
+	PostOnly            bool                   `protobuf:"varint,16,opt,name=post_only,json=postOnly,proto3" json:"post_only,omitempty"`
+	PostOnlyReprice     bool                   `protobuf:"varint,17,opt,name=post_only_reprice,json=postOnlyReprice,proto3" json:"post_only_reprice,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *OrderPlacedEvent) Reset() {
@@ -286,12 +582,83 @@ func (x *OrderPlacedEvent) GetLimitPriceCents() int64 {
 	return 0
 }
 
+func (x *OrderPlacedEvent) GetTriggerPriceCents() int64 {
+	if x != nil {
+		return x.TriggerPriceCents
+	}
+	return 0
+}
+
+func (x *OrderPlacedEvent) GetTimeInForce() TimeInForce {
+	if x != nil {
+		return x.TimeInForce
+	}
+	return TimeInForce_TIME_IN_FORCE_UNSPECIFIED
+}
+
+func (x *OrderPlacedEvent) GetExpiresAtMs() int64 {
+	if x != nil {
+		return x.ExpiresAtMs
+	}
+	return 0
+}
+
+func (x *OrderPlacedEvent) GetDisplayQuantity() int64 {
+	if x != nil {
+		return x.DisplayQuantity
+	}
+	return 0
+}
+
+func (x *OrderPlacedEvent) GetTrailingOffsetCents() int64 {
+	if x != nil {
+		return x.TrailingOffsetCents
+	}
+	return 0
+}
+
+func (x *OrderPlacedEvent) GetTrailingOffsetBps() int64 {
+	if x != nil {
+		return x.TrailingOffsetBps
+	}
+	return 0
+}
+
+func (x *OrderPlacedEvent) GetReservedCashCents() int64 {
+	if x != nil {
+		return x.ReservedCashCents
+	}
+	return 0
+}
+
+func (x *OrderPlacedEvent) GetSelfTradePrevention() SelfTradePrevention {
+	if x != nil {
+		return x.SelfTradePrevention
+	}
+	return SelfTradePrevention_SELF_TRADE_PREVENTION_UNSPECIFIED
+}
+
+func (x *OrderPlacedEvent) GetPostOnly() bool {
+	if x != nil {
+		return x.PostOnly
+	}
+	return false
+}
+
+func (x *OrderPlacedEvent) GetPostOnlyReprice() bool {
+	if x != nil {
+		return x.PostOnlyReprice
+	}
+	return false
+}
+
 // OrderCancelledEvent is emitted when an order is cancelled.
 type OrderCancelledEvent struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
 	OrderId           string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
 	TraderId          int64                  `protobuf:"varint,2,opt,name=trader_id,json=traderId,proto3" json:"trader_id,omitempty"`
-	RemainingQuantity int64                  `protobuf:"varint,4,opt,name=remaining_quantity,json=remainingQuantity,proto3" json:"remaining_quantity,omitempty"`
+	RemainingQuantity int64                  `protobuf:"varint,3,opt,name=remaining_quantity,json=remainingQuantity,proto3" json:"remaining_quantity,omitempty"`
+	Reason            CancelReason           `protobuf:"varint,4,opt,name=reason,proto3,enum=common.events.CancelReason" json:"reason,omitempty"`
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -347,13 +714,20 @@ func (x *OrderCancelledEvent) GetRemainingQuantity() int64 {
 	return 0
 }
 
+func (x *OrderCancelledEvent) GetReason() CancelReason {
+	if x != nil {
+		return x.Reason
+	}
+	return CancelReason_CANCEL_REASON_UNSPECIFIED
+}
+
 // OrderFilledEvent is emitted when an order is fully filled.
 type OrderFilledEvent struct {
 	state                 protoimpl.MessageState `protogen:"open.v1"`
 	OrderId               string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
 	TraderId              int64                  `protobuf:"varint,2,opt,name=trader_id,json=traderId,proto3" json:"trader_id,omitempty"`
-	TotalQuantity         int64                  `protobuf:"varint,4,opt,name=total_quantity,json=totalQuantity,proto3" json:"total_quantity,omitempty"`
-	AverageFillPriceCents int64                  `protobuf:"varint,5,opt,name=average_fill_price_cents,json=averageFillPriceCents,proto3" json:"average_fill_price_cents,omitempty"`
+	TotalQuantity         int64                  `protobuf:"varint,3,opt,name=total_quantity,json=totalQuantity,proto3" json:"total_quantity,omitempty"`
+	AverageFillPriceCents int64                  `protobuf:"varint,4,opt,name=average_fill_price_cents,json=averageFillPriceCents,proto3" json:"average_fill_price_cents,omitempty"`
 	unknownFields         protoimpl.UnknownFields
 	sizeCache             protoimpl.SizeCache
 }
@@ -421,9 +795,9 @@ type OrderPartiallyFilledEvent struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
 	OrderId           string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
 	TraderId          int64                  `protobuf:"varint,2,opt,name=trader_id,json=traderId,proto3" json:"trader_id,omitempty"`
-	FilledQuantity    int64                  `protobuf:"varint,4,opt,name=filled_quantity,json=filledQuantity,proto3" json:"filled_quantity,omitempty"`
-	RemainingQuantity int64                  `protobuf:"varint,5,opt,name=remaining_quantity,json=remainingQuantity,proto3" json:"remaining_quantity,omitempty"`
-	FillPriceCents    int64                  `protobuf:"varint,6,opt,name=fill_price_cents,json=fillPriceCents,proto3" json:"fill_price_cents,omitempty"`
+	FilledQuantity    int64                  `protobuf:"varint,3,opt,name=filled_quantity,json=filledQuantity,proto3" json:"filled_quantity,omitempty"`
+	RemainingQuantity int64                  `protobuf:"varint,4,opt,name=remaining_quantity,json=remainingQuantity,proto3" json:"remaining_quantity,omitempty"`
+	FillPriceCents    int64                  `protobuf:"varint,5,opt,name=fill_price_cents,json=fillPriceCents,proto3" json:"fill_price_cents,omitempty"`
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -498,8 +872,8 @@ type OrderRejectedEvent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
 	TraderId      int64                  `protobuf:"varint,2,opt,name=trader_id,json=traderId,proto3" json:"trader_id,omitempty"`
-	Reason        ErrorCode              `protobuf:"varint,4,opt,name=reason,proto3,enum=common.types.ErrorCode" json:"reason,omitempty"`
-	ErrorMessage  string                 `protobuf:"bytes,5,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	Reason        ErrorCode              `protobuf:"varint,3,opt,name=reason,proto3,enum=common.types.ErrorCode" json:"reason,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -663,70 +1037,861 @@ func (x *TradeExecutedEvent) GetTotalValueCents() int64 {
 	return 0
 }
 
-var File_proto_v1_common_events_proto protoreflect.FileDescriptor
+// OrderTriggeredEvent is emitted when a stop order's trigger price trades and
+// the order is released into the book as a MARKET or LIMIT order.
+type OrderTriggeredEvent struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	OrderId           string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	TraderId          int64                  `protobuf:"varint,2,opt,name=trader_id,json=traderId,proto3" json:"trader_id,omitempty"`
+	StockTicker       string                 `protobuf:"bytes,3,opt,name=stock_ticker,json=stockTicker,proto3" json:"stock_ticker,omitempty"`
+	OrderType         OrderType              `protobuf:"varint,4,opt,name=order_type,json=orderType,proto3,enum=common.types.OrderType" json:"order_type,omitempty"`
+	Side              OrderSide              `protobuf:"varint,5,opt,name=side,proto3,enum=common.types.OrderSide" json:"side,omitempty"`
+	Quantity          int64                  `protobuf:"varint,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	TriggerPriceCents int64                  `protobuf:"varint,7,opt,name=trigger_price_cents,json=triggerPriceCents,proto3" json:"trigger_price_cents,omitempty"`
+	TradePriceCents   int64                  `protobuf:"varint,8,opt,name=trade_price_cents,json=tradePriceCents,proto3" json:"trade_price_cents,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
 
-const file_proto_v1_common_events_proto_rawDesc = "" +
-	"\n" +
-	"\x1cproto/v1/common/events.proto\x12\rcommon.events\x1a\x1bproto/v1/common/types.proto\"\xcd\x04\n" +
-	"\vEngineEvent\x12\x19\n" +
-	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12!\n" +
-	"\ftimestamp_ms\x18\x02 \x01(\x03R\vtimestampMs\x127\n" +
-	"\n" +
-	"event_type\x18\x03 \x01(\x0e2\x18.common.events.EventTypeR\teventType\x12B\n" +
-	"\forder_placed\x18\n" +
-	" \x01(\v2\x1f.common.events.OrderPlacedEventR\vorderPlaced\x12K\n" +
-	"\x0forder_cancelled\x18\v \x01(\v2\".common.events.OrderCancelledEventR\x0eorderCancelled\x12B\n" +
-	"\forder_filled\x18\f \x01(\v2\x1f.common.events.OrderFilledEventR\vorderFilled\x12^\n" +
-	"\x16order_partially_filled\x18\r \x01(\v2(.common.events.OrderPartiallyFilledEventR\x14orderPartiallyFilled\x12H\n" +
-	"\x0eorder_rejected\x18\x0e \x01(\v2!.common.events.OrderRejectedEventR\rorderRejected\x12H\n" +
-	"\x0etrade_executed\x18\x0f \x01(\v2!.common.events.TradeExecutedEventR\rtradeExecuted\"\x9a\x02\n" +
-	"\x10OrderPlacedEvent\x12\x19\n" +
-	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1b\n" +
-	"\ttrader_id\x18\x02 \x01(\x03R\btraderId\x12!\n" +
-	"\fstock_ticker\x18\x04 \x01(\tR\vstockTicker\x126\n" +
-	"\n" +
-	"order_type\x18\x05 \x01(\x0e2\x17.common.types.OrderTypeR\torderType\x12+\n" +
-	"\x04side\x18\x06 \x01(\x0e2\x17.common.types.OrderSideR\x04side\x12\x1a\n" +
-	"\bquantity\x18\a \x01(\x03R\bquantity\x12*\n" +
-	"\x11limit_price_cents\x18\b \x01(\x03R\x0flimitPriceCents\"|\n" +
-	"\x13OrderCancelledEvent\x12\x19\n" +
-	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1b\n" +
-	"\ttrader_id\x18\x02 \x01(\x03R\btraderId\x12-\n" +
-	"\x12remaining_quantity\x18\x04 \x01(\x03R\x11remainingQuantity\"\xaa\x01\n" +
-	"\x10OrderFilledEvent\x12\x19\n" +
-	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1b\n" +
-	"\ttrader_id\x18\x02 \x01(\x03R\btraderId\x12%\n" +
-	"\x0etotal_quantity\x18\x04 \x01(\x03R\rtotalQuantity\x127\n" +
-	"\x18average_fill_price_cents\x18\x05 \x01(\x03R\x15averageFillPriceCents\"\xd5\x01\n" +
-	"\x19OrderPartiallyFilledEvent\x12\x19\n" +
-	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1b\n" +
-	"\ttrader_id\x18\x02 \x01(\x03R\btraderId\x12'\n" +
-	"\x0ffilled_quantity\x18\x04 \x01(\x03R\x0efilledQuantity\x12-\n" +
-	"\x12remaining_quantity\x18\x05 \x01(\x03R\x11remainingQuantity\x12(\n" +
-	"\x10fill_price_cents\x18\x06 \x01(\x03R\x0efillPriceCents\"\xa2\x01\n" +
-	"\x12OrderRejectedEvent\x12\x19\n" +
-	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1b\n" +
-	"\ttrader_id\x18\x02 \x01(\x03R\btraderId\x12/\n" +
-	"\x06reason\x18\x04 \x01(\x0e2\x17.common.types.ErrorCodeR\x06reason\x12#\n" +
-	"\rerror_message\x18\x05 \x01(\tR\ferrorMessage\"\xc0\x02\n" +
-	"\x12TradeExecutedEvent\x12!\n" +
-	"\fstock_ticker\x18\x01 \x01(\tR\vstockTicker\x12$\n" +
-	"\x0ebuyer_order_id\x18\x02 \x01(\tR\fbuyerOrderId\x12&\n" +
-	"\x0fseller_order_id\x18\x03 \x01(\tR\rsellerOrderId\x12&\n" +
-	"\x0fbuyer_trader_id\x18\x04 \x01(\x03R\rbuyerTraderId\x12(\n" +
-	"\x10seller_trader_id\x18\x05 \x01(\x03R\x0esellerTraderId\x12\x1a\n" +
-	"\bquantity\x18\x06 \x01(\x03R\bquantity\x12\x1f\n" +
-	"\vprice_cents\x18\a \x01(\x03R\n" +
-	"priceCents\x12*\n" +
-	"\x11total_value_cents\x18\b \x01(\x03R\x0ftotalValueCents*\xa4\x01\n" +
-	"\tEventType\x12\x1a\n" +
-	"\x16EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x10\n" +
-	"\fORDER_PLACED\x10\x01\x12\x13\n" +
-	"\x0fORDER_CANCELLED\x10\x02\x12\x10\n" +
-	"\fORDER_FILLED\x10\x03\x12\x1a\n" +
-	"\x16ORDER_PARTIALLY_FILLED\x10\x04\x12\x12\n" +
-	"\x0eORDER_REJECTED\x10\x05\x12\x12\n" +
-	"\x0eTRADE_EXECUTED\x10\x06BDZBgithub.com/Marwan051/tradding_platform_game/proto/gen/go/v1/commonb\x06proto3"
+func (x *OrderTriggeredEvent) Reset() {
+	*x = OrderTriggeredEvent{}
+	mi := &file_proto_v1_common_events_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderTriggeredEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderTriggeredEvent) ProtoMessage() {}
+
+func (x *OrderTriggeredEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_common_events_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderTriggeredEvent.ProtoReflect.Descriptor instead.
+func (*OrderTriggeredEvent) Descriptor() ([]byte, []int) {
+	return file_proto_v1_common_events_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *OrderTriggeredEvent) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *OrderTriggeredEvent) GetTraderId() int64 {
+	if x != nil {
+		return x.TraderId
+	}
+	return 0
+}
+
+func (x *OrderTriggeredEvent) GetStockTicker() string {
+	if x != nil {
+		return x.StockTicker
+	}
+	return ""
+}
+
+func (x *OrderTriggeredEvent) GetOrderType() OrderType {
+	if x != nil {
+		return x.OrderType
+	}
+	return OrderType_ORDER_TYPE_UNSPECIFIED
+}
+
+func (x *OrderTriggeredEvent) GetSide() OrderSide {
+	if x != nil {
+		return x.Side
+	}
+	return OrderSide_ORDER_SIDE_UNSPECIFIED
+}
+
+func (x *OrderTriggeredEvent) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *OrderTriggeredEvent) GetTriggerPriceCents() int64 {
+	if x != nil {
+		return x.TriggerPriceCents
+	}
+	return 0
+}
+
+func (x *OrderTriggeredEvent) GetTradePriceCents() int64 {
+	if x != nil {
+		return x.TradePriceCents
+	}
+	return 0
+}
+
+// TrailingStopMovedEvent is emitted when a trade moves the trigger of a held trailing stop.
+type TrailingStopMovedEvent struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	OrderId           string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	TraderId          int64                  `protobuf:"varint,2,opt,name=trader_id,json=traderId,proto3" json:"trader_id,omitempty"`
+	StockTicker       string                 `protobuf:"bytes,3,opt,name=stock_ticker,json=stockTicker,proto3" json:"stock_ticker,omitempty"`
+	Side              OrderSide              `protobuf:"varint,4,opt,name=side,proto3,enum=common.types.OrderSide" json:"side,omitempty"`
+	TriggerPriceCents int64                  `protobuf:"varint,5,opt,name=trigger_price_cents,json=triggerPriceCents,proto3" json:"trigger_price_cents,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *TrailingStopMovedEvent) Reset() {
+	*x = TrailingStopMovedEvent{}
+	mi := &file_proto_v1_common_events_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrailingStopMovedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrailingStopMovedEvent) ProtoMessage() {}
+
+func (x *TrailingStopMovedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_common_events_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrailingStopMovedEvent.ProtoReflect.Descriptor instead.
+func (*TrailingStopMovedEvent) Descriptor() ([]byte, []int) {
+	return file_proto_v1_common_events_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TrailingStopMovedEvent) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *TrailingStopMovedEvent) GetTraderId() int64 {
+	if x != nil {
+		return x.TraderId
+	}
+	return 0
+}
+
+func (x *TrailingStopMovedEvent) GetStockTicker() string {
+	if x != nil {
+		return x.StockTicker
+	}
+	return ""
+}
+
+func (x *TrailingStopMovedEvent) GetSide() OrderSide {
+	if x != nil {
+		return x.Side
+	}
+	return OrderSide_ORDER_SIDE_UNSPECIFIED
+}
+
+func (x *TrailingStopMovedEvent) GetTriggerPriceCents() int64 {
+	if x != nil {
+		return x.TriggerPriceCents
+	}
+	return 0
+}
+
+// OrderAmendedEvent is emitted when a resting order's quantity or limit price changes.
+// Quantities are remaining quantities before and after the amend.
+type OrderAmendedEvent struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	OrderId            string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	TraderId           int64                  `protobuf:"varint,2,opt,name=trader_id,json=traderId,proto3" json:"trader_id,omitempty"`
+	StockTicker        string                 `protobuf:"bytes,3,opt,name=stock_ticker,json=stockTicker,proto3" json:"stock_ticker,omitempty"`
+	OrderType          OrderType              `protobuf:"varint,4,opt,name=order_type,json=orderType,proto3,enum=common.types.OrderType" json:"order_type,omitempty"`
+	Side               OrderSide              `protobuf:"varint,5,opt,name=side,proto3,enum=common.types.OrderSide" json:"side,omitempty"`
+	OldQuantity        int64                  `protobuf:"varint,6,opt,name=old_quantity,json=oldQuantity,proto3" json:"old_quantity,omitempty"`
+	NewQuantity        int64                  `protobuf:"varint,7,opt,name=new_quantity,json=newQuantity,proto3" json:"new_quantity,omitempty"`
+	OldLimitPriceCents int64                  `protobuf:"varint,8,opt,name=old_limit_price_cents,json=oldLimitPriceCents,proto3" json:"old_limit_price_cents,omitempty"`
+	NewLimitPriceCents int64                  `protobuf:"varint,9,opt,name=new_limit_price_cents,json=newLimitPriceCents,proto3" json:"new_limit_price_cents,omitempty"`
+	Requeued           bool                   `protobuf:"varint,10,opt,name=requeued,proto3" json:"requeued,omitempty"`                                              // False when a quantity reduction kept queue priority
+	ReservedCashCents  int64                  `protobuf:"varint,11,opt,name=reserved_cash_cents,json=reservedCashCents,proto3" json:"reserved_cash_cents,omitempty"` // Reserve of a stop-market or trailing-stop buy after the amend
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *OrderAmendedEvent) Reset() {
+	*x = OrderAmendedEvent{}
+	mi := &file_proto_v1_common_events_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderAmendedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderAmendedEvent) ProtoMessage() {}
+
+func (x *OrderAmendedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_common_events_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderAmendedEvent.ProtoReflect.Descriptor instead.
+func (*OrderAmendedEvent) Descriptor() ([]byte, []int) {
+	return file_proto_v1_common_events_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *OrderAmendedEvent) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *OrderAmendedEvent) GetTraderId() int64 {
+	if x != nil {
+		return x.TraderId
+	}
+	return 0
+}
+
+func (x *OrderAmendedEvent) GetStockTicker() string {
+	if x != nil {
+		return x.StockTicker
+	}
+	return ""
+}
+
+func (x *OrderAmendedEvent) GetOrderType() OrderType {
+	if x != nil {
+		return x.OrderType
+	}
+	return OrderType_ORDER_TYPE_UNSPECIFIED
+}
+
+func (x *OrderAmendedEvent) GetSide() OrderSide {
+	if x != nil {
+		return x.Side
+	}
+	return OrderSide_ORDER_SIDE_UNSPECIFIED
+}
+
+func (x *OrderAmendedEvent) GetOldQuantity() int64 {
+	if x != nil {
+		return x.OldQuantity
+	}
+	return 0
+}
+
+func (x *OrderAmendedEvent) GetNewQuantity() int64 {
+	if x != nil {
+		return x.NewQuantity
+	}
+	return 0
+}
+
+func (x *OrderAmendedEvent) GetOldLimitPriceCents() int64 {
+	if x != nil {
+		return x.OldLimitPriceCents
+	}
+	return 0
+}
+
+func (x *OrderAmendedEvent) GetNewLimitPriceCents() int64 {
+	if x != nil {
+		return x.NewLimitPriceCents
+	}
+	return 0
+}
+
+func (x *OrderAmendedEvent) GetRequeued() bool {
+	if x != nil {
+		return x.Requeued
+	}
+	return false
+}
+
+func (x *OrderAmendedEvent) GetReservedCashCents() int64 {
+	if x != nil {
+		return x.ReservedCashCents
+	}
+	return 0
+}
+
+// SelfTradePreventedEvent is emitted for every match skipped because both orders
+// belong to the same trader or owner group.
+type SelfTradePreventedEvent struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	StockTicker      string                 `protobuf:"bytes,1,opt,name=stock_ticker,json=stockTicker,proto3" json:"stock_ticker,omitempty"`
+	IncomingOrderId  string                 `protobuf:"bytes,2,opt,name=incoming_order_id,json=incomingOrderId,proto3" json:"incoming_order_id,omitempty"`
+	RestingOrderId   string                 `protobuf:"bytes,3,opt,name=resting_order_id,json=restingOrderId,proto3" json:"resting_order_id,omitempty"`
+	IncomingTraderId int64                  `protobuf:"varint,4,opt,name=incoming_trader_id,json=incomingTraderId,proto3" json:"incoming_trader_id,omitempty"`
+	RestingTraderId  int64                  `protobuf:"varint,5,opt,name=resting_trader_id,json=restingTraderId,proto3" json:"resting_trader_id,omitempty"`
+	Mode             SelfTradePrevention    `protobuf:"varint,6,opt,name=mode,proto3,enum=common.types.SelfTradePrevention" json:"mode,omitempty"`
+	Quantity         int64                  `protobuf:"varint,7,opt,name=quantity,proto3" json:"quantity,omitempty"` // Quantity that would have traded
+	PriceCents       int64                  `protobuf:"varint,8,opt,name=price_cents,json=priceCents,proto3" json:"price_cents,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SelfTradePreventedEvent) Reset() {
+	*x = SelfTradePreventedEvent{}
+	mi := &file_proto_v1_common_events_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SelfTradePreventedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelfTradePreventedEvent) ProtoMessage() {}
+
+func (x *SelfTradePreventedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_common_events_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelfTradePreventedEvent.ProtoReflect.Descriptor instead.
+func (*SelfTradePreventedEvent) Descriptor() ([]byte, []int) {
+	return file_proto_v1_common_events_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SelfTradePreventedEvent) GetStockTicker() string {
+	if x != nil {
+		return x.StockTicker
+	}
+	return ""
+}
+
+func (x *SelfTradePreventedEvent) GetIncomingOrderId() string {
+	if x != nil {
+		return x.IncomingOrderId
+	}
+	return ""
+}
+
+func (x *SelfTradePreventedEvent) GetRestingOrderId() string {
+	if x != nil {
+		return x.RestingOrderId
+	}
+	return ""
+}
+
+func (x *SelfTradePreventedEvent) GetIncomingTraderId() int64 {
+	if x != nil {
+		return x.IncomingTraderId
+	}
+	return 0
+}
+
+func (x *SelfTradePreventedEvent) GetRestingTraderId() int64 {
+	if x != nil {
+		return x.RestingTraderId
+	}
+	return 0
+}
+
+func (x *SelfTradePreventedEvent) GetMode() SelfTradePrevention {
+	if x != nil {
+		return x.Mode
+	}
+	return SelfTradePrevention_SELF_TRADE_PREVENTION_UNSPECIFIED
+}
+
+func (x *SelfTradePreventedEvent) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *SelfTradePreventedEvent) GetPriceCents() int64 {
+	if x != nil {
+		return x.PriceCents
+	}
+	return 0
+}
+
+// AuctionUncrossedEvent is emitted when an opening or closing auction ends.
+type AuctionUncrossedEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StockTicker   string                 `protobuf:"bytes,1,opt,name=stock_ticker,json=stockTicker,proto3" json:"stock_ticker,omitempty"`
+	PriceCents    int64                  `protobuf:"varint,2,opt,name=price_cents,json=priceCents,proto3" json:"price_cents,omitempty"` // Uncrossing price, or the last trade price if nothing crossed
+	Volume        int64                  `protobuf:"varint,3,opt,name=volume,proto3" json:"volume,omitempty"`
+	Closing       bool                   `protobuf:"varint,4,opt,name=closing,proto3" json:"closing,omitempty"` // The closing auction's price becomes the previous close
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuctionUncrossedEvent) Reset() {
+	*x = AuctionUncrossedEvent{}
+	mi := &file_proto_v1_common_events_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuctionUncrossedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuctionUncrossedEvent) ProtoMessage() {}
+
+func (x *AuctionUncrossedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_common_events_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuctionUncrossedEvent.ProtoReflect.Descriptor instead.
+func (*AuctionUncrossedEvent) Descriptor() ([]byte, []int) {
+	return file_proto_v1_common_events_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AuctionUncrossedEvent) GetStockTicker() string {
+	if x != nil {
+		return x.StockTicker
+	}
+	return ""
+}
+
+func (x *AuctionUncrossedEvent) GetPriceCents() int64 {
+	if x != nil {
+		return x.PriceCents
+	}
+	return 0
+}
+
+func (x *AuctionUncrossedEvent) GetVolume() int64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+func (x *AuctionUncrossedEvent) GetClosing() bool {
+	if x != nil {
+		return x.Closing
+	}
+	return false
+}
+
+// TradingHaltedEvent is emitted when an order would have traded outside a price band.
+type TradingHaltedEvent struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	StockTicker         string                 `protobuf:"bytes,1,opt,name=stock_ticker,json=stockTicker,proto3" json:"stock_ticker,omitempty"`
+	Reason              HaltReason             `protobuf:"varint,2,opt,name=reason,proto3,enum=common.events.HaltReason" json:"reason,omitempty"`
+	PriceCents          int64                  `protobuf:"varint,3,opt,name=price_cents,json=priceCents,proto3" json:"price_cents,omitempty"` // Price of the trade that was prevented
+	ReferencePriceCents int64                  `protobuf:"varint,4,opt,name=reference_price_cents,json=referencePriceCents,proto3" json:"reference_price_cents,omitempty"`
+	LastTradePriceCents int64                  `protobuf:"varint,5,opt,name=last_trade_price_cents,json=lastTradePriceCents,proto3" json:"last_trade_price_cents,omitempty"`
+	HaltedUntilMs       int64                  `protobuf:"varint,6,opt,name=halted_until_ms,json=haltedUntilMs,proto3" json:"halted_until_ms,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *TradingHaltedEvent) Reset() {
+	*x = TradingHaltedEvent{}
+	mi := &file_proto_v1_common_events_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TradingHaltedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TradingHaltedEvent) ProtoMessage() {}
+
+func (x *TradingHaltedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_common_events_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TradingHaltedEvent.ProtoReflect.Descriptor instead.
+func (*TradingHaltedEvent) Descriptor() ([]byte, []int) {
+	return file_proto_v1_common_events_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TradingHaltedEvent) GetStockTicker() string {
+	if x != nil {
+		return x.StockTicker
+	}
+	return ""
+}
+
+func (x *TradingHaltedEvent) GetReason() HaltReason {
+	if x != nil {
+		return x.Reason
+	}
+	return HaltReason_HALT_REASON_UNSPECIFIED
+}
+
+func (x *TradingHaltedEvent) GetPriceCents() int64 {
+	if x != nil {
+		return x.PriceCents
+	}
+	return 0
+}
+
+func (x *TradingHaltedEvent) GetReferencePriceCents() int64 {
+	if x != nil {
+		return x.ReferencePriceCents
+	}
+	return 0
+}
+
+func (x *TradingHaltedEvent) GetLastTradePriceCents() int64 {
+	if x != nil {
+		return x.LastTradePriceCents
+	}
+	return 0
+}
+
+func (x *TradingHaltedEvent) GetHaltedUntilMs() int64 {
+	if x != nil {
+		return x.HaltedUntilMs
+	}
+	return 0
+}
+
+// TradingResumedEvent is emitted when a halt ends with a reopening auction.
+type TradingResumedEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StockTicker   string                 `protobuf:"bytes,1,opt,name=stock_ticker,json=stockTicker,proto3" json:"stock_ticker,omitempty"`
+	PriceCents    int64                  `protobuf:"varint,2,opt,name=price_cents,json=priceCents,proto3" json:"price_cents,omitempty"` // Reopening price, or the last trade price if nothing crossed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TradingResumedEvent) Reset() {
+	*x = TradingResumedEvent{}
+	mi := &file_proto_v1_common_events_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TradingResumedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TradingResumedEvent) ProtoMessage() {}
+
+func (x *TradingResumedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_common_events_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TradingResumedEvent.ProtoReflect.Descriptor instead.
+func (*TradingResumedEvent) Descriptor() ([]byte, []int) {
+	return file_proto_v1_common_events_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TradingResumedEvent) GetStockTicker() string {
+	if x != nil {
+		return x.StockTicker
+	}
+	return ""
+}
+
+func (x *TradingResumedEvent) GetPriceCents() int64 {
+	if x != nil {
+		return x.PriceCents
+	}
+	return 0
+}
+
+// SessionPhaseChangedEvent is emitted when the market moves to another phase of its trading day.
+type SessionPhaseChangedEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Phase         SessionPhase           `protobuf:"varint,1,opt,name=phase,proto3,enum=common.events.SessionPhase" json:"phase,omitempty"`
+	PreviousPhase SessionPhase           `protobuf:"varint,2,opt,name=previous_phase,json=previousPhase,proto3,enum=common.events.SessionPhase" json:"previous_phase,omitempty"`
+	ChangedAtMs   int64                  `protobuf:"varint,3,opt,name=changed_at_ms,json=changedAtMs,proto3" json:"changed_at_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionPhaseChangedEvent) Reset() {
+	*x = SessionPhaseChangedEvent{}
+	mi := &file_proto_v1_common_events_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionPhaseChangedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionPhaseChangedEvent) ProtoMessage() {}
+
+func (x *SessionPhaseChangedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_common_events_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionPhaseChangedEvent.ProtoReflect.Descriptor instead.
+func (*SessionPhaseChangedEvent) Descriptor() ([]byte, []int) {
+	return file_proto_v1_common_events_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SessionPhaseChangedEvent) GetPhase() SessionPhase {
+	if x != nil {
+		return x.Phase
+	}
+	return SessionPhase_SESSION_PHASE_UNSPECIFIED
+}
+
+func (x *SessionPhaseChangedEvent) GetPreviousPhase() SessionPhase {
+	if x != nil {
+		return x.PreviousPhase
+	}
+	return SessionPhase_SESSION_PHASE_UNSPECIFIED
+}
+
+func (x *SessionPhaseChangedEvent) GetChangedAtMs() int64 {
+	if x != nil {
+		return x.ChangedAtMs
+	}
+	return 0
+}
+
+var File_proto_v1_common_events_proto protoreflect.FileDescriptor
+
+const file_proto_v1_common_events_proto_rawDesc = "" +
+	"\n" +
+	"\x1cproto/v1/common/events.proto\x12\rcommon.events\x1a\x1bproto/v1/common/types.proto\"\xd5\n" +
+	"\n" +
+	"\vEngineEvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12!\n" +
+	"\ftimestamp_ms\x18\x02 \x01(\x03R\vtimestampMs\x127\n" +
+	"\n" +
+	"event_type\x18\x03 \x01(\x0e2\x18.common.events.EventTypeR\teventType\x12\x1a\n" +
+	"\bsequence\x18\x04 \x01(\x03R\bsequence\x12!\n" +
+	"\fstock_ticker\x18\x05 \x01(\tR\vstockTicker\x12%\n" +
+	"\x0estock_sequence\x18\x06 \x01(\x03R\rstockSequence\x12\x14\n" +
+	"\x05epoch\x18\a \x01(\tR\x05epoch\x12B\n" +
+	"\forder_placed\x18\n" +
+	" \x01(\v2\x1f.common.events.OrderPlacedEventR\vorderPlaced\x12K\n" +
+	"\x0forder_cancelled\x18\v \x01(\v2\".common.events.OrderCancelledEventR\x0eorderCancelled\x12B\n" +
+	"\forder_filled\x18\f \x01(\v2\x1f.common.events.OrderFilledEventR\vorderFilled\x12^\n" +
+	"\x16order_partially_filled\x18\r \x01(\v2(.common.events.OrderPartiallyFilledEventR\x14orderPartiallyFilled\x12H\n" +
+	"\x0eorder_rejected\x18\x0e \x01(\v2!.common.events.OrderRejectedEventR\rorderRejected\x12H\n" +
+	"\x0etrade_executed\x18\x0f \x01(\v2!.common.events.TradeExecutedEventR\rtradeExecuted\x12K\n" +
+	"\x0forder_triggered\x18\x10 \x01(\v2\".common.events.OrderTriggeredEventR\x0eorderTriggered\x12E\n" +
+	"\rorder_amended\x18\x11 \x01(\v2 .common.events.OrderAmendedEventR\forderAmended\x12X\n" +
+	"\x14self_trade_prevented\x18\x12 \x01(\v2&.common.events.SelfTradePreventedEventR\x12selfTradePrevented\x12Q\n" +
+	"\x11auction_uncrossed\x18\x13 \x01(\v2$.common.events.AuctionUncrossedEventR\x10auctionUncrossed\x12H\n" +
+	"\x0etrading_halted\x18\x14 \x01(\v2!.common.events.TradingHaltedEventR\rtradingHalted\x12K\n" +
+	"\x0ftrading_resumed\x18\x15 \x01(\v2\".common.events.TradingResumedEventR\x0etradingResumed\x12[\n" +
+	"\x15session_phase_changed\x18\x16 \x01(\v2'.common.events.SessionPhaseChangedEventR\x13sessionPhaseChanged\x12U\n" +
+	"\x13trailing_stop_moved\x18\x17 \x01(\v2%.common.events.TrailingStopMovedEventR\x11trailingStopMoved\"\x8c\x06\n" +
+	"\x10OrderPlacedEvent\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1b\n" +
+	"\ttrader_id\x18\x02 \x01(\x03R\btraderId\x12!\n" +
+	"\fstock_ticker\x18\x03 \x01(\tR\vstockTicker\x126\n" +
+	"\n" +
+	"order_type\x18\x04 \x01(\x0e2\x17.common.types.OrderTypeR\torderType\x12+\n" +
+	"\x04side\x18\x05 \x01(\x0e2\x17.common.types.OrderSideR\x04side\x12\x1a\n" +
+	"\bquantity\x18\x06 \x01(\x03R\bquantity\x12*\n" +
+	"\x11limit_price_cents\x18\a \x01(\x03R\x0flimitPriceCents\x12.\n" +
+	"\x13trigger_price_cents\x18\b \x01(\x03R\x11triggerPriceCents\x12=\n" +
+	"\rtime_in_force\x18\t \x01(\x0e2\x19.common.types.TimeInForceR\vtimeInForce\x12\"\n" +
+	"\rexpires_at_ms\x18\n" +
+	" \x01(\x03R\vexpiresAtMs\x12)\n" +
+	"\x10display_quantity\x18\v \x01(\x03R\x0fdisplayQuantity\x122\n" +
+	"\x15trailing_offset_cents\x18\f \x01(\x03R\x13trailingOffsetCents\x12.\n" +
+	"\x13trailing_offset_bps\x18\r \x01(\x03R\x11trailingOffsetBps\x12.\n" +
+	"\x13reserved_cash_cents\x18\x0e \x01(\x03R\x11reservedCashCents\x12U\n" +
+	"\x15self_trade_prevention\x18\x0f \x01(\x0e2!.common.types.SelfTradePreventionR\x13selfTradePrevention\x12\x1b\n" +
+	"\tpost_only\x18\x10 \x01(\bR\bpostOnly\x12*\n" +
+	"\x11post_only_reprice\x18\x11 \x01(\bR\x0fpostOnlyReprice\"\xb1\x01\n" +
+	"\x13OrderCancelledEvent\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1b\n" +
+	"\ttrader_id\x18\x02 \x01(\x03R\btraderId\x12-\n" +
+	"\x12remaining_quantity\x18\x03 \x01(\x03R\x11remainingQuantity\x123\n" +
+	"\x06reason\x18\x04 \x01(\x0e2\x1b.common.events.CancelReasonR\x06reason\"\xaa\x01\n" +
+	"\x10OrderFilledEvent\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1b\n" +
+	"\ttrader_id\x18\x02 \x01(\x03R\btraderId\x12%\n" +
+	"\x0etotal_quantity\x18\x03 \x01(\x03R\rtotalQuantity\x127\n" +
+	"\x18average_fill_price_cents\x18\x04 \x01(\x03R\x15averageFillPriceCents\"\xd5\x01\n" +
+	"\x19OrderPartiallyFilledEvent\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1b\n" +
+	"\ttrader_id\x18\x02 \x01(\x03R\btraderId\x12'\n" +
+	"\x0ffilled_quantity\x18\x03 \x01(\x03R\x0efilledQuantity\x12-\n" +
+	"\x12remaining_quantity\x18\x04 \x01(\x03R\x11remainingQuantity\x12(\n" +
+	"\x10fill_price_cents\x18\x05 \x01(\x03R\x0efillPriceCents\"\xa2\x01\n" +
+	"\x12OrderRejectedEvent\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1b\n" +
+	"\ttrader_id\x18\x02 \x01(\x03R\btraderId\x12/\n" +
+	"\x06reason\x18\x03 \x01(\x0e2\x17.common.types.ErrorCodeR\x06reason\x12#\n" +
+	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\"\xc0\x02\n" +
+	"\x12TradeExecutedEvent\x12!\n" +
+	"\fstock_ticker\x18\x01 \x01(\tR\vstockTicker\x12$\n" +
+	"\x0ebuyer_order_id\x18\x02 \x01(\tR\fbuyerOrderId\x12&\n" +
+	"\x0fseller_order_id\x18\x03 \x01(\tR\rsellerOrderId\x12&\n" +
+	"\x0fbuyer_trader_id\x18\x04 \x01(\x03R\rbuyerTraderId\x12(\n" +
+	"\x10seller_trader_id\x18\x05 \x01(\x03R\x0esellerTraderId\x12\x1a\n" +
+	"\bquantity\x18\x06 \x01(\x03R\bquantity\x12\x1f\n" +
+	"\vprice_cents\x18\a \x01(\x03R\n" +
+	"priceCents\x12*\n" +
+	"\x11total_value_cents\x18\b \x01(\x03R\x0ftotalValueCents\"\xcd\x02\n" +
+	"\x13OrderTriggeredEvent\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1b\n" +
+	"\ttrader_id\x18\x02 \x01(\x03R\btraderId\x12!\n" +
+	"\fstock_ticker\x18\x03 \x01(\tR\vstockTicker\x126\n" +
+	"\n" +
+	"order_type\x18\x04 \x01(\x0e2\x17.common.types.OrderTypeR\torderType\x12+\n" +
+	"\x04side\x18\x05 \x01(\x0e2\x17.common.types.OrderSideR\x04side\x12\x1a\n" +
+	"\bquantity\x18\x06 \x01(\x03R\bquantity\x12.\n" +
+	"\x13trigger_price_cents\x18\a \x01(\x03R\x11triggerPriceCents\x12*\n" +
+	"\x11trade_price_cents\x18\b \x01(\x03R\x0ftradePriceCents\"\xd0\x01\n" +
+	"\x16TrailingStopMovedEvent\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1b\n" +
+	"\ttrader_id\x18\x02 \x01(\x03R\btraderId\x12!\n" +
+	"\fstock_ticker\x18\x03 \x01(\tR\vstockTicker\x12+\n" +
+	"\x04side\x18\x04 \x01(\x0e2\x17.common.types.OrderSideR\x04side\x12.\n" +
+	"\x13trigger_price_cents\x18\x05 \x01(\x03R\x11triggerPriceCents\"\xcb\x03\n" +
+	"\x11OrderAmendedEvent\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1b\n" +
+	"\ttrader_id\x18\x02 \x01(\x03R\btraderId\x12!\n" +
+	"\fstock_ticker\x18\x03 \x01(\tR\vstockTicker\x126\n" +
+	"\n" +
+	"order_type\x18\x04 \x01(\x0e2\x17.common.types.OrderTypeR\torderType\x12+\n" +
+	"\x04side\x18\x05 \x01(\x0e2\x17.common.types.OrderSideR\x04side\x12!\n" +
+	"\fold_quantity\x18\x06 \x01(\x03R\voldQuantity\x12!\n" +
+	"\fnew_quantity\x18\a \x01(\x03R\vnewQuantity\x121\n" +
+	"\x15old_limit_price_cents\x18\b \x01(\x03R\x12oldLimitPriceCents\x121\n" +
+	"\x15new_limit_price_cents\x18\t \x01(\x03R\x12newLimitPriceCents\x12\x1a\n" +
+	"\brequeued\x18\n" +
+	" \x01(\bR\brequeued\x12.\n" +
+	"\x13reserved_cash_cents\x18\v \x01(\x03R\x11reservedCashCents\"\xe0\x02\n" +
+	"\x17SelfTradePreventedEvent\x12!\n" +
+	"\fstock_ticker\x18\x01 \x01(\tR\vstockTicker\x12*\n" +
+	"\x11incoming_order_id\x18\x02 \x01(\tR\x0fincomingOrderId\x12(\n" +
+	"\x10resting_order_id\x18\x03 \x01(\tR\x0erestingOrderId\x12,\n" +
+	"\x12incoming_trader_id\x18\x04 \x01(\x03R\x10incomingTraderId\x12*\n" +
+	"\x11resting_trader_id\x18\x05 \x01(\x03R\x0frestingTraderId\x125\n" +
+	"\x04mode\x18\x06 \x01(\x0e2!.common.types.SelfTradePreventionR\x04mode\x12\x1a\n" +
+	"\bquantity\x18\a \x01(\x03R\bquantity\x12\x1f\n" +
+	"\vprice_cents\x18\b \x01(\x03R\n" +
+	"priceCents\"\x8d\x01\n" +
+	"\x15AuctionUncrossedEvent\x12!\n" +
+	"\fstock_ticker\x18\x01 \x01(\tR\vstockTicker\x12\x1f\n" +
+	"\vprice_cents\x18\x02 \x01(\x03R\n" +
+	"priceCents\x12\x16\n" +
+	"\x06volume\x18\x03 \x01(\x03R\x06volume\x12\x18\n" +
+	"\aclosing\x18\x04 \x01(\bR\aclosing\"\x9c\x02\n" +
+	"\x12TradingHaltedEvent\x12!\n" +
+	"\fstock_ticker\x18\x01 \x01(\tR\vstockTicker\x121\n" +
+	"\x06reason\x18\x02 \x01(\x0e2\x19.common.events.HaltReasonR\x06reason\x12\x1f\n" +
+	"\vprice_cents\x18\x03 \x01(\x03R\n" +
+	"priceCents\x122\n" +
+	"\x15reference_price_cents\x18\x04 \x01(\x03R\x13referencePriceCents\x123\n" +
+	"\x16last_trade_price_cents\x18\x05 \x01(\x03R\x13lastTradePriceCents\x12&\n" +
+	"\x0fhalted_until_ms\x18\x06 \x01(\x03R\rhaltedUntilMs\"Y\n" +
+	"\x13TradingResumedEvent\x12!\n" +
+	"\fstock_ticker\x18\x01 \x01(\tR\vstockTicker\x12\x1f\n" +
+	"\vprice_cents\x18\x02 \x01(\x03R\n" +
+	"priceCents\"\xb5\x01\n" +
+	"\x18SessionPhaseChangedEvent\x121\n" +
+	"\x05phase\x18\x01 \x01(\x0e2\x1b.common.events.SessionPhaseR\x05phase\x12B\n" +
+	"\x0eprevious_phase\x18\x02 \x01(\x0e2\x1b.common.events.SessionPhaseR\rpreviousPhase\x12\"\n" +
+	"\rchanged_at_ms\x18\x03 \x01(\x03R\vchangedAtMs*\xda\x02\n" +
+	"\tEventType\x12\x1a\n" +
+	"\x16EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fORDER_PLACED\x10\x01\x12\x13\n" +
+	"\x0fORDER_CANCELLED\x10\x02\x12\x10\n" +
+	"\fORDER_FILLED\x10\x03\x12\x1a\n" +
+	"\x16ORDER_PARTIALLY_FILLED\x10\x04\x12\x12\n" +
+	"\x0eORDER_REJECTED\x10\x05\x12\x12\n" +
+	"\x0eTRADE_EXECUTED\x10\x06\x12\x13\n" +
+	"\x0fORDER_TRIGGERED\x10\a\x12\x11\n" +
+	"\rORDER_AMENDED\x10\b\x12\x18\n" +
+	"\x14SELF_TRADE_PREVENTED\x10\t\x12\x15\n" +
+	"\x11AUCTION_UNCROSSED\x10\n" +
+	"\x12\x12\n" +
+	"\x0eTRADING_HALTED\x10\v\x12\x13\n" +
+	"\x0fTRADING_RESUMED\x10\f\x12\x19\n" +
+	"\x15SESSION_PHASE_CHANGED\x10\r\x12\x17\n" +
+	"\x13TRAILING_STOP_MOVED\x10\x0e*\x94\x01\n" +
+	"\fCancelReason\x12\x1d\n" +
+	"\x19CANCEL_REASON_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eUSER_REQUESTED\x10\x01\x12\x17\n" +
+	"\x13IMMEDIATE_OR_CANCEL\x10\x02\x12\x10\n" +
+	"\fFILL_OR_KILL\x10\x03\x12\v\n" +
+	"\aEXPIRED\x10\x04\x12\x19\n" +
+	"\x15SELF_TRADE_PREVENTION\x10\x05*L\n" +
+	"\n" +
+	"HaltReason\x12\x1b\n" +
+	"\x17HALT_REASON_UNSPECIFIED\x10\x00\x12\x0f\n" +
+	"\vSTATIC_BAND\x10\x01\x12\x10\n" +
+	"\fDYNAMIC_BAND\x10\x02*b\n" +
+	"\fSessionPhase\x12\x1d\n" +
+	"\x19SESSION_PHASE_UNSPECIFIED\x10\x00\x12\f\n" +
+	"\bPRE_OPEN\x10\x01\x12\x0e\n" +
+	"\n" +
+	"CONTINUOUS\x10\x02\x12\t\n" +
+	"\x05CLOSE\x10\x03\x12\n" +
+	"\n" +
+	"\x06CLOSED\x10\x04BDZBgithub.com/Marwan051/tradding_platform_game/proto/gen/go/v1/commonb\x06proto3"
 
 var (
 	file_proto_v1_common_events_proto_rawDescOnce sync.Once
@@ -740,37 +1905,70 @@ func file_proto_v1_common_events_proto_rawDescGZIP() []byte {
 	return file_proto_v1_common_events_proto_rawDescData
 }
 
-var file_proto_v1_common_events_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_proto_v1_common_events_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_proto_v1_common_events_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_proto_v1_common_events_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_proto_v1_common_events_proto_goTypes = []any{
 	(EventType)(0),                    // 0: common.events.EventType
-	(*EngineEvent)(nil),               // 1: common.events.EngineEvent
-	(*OrderPlacedEvent)(nil),          // 2: common.events.OrderPlacedEvent
-	(*OrderCancelledEvent)(nil),       // 3: common.events.OrderCancelledEvent
-	(*OrderFilledEvent)(nil),          // 4: common.events.OrderFilledEvent
-	(*OrderPartiallyFilledEvent)(nil), // 5: common.events.OrderPartiallyFilledEvent
-	(*OrderRejectedEvent)(nil),        // 6: common.events.OrderRejectedEvent
-	(*TradeExecutedEvent)(nil),        // 7: common.events.TradeExecutedEvent
-	(OrderType)(0),                    // 8: common.types.OrderType
-	(OrderSide)(0),                    // 9: common.types.OrderSide
-	(ErrorCode)(0),                    // 10: common.types.ErrorCode
+	(CancelReason)(0),                 // 1: common.events.CancelReason
+	(HaltReason)(0),                   // 2: common.events.HaltReason
+	(SessionPhase)(0),                 // 3: common.events.SessionPhase
+	(*EngineEvent)(nil),               // 4: common.events.EngineEvent
+	(*OrderPlacedEvent)(nil),          // 5: common.events.OrderPlacedEvent
+	(*OrderCancelledEvent)(nil),       // 6: common.events.OrderCancelledEvent
+	(*OrderFilledEvent)(nil),          // 7: common.events.OrderFilledEvent
+	(*OrderPartiallyFilledEvent)(nil), // 8: common.events.OrderPartiallyFilledEvent
+	(*OrderRejectedEvent)(nil),        // 9: common.events.OrderRejectedEvent
+	(*TradeExecutedEvent)(nil),        // 10: common.events.TradeExecutedEvent
+	(*OrderTriggeredEvent)(nil),       // 11: common.events.OrderTriggeredEvent
+	(*TrailingStopMovedEvent)(nil),    // 12: common.events.TrailingStopMovedEvent
+	(*OrderAmendedEvent)(nil),         // 13: common.events.OrderAmendedEvent
+	(*SelfTradePreventedEvent)(nil),   // 14: common.events.SelfTradePreventedEvent
+	(*AuctionUncrossedEvent)(nil),     // 15: common.events.AuctionUncrossedEvent
+	(*TradingHaltedEvent)(nil),        // 16: common.events.TradingHaltedEvent
+	(*TradingResumedEvent)(nil),       // 17: common.events.TradingResumedEvent
+	(*SessionPhaseChangedEvent)(nil),  // 18: common.events.SessionPhaseChangedEvent
+	(OrderType)(0),                    // 19: common.types.OrderType
+	(OrderSide)(0),                    // 20: common.types.OrderSide
+	(TimeInForce)(0),                  // 21: common.types.TimeInForce
+	(SelfTradePrevention)(0),          // 22: common.types.SelfTradePrevention
+	(ErrorCode)(0),                    // 23: common.types.ErrorCode
 }
 var file_proto_v1_common_events_proto_depIdxs = []int32{
 	0,  // 0: common.events.EngineEvent.event_type:type_name -> common.events.EventType
-	2,  // 1: common.events.EngineEvent.order_placed:type_name -> common.events.OrderPlacedEvent
-	3,  // 2: common.events.EngineEvent.order_cancelled:type_name -> common.events.OrderCancelledEvent
-	4,  // 3: common.events.EngineEvent.order_filled:type_name -> common.events.OrderFilledEvent
-	5,  // 4: common.events.EngineEvent.order_partially_filled:type_name -> common.events.OrderPartiallyFilledEvent
-	6,  // 5: common.events.EngineEvent.order_rejected:type_name -> common.events.OrderRejectedEvent
-	7,  // 6: common.events.EngineEvent.trade_executed:type_name -> common.events.TradeExecutedEvent
-	8,  // 7: common.events.OrderPlacedEvent.order_type:type_name -> common.types.OrderType
-	9,  // 8: common.events.OrderPlacedEvent.side:type_name -> common.types.OrderSide
-	10, // 9: common.events.OrderRejectedEvent.reason:type_name -> common.types.ErrorCode
-	10, // [10:10] is the sub-list for method output_type
-	10, // [10:10] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	5,  // 1: common.events.EngineEvent.order_placed:type_name -> common.events.OrderPlacedEvent
+	6,  // 2: common.events.EngineEvent.order_cancelled:type_name -> common.events.OrderCancelledEvent
+	7,  // 3: common.events.EngineEvent.order_filled:type_name -> common.events.OrderFilledEvent
+	8,  // 4: common.events.EngineEvent.order_partially_filled:type_name -> common.events.OrderPartiallyFilledEvent
+	9,  // 5: common.events.EngineEvent.order_rejected:type_name -> common.events.OrderRejectedEvent
+	10, // 6: common.events.EngineEvent.trade_executed:type_name -> common.events.TradeExecutedEvent
+	11, // 7: common.events.EngineEvent.order_triggered:type_name -> common.events.OrderTriggeredEvent
+	13, // 8: common.events.EngineEvent.order_amended:type_name -> common.events.OrderAmendedEvent
+	14, // 9: common.events.EngineEvent.self_trade_prevented:type_name -> common.events.SelfTradePreventedEvent
+	15, // 10: common.events.EngineEvent.auction_uncrossed:type_name -> common.events.AuctionUncrossedEvent
+	16, // 11: common.events.EngineEvent.trading_halted:type_name -> common.events.TradingHaltedEvent
+	17, // 12: common.events.EngineEvent.trading_resumed:type_name -> common.events.TradingResumedEvent
+	18, // 13: common.events.EngineEvent.session_phase_changed:type_name -> common.events.SessionPhaseChangedEvent
+	12, // 14: common.events.EngineEvent.trailing_stop_moved:type_name -> common.events.TrailingStopMovedEvent
+	19, // 15: common.events.OrderPlacedEvent.order_type:type_name -> common.types.OrderType
+	20, // 16: common.events.OrderPlacedEvent.side:type_name -> common.types.OrderSide
+	21, // 17: common.events.OrderPlacedEvent.time_in_force:type_name -> common.types.TimeInForce
+	22, // 18: common.events.OrderPlacedEvent.self_trade_prevention:type_name -> common.types.SelfTradePrevention
+	1,  // 19: common.events.OrderCancelledEvent.reason:type_name -> common.events.CancelReason
+	23, // 20: common.events.OrderRejectedEvent.reason:type_name -> common.types.ErrorCode
+	19, // 21: common.events.OrderTriggeredEvent.order_type:type_name -> common.types.OrderType
+	20, // 22: common.events.OrderTriggeredEvent.side:type_name -> common.types.OrderSide
+	20, // 23: common.events.TrailingStopMovedEvent.side:type_name -> common.types.OrderSide
+	19, // 24: common.events.OrderAmendedEvent.order_type:type_name -> common.types.OrderType
+	20, // 25: common.events.OrderAmendedEvent.side:type_name -> common.types.OrderSide
+	22, // 26: common.events.SelfTradePreventedEvent.mode:type_name -> common.types.SelfTradePrevention
+	2,  // 27: common.events.TradingHaltedEvent.reason:type_name -> common.events.HaltReason
+	3,  // 28: common.events.SessionPhaseChangedEvent.phase:type_name -> common.events.SessionPhase
+	3,  // 29: common.events.SessionPhaseChangedEvent.previous_phase:type_name -> common.events.SessionPhase
+	30, // [30:30] is the sub-list for method output_type
+	30, // [30:30] is the sub-list for method input_type
+	30, // [30:30] is the sub-list for extension type_name
+	30, // [30:30] is the sub-list for extension extendee
+	0,  // [0:30] is the sub-list for field type_name
 }
 
 func init() { file_proto_v1_common_events_proto_init() }
@@ -784,8 +1982,8 @@ func file_proto_v1_common_events_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_v1_common_events_proto_rawDesc), len(file_proto_v1_common_events_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   7,
+			NumEnums:      4,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -28,6 +28,9 @@ const (
 	OrderType_ORDER_TYPE_UNSPECIFIED OrderType = 0
 	OrderType_MARKET                 OrderType = 1
 	OrderType_LIMIT                  OrderType = 2
+	OrderType_STOP_MARKET            OrderType = 3 // Becomes a MARKET order once the trigger price trades
+	OrderType_STOP_LIMIT             OrderType = 4 // Becomes a LIMIT order once the trigger price trades
+	OrderType_TRAILING_STOP          OrderType = 5 // Stop whose trigger follows the last trade price, becomes a MARKET order
 )
 
 // Enum value maps for OrderType.
@@ -36,11 +39,17 @@ var (
 		0: "ORDER_TYPE_UNSPECIFIED",
 		1: "MARKET",
 		2: "LIMIT",
+		3: "STOP_MARKET",
+		4: "STOP_LIMIT",
+		5: "TRAILING_STOP",
 	}
 	OrderType_value = map[string]int32{
 		"ORDER_TYPE_UNSPECIFIED": 0,
 		"MARKET":                 1,
 		"LIMIT":                  2,
+		"STOP_MARKET":            3,
+		"STOP_LIMIT":             4,
+		"TRAILING_STOP":          5,
 	}
 )
 
@@ -71,6 +80,125 @@ func (OrderType) EnumDescriptor() ([]byte, []int) {
 	return file_proto_v1_common_types_proto_rawDescGZIP(), []int{0}
 }
 
+// TimeInForce specifies how long an order keeps working before its unfilled part is cancelled.
+type TimeInForce int32
+
+const (
+	TimeInForce_TIME_IN_FORCE_UNSPECIFIED TimeInForce = 0 // Treated as GTC
+	TimeInForce_GTC                       TimeInForce = 1 // Good till cancelled
+	TimeInForce_IOC                       TimeInForce = 2 // Immediate or cancel
+	TimeInForce_FOK                       TimeInForce = 3 // Fill or kill
+	TimeInForce_DAY                       TimeInForce = 4 // Cancelled at the end of the trading day
+	TimeInForce_GTD                       TimeInForce = 5 // Good till date, cancelled at expires_at_ms
+)
+
+// Enum value maps for TimeInForce.
+var (
+	TimeInForce_name = map[int32]string{
+		0: "TIME_IN_FORCE_UNSPECIFIED",
+		1: "GTC",
+		2: "IOC",
+		3: "FOK",
+		4: "DAY",
+		5: "GTD",
+	}
+	TimeInForce_value = map[string]int32{
+		"TIME_IN_FORCE_UNSPECIFIED": 0,
+		"GTC":                       1,
+		"IOC":                       2,
+		"FOK":                       3,
+		"DAY":                       4,
+		"GTD":                       5,
+	}
+)
+
+func (x TimeInForce) Enum() *TimeInForce {
+	p := new(TimeInForce)
+	*p = x
+	return p
+}
+
+func (x TimeInForce) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TimeInForce) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_v1_common_types_proto_enumTypes[1].Descriptor()
+}
+
+func (TimeInForce) Type() protoreflect.EnumType {
+	return &file_proto_v1_common_types_proto_enumTypes[1]
+}
+
+func (x TimeInForce) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TimeInForce.Descriptor instead.
+func (TimeInForce) EnumDescriptor() ([]byte, []int) {
+	return file_proto_v1_common_types_proto_rawDescGZIP(), []int{1}
+}
+
+// SelfTradePrevention specifies what happens when an order would trade against
+// another order from the same trader or owner group.
+type SelfTradePrevention int32
+
+const (
+	SelfTradePrevention_SELF_TRADE_PREVENTION_UNSPECIFIED SelfTradePrevention = 0 // Use the engine's configured mode
+	SelfTradePrevention_ALLOW_SELF_TRADE                  SelfTradePrevention = 1
+	SelfTradePrevention_CANCEL_NEWEST                     SelfTradePrevention = 2 // Cancel the incoming order's remainder
+	SelfTradePrevention_CANCEL_OLDEST                     SelfTradePrevention = 3 // Cancel the resting order and keep matching
+	SelfTradePrevention_CANCEL_BOTH                       SelfTradePrevention = 4
+	SelfTradePrevention_DECREMENT                         SelfTradePrevention = 5 // Reduce both by the smaller quantity, cancelling whichever reaches zero
+)
+
+// Enum value maps for SelfTradePrevention.
+var (
+	SelfTradePrevention_name = map[int32]string{
+		0: "SELF_TRADE_PREVENTION_UNSPECIFIED",
+		1: "ALLOW_SELF_TRADE",
+		2: "CANCEL_NEWEST",
+		3: "CANCEL_OLDEST",
+		4: "CANCEL_BOTH",
+		5: "DECREMENT",
+	}
+	SelfTradePrevention_value = map[string]int32{
+		"SELF_TRADE_PREVENTION_UNSPECIFIED": 0,
+		"ALLOW_SELF_TRADE":                  1,
+		"CANCEL_NEWEST":                     2,
+		"CANCEL_OLDEST":                     3,
+		"CANCEL_BOTH":                       4,
+		"DECREMENT":                         5,
+	}
+)
+
+func (x SelfTradePrevention) Enum() *SelfTradePrevention {
+	p := new(SelfTradePrevention)
+	*p = x
+	return p
+}
+
+func (x SelfTradePrevention) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SelfTradePrevention) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_v1_common_types_proto_enumTypes[2].Descriptor()
+}
+
+func (SelfTradePrevention) Type() protoreflect.EnumType {
+	return &file_proto_v1_common_types_proto_enumTypes[2]
+}
+
+func (x SelfTradePrevention) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SelfTradePrevention.Descriptor instead.
+func (SelfTradePrevention) EnumDescriptor() ([]byte, []int) {
+	return file_proto_v1_common_types_proto_rawDescGZIP(), []int{2}
+}
+
 // OrderSide specifies whether the order is a buy or sell.
 type OrderSide int32
 
@@ -105,11 +233,11 @@ func (x OrderSide) String() string {
 }
 
 func (OrderSide) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_v1_common_types_proto_enumTypes[1].Descriptor()
+	return file_proto_v1_common_types_proto_enumTypes[3].Descriptor()
 }
 
 func (OrderSide) Type() protoreflect.EnumType {
-	return &file_proto_v1_common_types_proto_enumTypes[1]
+	return &file_proto_v1_common_types_proto_enumTypes[3]
 }
 
 func (x OrderSide) Number() protoreflect.EnumNumber {
@@ -118,7 +246,7 @@ func (x OrderSide) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use OrderSide.Descriptor instead.
 func (OrderSide) EnumDescriptor() ([]byte, []int) {
-	return file_proto_v1_common_types_proto_rawDescGZIP(), []int{1}
+	return file_proto_v1_common_types_proto_rawDescGZIP(), []int{3}
 }
 
 // OrderStatus represents the current state of an order.
@@ -164,11 +292,11 @@ func (x OrderStatus) String() string {
 }
 
 func (OrderStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_v1_common_types_proto_enumTypes[2].Descriptor()
+	return file_proto_v1_common_types_proto_enumTypes[4].Descriptor()
 }
 
 func (OrderStatus) Type() protoreflect.EnumType {
-	return &file_proto_v1_common_types_proto_enumTypes[2]
+	return &file_proto_v1_common_types_proto_enumTypes[4]
 }
 
 func (x OrderStatus) Number() protoreflect.EnumNumber {
@@ -177,7 +305,7 @@ func (x OrderStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use OrderStatus.Descriptor instead.
 func (OrderStatus) EnumDescriptor() ([]byte, []int) {
-	return file_proto_v1_common_types_proto_rawDescGZIP(), []int{2}
+	return file_proto_v1_common_types_proto_rawDescGZIP(), []int{4}
 }
 
 // ErrorCode represents error reasons returned by services.
@@ -196,6 +324,8 @@ const (
 	ErrorCode_RATE_LIMIT_EXCEEDED    ErrorCode = 9
 	ErrorCode_MARKET_CLOSED          ErrorCode = 10
 	ErrorCode_INTERNAL_ERROR         ErrorCode = 11
+	ErrorCode_POST_ONLY_WOULD_CROSS  ErrorCode = 12 // Post-only order would have taken liquidity
+	ErrorCode_INVALID_ORDER_TYPE     ErrorCode = 13 // Order flags are not valid for the order type
 )
 
 // Enum value maps for ErrorCode.
@@ -213,6 +343,8 @@ var (
 		9:  "RATE_LIMIT_EXCEEDED",
 		10: "MARKET_CLOSED",
 		11: "INTERNAL_ERROR",
+		12: "POST_ONLY_WOULD_CROSS",
+		13: "INVALID_ORDER_TYPE",
 	}
 	ErrorCode_value = map[string]int32{
 		"ERROR_CODE_UNSPECIFIED": 0,
@@ -227,6 +359,8 @@ var (
 		"RATE_LIMIT_EXCEEDED":    9,
 		"MARKET_CLOSED":          10,
 		"INTERNAL_ERROR":         11,
+		"POST_ONLY_WOULD_CROSS":  12,
+		"INVALID_ORDER_TYPE":     13,
 	}
 )
 
@@ -241,11 +375,11 @@ func (x ErrorCode) String() string {
 }
 
 func (ErrorCode) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_v1_common_types_proto_enumTypes[3].Descriptor()
+	return file_proto_v1_common_types_proto_enumTypes[5].Descriptor()
 }
 
 func (ErrorCode) Type() protoreflect.EnumType {
-	return &file_proto_v1_common_types_proto_enumTypes[3]
+	return &file_proto_v1_common_types_proto_enumTypes[5]
 }
 
 func (x ErrorCode) Number() protoreflect.EnumNumber {
@@ -254,7 +388,7 @@ func (x ErrorCode) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ErrorCode.Descriptor instead.
 func (ErrorCode) EnumDescriptor() ([]byte, []int) {
-	return file_proto_v1_common_types_proto_rawDescGZIP(), []int{3}
+	return file_proto_v1_common_types_proto_rawDescGZIP(), []int{5}
 }
 
 // Order is a canonical order representation shared across services.
@@ -262,17 +396,23 @@ type Order struct {
 	state                 protoimpl.MessageState `protogen:"open.v1"`
 	OrderId               string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
 	TraderId              int64                  `protobuf:"varint,2,opt,name=trader_id,json=traderId,proto3" json:"trader_id,omitempty"`
-	StockTicker           string                 `protobuf:"bytes,4,opt,name=stock_ticker,json=stockTicker,proto3" json:"stock_ticker,omitempty"`
-	OrderType             OrderType              `protobuf:"varint,5,opt,name=order_type,json=orderType,proto3,enum=common.types.OrderType" json:"order_type,omitempty"`
-	Side                  OrderSide              `protobuf:"varint,6,opt,name=side,proto3,enum=common.types.OrderSide" json:"side,omitempty"`
-	Quantity              int64                  `protobuf:"varint,7,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	FilledQuantity        int64                  `protobuf:"varint,8,opt,name=filled_quantity,json=filledQuantity,proto3" json:"filled_quantity,omitempty"`
-	RemainingQuantity     int64                  `protobuf:"varint,9,opt,name=remaining_quantity,json=remainingQuantity,proto3" json:"remaining_quantity,omitempty"`
-	LimitPriceCents       int64                  `protobuf:"varint,10,opt,name=limit_price_cents,json=limitPriceCents,proto3" json:"limit_price_cents,omitempty"`
-	Status                OrderStatus            `protobuf:"varint,11,opt,name=status,proto3,enum=common.types.OrderStatus" json:"status,omitempty"`
-	CreatedAtMs           int64                  `protobuf:"varint,12,opt,name=created_at_ms,json=createdAtMs,proto3" json:"created_at_ms,omitempty"`
-	UpdatedAtMs           int64                  `protobuf:"varint,13,opt,name=updated_at_ms,json=updatedAtMs,proto3" json:"updated_at_ms,omitempty"`
-	AverageFillPriceCents int64                  `protobuf:"varint,14,opt,name=average_fill_price_cents,json=averageFillPriceCents,proto3" json:"average_fill_price_cents,omitempty"`
+	StockTicker           string                 `protobuf:"bytes,3,opt,name=stock_ticker,json=stockTicker,proto3" json:"stock_ticker,omitempty"`
+	OrderType             OrderType              `protobuf:"varint,4,opt,name=order_type,json=orderType,proto3,enum=common.types.OrderType" json:"order_type,omitempty"`
+	Side                  OrderSide              `protobuf:"varint,5,opt,name=side,proto3,enum=common.types.OrderSide" json:"side,omitempty"`
+	Quantity              int64                  `protobuf:"varint,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	FilledQuantity        int64                  `protobuf:"varint,7,opt,name=filled_quantity,json=filledQuantity,proto3" json:"filled_quantity,omitempty"`
+	RemainingQuantity     int64                  `protobuf:"varint,8,opt,name=remaining_quantity,json=remainingQuantity,proto3" json:"remaining_quantity,omitempty"`
+	LimitPriceCents       int64                  `protobuf:"varint,9,opt,name=limit_price_cents,json=limitPriceCents,proto3" json:"limit_price_cents,omitempty"`
+	Status                OrderStatus            `protobuf:"varint,10,opt,name=status,proto3,enum=common.types.OrderStatus" json:"status,omitempty"`
+	CreatedAtMs           int64                  `protobuf:"varint,11,opt,name=created_at_ms,json=createdAtMs,proto3" json:"created_at_ms,omitempty"`
+	UpdatedAtMs           int64                  `protobuf:"varint,12,opt,name=updated_at_ms,json=updatedAtMs,proto3" json:"updated_at_ms,omitempty"`
+	AverageFillPriceCents int64                  `protobuf:"varint,13,opt,name=average_fill_price_cents,json=averageFillPriceCents,proto3" json:"average_fill_price_cents,omitempty"`
+	TriggerPriceCents     int64                  `protobuf:"varint,14,opt,name=trigger_price_cents,json=triggerPriceCents,proto3" json:"trigger_price_cents,omitempty"`
+	TimeInForce           TimeInForce            `protobuf:"varint,15,opt,name=time_in_force,json=timeInForce,proto3,enum=common.types.TimeInForce" json:"time_in_force,omitempty"`
+	ExpiresAtMs           int64                  `protobuf:"varint,16,opt,name=expires_at_ms,json=expiresAtMs,proto3" json:"expires_at_ms,omitempty"`
+	DisplayQuantity       int64                  `protobuf:"varint,17,opt,name=display_quantity,json=displayQuantity,proto3" json:"display_quantity,omitempty"`
+	TrailingOffsetCents   int64                  `protobuf:"varint,18,opt,name=trailing_offset_cents,json=trailingOffsetCents,proto3" json:"trailing_offset_cents,omitempty"`
+	TrailingOffsetBps     int64                  `protobuf:"varint,19,opt,name=trailing_offset_bps,json=trailingOffsetBps,proto3" json:"trailing_offset_bps,omitempty"`
 	unknownFields         protoimpl.UnknownFields
 	sizeCache             protoimpl.SizeCache
 }
@@ -398,6 +538,48 @@ func (x *Order) GetAverageFillPriceCents() int64 {
 	return 0
 }
 
+func (x *Order) GetTriggerPriceCents() int64 {
+	if x != nil {
+		return x.TriggerPriceCents
+	}
+	return 0
+}
+
+func (x *Order) GetTimeInForce() TimeInForce {
+	if x != nil {
+		return x.TimeInForce
+	}
+	return TimeInForce_TIME_IN_FORCE_UNSPECIFIED
+}
+
+func (x *Order) GetExpiresAtMs() int64 {
+	if x != nil {
+		return x.ExpiresAtMs
+	}
+	return 0
+}
+
+func (x *Order) GetDisplayQuantity() int64 {
+	if x != nil {
+		return x.DisplayQuantity
+	}
+	return 0
+}
+
+func (x *Order) GetTrailingOffsetCents() int64 {
+	if x != nil {
+		return x.TrailingOffsetCents
+	}
+	return 0
+}
+
+func (x *Order) GetTrailingOffsetBps() int64 {
+	if x != nil {
+		return x.TrailingOffsetBps
+	}
+	return 0
+}
+
 // Trade represents an executed trade.
 type Trade struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
@@ -406,11 +588,11 @@ type Trade struct {
 	BuyerOrderId    string                 `protobuf:"bytes,3,opt,name=buyer_order_id,json=buyerOrderId,proto3" json:"buyer_order_id,omitempty"`
 	SellerOrderId   string                 `protobuf:"bytes,4,opt,name=seller_order_id,json=sellerOrderId,proto3" json:"seller_order_id,omitempty"`
 	BuyerTraderId   int64                  `protobuf:"varint,5,opt,name=buyer_trader_id,json=buyerTraderId,proto3" json:"buyer_trader_id,omitempty"`
-	SellerTraderId  int64                  `protobuf:"varint,7,opt,name=seller_trader_id,json=sellerTraderId,proto3" json:"seller_trader_id,omitempty"`
-	Quantity        int64                  `protobuf:"varint,9,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	PriceCents      int64                  `protobuf:"varint,10,opt,name=price_cents,json=priceCents,proto3" json:"price_cents,omitempty"`
-	TotalValueCents int64                  `protobuf:"varint,11,opt,name=total_value_cents,json=totalValueCents,proto3" json:"total_value_cents,omitempty"`
-	ExecutedAtMs    int64                  `protobuf:"varint,12,opt,name=executed_at_ms,json=executedAtMs,proto3" json:"executed_at_ms,omitempty"`
+	SellerTraderId  int64                  `protobuf:"varint,6,opt,name=seller_trader_id,json=sellerTraderId,proto3" json:"seller_trader_id,omitempty"`
+	Quantity        int64                  `protobuf:"varint,7,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	PriceCents      int64                  `protobuf:"varint,8,opt,name=price_cents,json=priceCents,proto3" json:"price_cents,omitempty"`
+	TotalValueCents int64                  `protobuf:"varint,9,opt,name=total_value_cents,json=totalValueCents,proto3" json:"total_value_cents,omitempty"`
+	ExecutedAtMs    int64                  `protobuf:"varint,10,opt,name=executed_at_ms,json=executedAtMs,proto3" json:"executed_at_ms,omitempty"`
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -628,36 +810,42 @@ var File_proto_v1_common_types_proto protoreflect.FileDescriptor
 
 const file_proto_v1_common_types_proto_rawDesc = "" +
 	"\n" +
-	"\x1bproto/v1/common/types.proto\x12\fcommon.types\"\x9b\x04\n" +
+	"\x1bproto/v1/common/types.proto\x12\fcommon.types\"\xbd\x06\n" +
 	"\x05Order\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1b\n" +
 	"\ttrader_id\x18\x02 \x01(\x03R\btraderId\x12!\n" +
-	"\fstock_ticker\x18\x04 \x01(\tR\vstockTicker\x126\n" +
+	"\fstock_ticker\x18\x03 \x01(\tR\vstockTicker\x126\n" +
 	"\n" +
-	"order_type\x18\x05 \x01(\x0e2\x17.common.types.OrderTypeR\torderType\x12+\n" +
-	"\x04side\x18\x06 \x01(\x0e2\x17.common.types.OrderSideR\x04side\x12\x1a\n" +
-	"\bquantity\x18\a \x01(\x03R\bquantity\x12'\n" +
-	"\x0ffilled_quantity\x18\b \x01(\x03R\x0efilledQuantity\x12-\n" +
-	"\x12remaining_quantity\x18\t \x01(\x03R\x11remainingQuantity\x12*\n" +
-	"\x11limit_price_cents\x18\n" +
-	" \x01(\x03R\x0flimitPriceCents\x121\n" +
-	"\x06status\x18\v \x01(\x0e2\x19.common.types.OrderStatusR\x06status\x12\"\n" +
-	"\rcreated_at_ms\x18\f \x01(\x03R\vcreatedAtMs\x12\"\n" +
-	"\rupdated_at_ms\x18\r \x01(\x03R\vupdatedAtMs\x127\n" +
-	"\x18average_fill_price_cents\x18\x0e \x01(\x03R\x15averageFillPriceCents\"\xf4\x02\n" +
+	"order_type\x18\x04 \x01(\x0e2\x17.common.types.OrderTypeR\torderType\x12+\n" +
+	"\x04side\x18\x05 \x01(\x0e2\x17.common.types.OrderSideR\x04side\x12\x1a\n" +
+	"\bquantity\x18\x06 \x01(\x03R\bquantity\x12'\n" +
+	"\x0ffilled_quantity\x18\a \x01(\x03R\x0efilledQuantity\x12-\n" +
+	"\x12remaining_quantity\x18\b \x01(\x03R\x11remainingQuantity\x12*\n" +
+	"\x11limit_price_cents\x18\t \x01(\x03R\x0flimitPriceCents\x121\n" +
+	"\x06status\x18\n" +
+	" \x01(\x0e2\x19.common.types.OrderStatusR\x06status\x12\"\n" +
+	"\rcreated_at_ms\x18\v \x01(\x03R\vcreatedAtMs\x12\"\n" +
+	"\rupdated_at_ms\x18\f \x01(\x03R\vupdatedAtMs\x127\n" +
+	"\x18average_fill_price_cents\x18\r \x01(\x03R\x15averageFillPriceCents\x12.\n" +
+	"\x13trigger_price_cents\x18\x0e \x01(\x03R\x11triggerPriceCents\x12=\n" +
+	"\rtime_in_force\x18\x0f \x01(\x0e2\x19.common.types.TimeInForceR\vtimeInForce\x12\"\n" +
+	"\rexpires_at_ms\x18\x10 \x01(\x03R\vexpiresAtMs\x12)\n" +
+	"\x10display_quantity\x18\x11 \x01(\x03R\x0fdisplayQuantity\x122\n" +
+	"\x15trailing_offset_cents\x18\x12 \x01(\x03R\x13trailingOffsetCents\x12.\n" +
+	"\x13trailing_offset_bps\x18\x13 \x01(\x03R\x11trailingOffsetBps\"\xf4\x02\n" +
 	"\x05Trade\x12\x19\n" +
 	"\btrade_id\x18\x01 \x01(\x03R\atradeId\x12!\n" +
 	"\fstock_ticker\x18\x02 \x01(\tR\vstockTicker\x12$\n" +
 	"\x0ebuyer_order_id\x18\x03 \x01(\tR\fbuyerOrderId\x12&\n" +
 	"\x0fseller_order_id\x18\x04 \x01(\tR\rsellerOrderId\x12&\n" +
 	"\x0fbuyer_trader_id\x18\x05 \x01(\x03R\rbuyerTraderId\x12(\n" +
-	"\x10seller_trader_id\x18\a \x01(\x03R\x0esellerTraderId\x12\x1a\n" +
-	"\bquantity\x18\t \x01(\x03R\bquantity\x12\x1f\n" +
-	"\vprice_cents\x18\n" +
-	" \x01(\x03R\n" +
+	"\x10seller_trader_id\x18\x06 \x01(\x03R\x0esellerTraderId\x12\x1a\n" +
+	"\bquantity\x18\a \x01(\x03R\bquantity\x12\x1f\n" +
+	"\vprice_cents\x18\b \x01(\x03R\n" +
 	"priceCents\x12*\n" +
-	"\x11total_value_cents\x18\v \x01(\x03R\x0ftotalValueCents\x12$\n" +
-	"\x0eexecuted_at_ms\x18\f \x01(\x03R\fexecutedAtMs\"\xff\x02\n" +
+	"\x11total_value_cents\x18\t \x01(\x03R\x0ftotalValueCents\x12$\n" +
+	"\x0eexecuted_at_ms\x18\n" +
+	" \x01(\x03R\fexecutedAtMs\"\xff\x02\n" +
 	"\n" +
 	"StockPrice\x12!\n" +
 	"\fstock_ticker\x18\x01 \x01(\tR\vstockTicker\x12.\n" +
@@ -669,12 +857,30 @@ const file_proto_v1_common_types_proto_rawDesc = "" +
 	"\rday_low_cents\x18\a \x01(\x03R\vdayLowCents\x12\x1d\n" +
 	"\n" +
 	"day_volume\x18\b \x01(\x03R\tdayVolume\x12!\n" +
-	"\ftimestamp_ms\x18\t \x01(\x03R\vtimestampMs*>\n" +
+	"\ftimestamp_ms\x18\t \x01(\x03R\vtimestampMs*r\n" +
 	"\tOrderType\x12\x1a\n" +
 	"\x16ORDER_TYPE_UNSPECIFIED\x10\x00\x12\n" +
 	"\n" +
 	"\x06MARKET\x10\x01\x12\t\n" +
-	"\x05LIMIT\x10\x02*:\n" +
+	"\x05LIMIT\x10\x02\x12\x0f\n" +
+	"\vSTOP_MARKET\x10\x03\x12\x0e\n" +
+	"\n" +
+	"STOP_LIMIT\x10\x04\x12\x11\n" +
+	"\rTRAILING_STOP\x10\x05*Y\n" +
+	"\vTimeInForce\x12\x1d\n" +
+	"\x19TIME_IN_FORCE_UNSPECIFIED\x10\x00\x12\a\n" +
+	"\x03GTC\x10\x01\x12\a\n" +
+	"\x03IOC\x10\x02\x12\a\n" +
+	"\x03FOK\x10\x03\x12\a\n" +
+	"\x03DAY\x10\x04\x12\a\n" +
+	"\x03GTD\x10\x05*\x98\x01\n" +
+	"\x13SelfTradePrevention\x12%\n" +
+	"!SELF_TRADE_PREVENTION_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10ALLOW_SELF_TRADE\x10\x01\x12\x11\n" +
+	"\rCANCEL_NEWEST\x10\x02\x12\x11\n" +
+	"\rCANCEL_OLDEST\x10\x03\x12\x0f\n" +
+	"\vCANCEL_BOTH\x10\x04\x12\r\n" +
+	"\tDECREMENT\x10\x05*:\n" +
 	"\tOrderSide\x12\x1a\n" +
 	"\x16ORDER_SIDE_UNSPECIFIED\x10\x00\x12\a\n" +
 	"\x03BUY\x10\x01\x12\b\n" +
@@ -686,7 +892,7 @@ const file_proto_v1_common_types_proto_rawDesc = "" +
 	"\n" +
 	"\x06FILLED\x10\x03\x12\r\n" +
 	"\tCANCELLED\x10\x04\x12\f\n" +
-	"\bREJECTED\x10\x05*\x94\x02\n" +
+	"\bREJECTED\x10\x05*\xc7\x02\n" +
 	"\tErrorCode\x12\x1a\n" +
 	"\x16ERROR_CODE_UNSPECIFIED\x10\x00\x12\x16\n" +
 	"\x12INSUFFICIENT_FUNDS\x10\x01\x12\x17\n" +
@@ -700,7 +906,9 @@ const file_proto_v1_common_types_proto_rawDesc = "" +
 	"\x13RATE_LIMIT_EXCEEDED\x10\t\x12\x11\n" +
 	"\rMARKET_CLOSED\x10\n" +
 	"\x12\x12\n" +
-	"\x0eINTERNAL_ERROR\x10\vBDZBgithub.com/Marwan051/tradding_platform_game/proto/gen/go/v1/commonb\x06proto3"
+	"\x0eINTERNAL_ERROR\x10\v\x12\x19\n" +
+	"\x15POST_ONLY_WOULD_CROSS\x10\f\x12\x16\n" +
+	"\x12INVALID_ORDER_TYPE\x10\rBDZBgithub.com/Marwan051/tradding_platform_game/proto/gen/go/v1/commonb\x06proto3"
 
 var (
 	file_proto_v1_common_types_proto_rawDescOnce sync.Once
@@ -714,26 +922,29 @@ func file_proto_v1_common_types_proto_rawDescGZIP() []byte {
 	return file_proto_v1_common_types_proto_rawDescData
 }
 
-var file_proto_v1_common_types_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_proto_v1_common_types_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
 var file_proto_v1_common_types_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_proto_v1_common_types_proto_goTypes = []any{
-	(OrderType)(0),     // 0: common.types.OrderType
-	(OrderSide)(0),     // 1: common.types.OrderSide
-	(OrderStatus)(0),   // 2: common.types.OrderStatus
-	(ErrorCode)(0),     // 3: common.types.ErrorCode
-	(*Order)(nil),      // 4: common.types.Order
-	(*Trade)(nil),      // 5: common.types.Trade
-	(*StockPrice)(nil), // 6: common.types.StockPrice
+	(OrderType)(0),           // 0: common.types.OrderType
+	(TimeInForce)(0),         // 1: common.types.TimeInForce
+	(SelfTradePrevention)(0), // 2: common.types.SelfTradePrevention
+	(OrderSide)(0),           // 3: common.types.OrderSide
+	(OrderStatus)(0),         // 4: common.types.OrderStatus
+	(ErrorCode)(0),           // 5: common.types.ErrorCode
+	(*Order)(nil),            // 6: common.types.Order
+	(*Trade)(nil),            // 7: common.types.Trade
+	(*StockPrice)(nil),       // 8: common.types.StockPrice
 }
 var file_proto_v1_common_types_proto_depIdxs = []int32{
 	0, // 0: common.types.Order.order_type:type_name -> common.types.OrderType
-	1, // 1: common.types.Order.side:type_name -> common.types.OrderSide
-	2, // 2: common.types.Order.status:type_name -> common.types.OrderStatus
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	3, // 1: common.types.Order.side:type_name -> common.types.OrderSide
+	4, // 2: common.types.Order.status:type_name -> common.types.OrderStatus
+	1, // 3: common.types.Order.time_in_force:type_name -> common.types.TimeInForce
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_proto_v1_common_types_proto_init() }
@@ -746,7 +957,7 @@ func file_proto_v1_common_types_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_v1_common_types_proto_rawDesc), len(file_proto_v1_common_types_proto_rawDesc)),
-			NumEnums:      4,
+			NumEnums:      6,
 			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
@@ -134,6 +134,8 @@ type OrderBook struct {
 	TimestampMs         int64                  `protobuf:"varint,4,opt,name=timestamp_ms,json=timestampMs,proto3" json:"timestamp_ms,omitempty"`
 	LastTradePriceCents int64                  `protobuf:"varint,5,opt,name=last_trade_price_cents,json=lastTradePriceCents,proto3" json:"last_trade_price_cents,omitempty"`
 	SpreadCents         int64                  `protobuf:"varint,6,opt,name=spread_cents,json=spreadCents,proto3" json:"spread_cents,omitempty"`
+	BestBidCents        int64                  `protobuf:"varint,7,opt,name=best_bid_cents,json=bestBidCents,proto3" json:"best_bid_cents,omitempty"` // 0 if there are no bids
+	BestAskCents        int64                  `protobuf:"varint,8,opt,name=best_ask_cents,json=bestAskCents,proto3" json:"best_ask_cents,omitempty"` // 0 if there are no asks
 	unknownFields       protoimpl.UnknownFields
 	sizeCache           protoimpl.SizeCache
 }
@@ -210,6 +212,20 @@ func (x *OrderBook) GetSpreadCents() int64 {
 	return 0
 }
 
+func (x *OrderBook) GetBestBidCents() int64 {
+	if x != nil {
+		return x.BestBidCents
+	}
+	return 0
+}
+
+func (x *OrderBook) GetBestAskCents() int64 {
+	if x != nil {
+		return x.BestAskCents
+	}
+	return 0
+}
+
 // PriceLevel represents a specific price point in the order book with aggregated quantity.
 type PriceLevel struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1076,10 +1092,10 @@ func (x *GetOrderRequest) GetOrderId() string {
 type GetUserOrdersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TraderId      int64                  `protobuf:"varint,1,opt,name=trader_id,json=traderId,proto3" json:"trader_id,omitempty"`
-	StatusFilter  []common.OrderStatus   `protobuf:"varint,3,rep,packed,name=status_filter,json=statusFilter,proto3,enum=common.types.OrderStatus" json:"status_filter,omitempty"`
-	StockTicker   string                 `protobuf:"bytes,4,opt,name=stock_ticker,json=stockTicker,proto3" json:"stock_ticker,omitempty"`
-	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int64                  `protobuf:"varint,6,opt,name=offset,proto3" json:"offset,omitempty"`
+	StatusFilter  []common.OrderStatus   `protobuf:"varint,2,rep,packed,name=status_filter,json=statusFilter,proto3,enum=common.types.OrderStatus" json:"status_filter,omitempty"`
+	StockTicker   string                 `protobuf:"bytes,3,opt,name=stock_ticker,json=stockTicker,proto3" json:"stock_ticker,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int64                  `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1546,14 +1562,16 @@ const file_proto_v1_market_data_market_data_proto_rawDesc = "" +
 	"&proto/v1/market_data/market_data.proto\x12\x13trading.market_data\x1a\x1bproto/v1/common/types.proto\"N\n" +
 	"\x13GetOrderBookRequest\x12!\n" +
 	"\fstock_ticker\x18\x01 \x01(\tR\vstockTicker\x12\x14\n" +
-	"\x05depth\x18\x02 \x01(\x05R\x05depth\"\x93\x02\n" +
+	"\x05depth\x18\x02 \x01(\x05R\x05depth\"\xdf\x02\n" +
 	"\tOrderBook\x12!\n" +
 	"\fstock_ticker\x18\x01 \x01(\tR\vstockTicker\x123\n" +
 	"\x04bids\x18\x02 \x03(\v2\x1f.trading.market_data.PriceLevelR\x04bids\x123\n" +
 	"\x04asks\x18\x03 \x03(\v2\x1f.trading.market_data.PriceLevelR\x04asks\x12!\n" +
 	"\ftimestamp_ms\x18\x04 \x01(\x03R\vtimestampMs\x123\n" +
 	"\x16last_trade_price_cents\x18\x05 \x01(\x03R\x13lastTradePriceCents\x12!\n" +
-	"\fspread_cents\x18\x06 \x01(\x03R\vspreadCents\"j\n" +
+	"\fspread_cents\x18\x06 \x01(\x03R\vspreadCents\x12$\n" +
+	"\x0ebest_bid_cents\x18\a \x01(\x03R\fbestBidCents\x12$\n" +
+	"\x0ebest_ask_cents\x18\b \x01(\x03R\fbestAskCents\"j\n" +
 	"\n" +
 	"PriceLevel\x12\x1f\n" +
 	"\vprice_cents\x18\x01 \x01(\x03R\n" +
@@ -1622,10 +1640,10 @@ const file_proto_v1_market_data_market_data_proto_rawDesc = "" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\"\xc4\x01\n" +
 	"\x14GetUserOrdersRequest\x12\x1b\n" +
 	"\ttrader_id\x18\x01 \x01(\x03R\btraderId\x12>\n" +
-	"\rstatus_filter\x18\x03 \x03(\x0e2\x19.common.types.OrderStatusR\fstatusFilter\x12!\n" +
-	"\fstock_ticker\x18\x04 \x01(\tR\vstockTicker\x12\x14\n" +
-	"\x05limit\x18\x05 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x06 \x01(\x03R\x06offset\"e\n" +
+	"\rstatus_filter\x18\x02 \x03(\x0e2\x19.common.types.OrderStatusR\fstatusFilter\x12!\n" +
+	"\fstock_ticker\x18\x03 \x01(\tR\vstockTicker\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x05 \x01(\x03R\x06offset\"e\n" +
 	"\x15GetUserOrdersResponse\x12+\n" +
 	"\x06orders\x18\x01 \x03(\v2\x13.common.types.OrderR\x06orders\x12\x1f\n" +
 	"\vtotal_count\x18\x02 \x01(\x05R\n" +
@@ -8,6 +8,7 @@ package matching_engine
 
 import (
 	common "github.com/Marwan051/tradding_platform_game/proto/gen/go/v1/common"
+	market_data "github.com/Marwan051/tradding_platform_game/proto/gen/go/v1/market_data"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -24,15 +25,26 @@ const (
 
 // PlaceOrderRequest contains the parameters to place a new order.
 type PlaceOrderRequest struct {
-	state                 protoimpl.MessageState `protogen:"open.v1"`
-	TraderId              int64                  `protobuf:"varint,1,opt,name=trader_id,json=traderId,proto3" json:"trader_id,omitempty"`
-	StockTicker           string                 `protobuf:"bytes,3,opt,name=stock_ticker,json=stockTicker,proto3" json:"stock_ticker,omitempty"`
-	OrderType             common.OrderType       `protobuf:"varint,4,opt,name=order_type,json=orderType,proto3,enum=common.types.OrderType" json:"order_type,omitempty"`
-	Side                  common.OrderSide       `protobuf:"varint,5,opt,name=side,proto3,enum=common.types.OrderSide" json:"side,omitempty"`
-	Quantity              int64                  `protobuf:"varint,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	LimitPriceCents       int64                  `protobuf:"varint,7,opt,name=limit_price_cents,json=limitPriceCents,proto3" json:"limit_price_cents,omitempty"`
-	ClientOrderId         string                 `protobuf:"bytes,8,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
-	AvailableBalanceCents int64                  `protobuf:"varint,9,opt,name=available_balance_cents,json=availableBalanceCents,proto3" json:"available_balance_cents,omitempty"` // For MARKET BUY: buyer's available cash to cap spend
+	state                 protoimpl.MessageState     `protogen:"open.v1"`
+	TraderId              int64                      `protobuf:"varint,1,opt,name=trader_id,json=traderId,proto3" json:"trader_id,omitempty"`
+	StockTicker           string                     `protobuf:"bytes,2,opt,name=stock_ticker,json=stockTicker,proto3" json:"stock_ticker,omitempty"`
+	OrderType             common.OrderType           `protobuf:"varint,3,opt,name=order_type,json=orderType,proto3,enum=common.types.OrderType" json:"order_type,omitempty"`
+	Side                  common.OrderSide           `protobuf:"varint,4,opt,name=side,proto3,enum=common.types.OrderSide" json:"side,omitempty"`
+	Quantity              int64                      `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	LimitPriceCents       int64                      `protobuf:"varint,6,opt,name=limit_price_cents,json=limitPriceCents,proto3" json:"limit_price_cents,omitempty"`
+	ClientOrderId         string                     `protobuf:"bytes,7,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`                                                           // The trader's own ID; repeating it within the engine's window returns the first response
+	AvailableBalanceCents int64                      `protobuf:"varint,8,opt,name=available_balance_cents,json=availableBalanceCents,proto3" json:"available_balance_cents,omitempty"`                                  // For MARKET BUY: buyer's available cash to cap spend
+	TriggerPriceCents     int64                      `protobuf:"varint,9,opt,name=trigger_price_cents,json=triggerPriceCents,proto3" json:"trigger_price_cents,omitempty"`                                              // For STOP_MARKET/STOP_LIMIT: last trade price that releases the order
+	TimeInForce           common.TimeInForce         `protobuf:"varint,10,opt,name=time_in_force,json=timeInForce,proto3,enum=common.types.TimeInForce" json:"time_in_force,omitempty"`                                 // Defaults to GTC
+	ExpiresAtMs           int64                      `protobuf:"varint,11,opt,name=expires_at_ms,json=expiresAtMs,proto3" json:"expires_at_ms,omitempty"`                                                               // For GTD: unix millis when the order is cancelled
+	PostOnly              bool                       `protobuf:"varint,12,opt,name=post_only,json=postOnly,proto3" json:"post_only,omitempty"`                                                                          // For LIMIT: reject instead of taking liquidity
+	PostOnlyReprice       bool                       `protobuf:"varint,13,opt,name=post_only_reprice,json=postOnlyReprice,proto3" json:"post_only_reprice,omitempty"`                                                   // For post-only: reprice one tick behind the opposite best price instead of rejecting
+	DisplayQuantity       int64                      `protobuf:"varint,14,opt,name=display_quantity,json=displayQuantity,proto3" json:"display_quantity,omitempty"`                                                     // For LIMIT/STOP_LIMIT: iceberg slice shown on the book, 0 shows the full quantity
+	OwnerTraderId         int64                      `protobuf:"varint,15,opt,name=owner_trader_id,json=ownerTraderId,proto3" json:"owner_trader_id,omitempty"`                                                         // For bots: the owning trader, groups them for self-trade prevention
+	SelfTradePrevention   common.SelfTradePrevention `protobuf:"varint,16,opt,name=self_trade_prevention,json=selfTradePrevention,proto3,enum=common.types.SelfTradePrevention" json:"self_trade_prevention,omitempty"` // Defaults to the engine's configured mode
+	TrailingOffsetCents   int64                      `protobuf:"varint,17,opt,name=trailing_offset_cents,json=trailingOffsetCents,proto3" json:"trailing_offset_cents,omitempty"`                                       // For TRAILING_STOP: trigger distance from the best price seen
+	TrailingOffsetBps     int64                      `protobuf:"varint,18,opt,name=trailing_offset_bps,json=trailingOffsetBps,proto3" json:"trailing_offset_bps,omitempty"`                                             // For TRAILING_STOP: the same distance in basis points, if no cents offset is set
+	AvailableShares       *int64                     `protobuf:"varint,19,opt,name=available_shares,json=availableShares,proto3,oneof" json:"available_shares,omitempty"`                                               // For SELL: seller's shares not held by other open orders, caps the quantity if set
 	unknownFields         protoimpl.UnknownFields
 	sizeCache             protoimpl.SizeCache
 }
@@ -123,6 +135,83 @@ func (x *PlaceOrderRequest) GetAvailableBalanceCents() int64 {
 	return 0
 }
 
+func (x *PlaceOrderRequest) GetTriggerPriceCents() int64 {
+	if x != nil {
+		return x.TriggerPriceCents
+	}
+	return 0
+}
+
+func (x *PlaceOrderRequest) GetTimeInForce() common.TimeInForce {
+	if x != nil {
+		return x.TimeInForce
+	}
+	return common.TimeInForce(0)
+}
+
+func (x *PlaceOrderRequest) GetExpiresAtMs() int64 {
+	if x != nil {
+		return x.ExpiresAtMs
+	}
+	return 0
+}
+
+func (x *PlaceOrderRequest) GetPostOnly() bool {
+	if x != nil {
+		return x.PostOnly
+	}
+	return false
+}
+
+func (x *PlaceOrderRequest) GetPostOnlyReprice() bool {
+	if x != nil {
+		return x.PostOnlyReprice
+	}
+	return false
+}
+
+func (x *PlaceOrderRequest) GetDisplayQuantity() int64 {
+	if x != nil {
+		return x.DisplayQuantity
+	}
+	return 0
+}
+
+func (x *PlaceOrderRequest) GetOwnerTraderId() int64 {
+	if x != nil {
+		return x.OwnerTraderId
+	}
+	return 0
+}
+
+func (x *PlaceOrderRequest) GetSelfTradePrevention() common.SelfTradePrevention {
+	if x != nil {
+		return x.SelfTradePrevention
+	}
+	return common.SelfTradePrevention(0)
+}
+
+func (x *PlaceOrderRequest) GetTrailingOffsetCents() int64 {
+	if x != nil {
+		return x.TrailingOffsetCents
+	}
+	return 0
+}
+
+func (x *PlaceOrderRequest) GetTrailingOffsetBps() int64 {
+	if x != nil {
+		return x.TrailingOffsetBps
+	}
+	return 0
+}
+
+func (x *PlaceOrderRequest) GetAvailableShares() int64 {
+	if x != nil && x.AvailableShares != nil {
+		return *x.AvailableShares
+	}
+	return 0
+}
+
 // PlaceOrderResponse returns the result of placing an order.
 type PlaceOrderResponse struct {
 	state                 protoimpl.MessageState `protogen:"open.v1"`
@@ -216,12 +305,10 @@ func (x *PlaceOrderResponse) GetErrorCode() common.ErrorCode {
 	return common.ErrorCode(0)
 }
 
-// CancelOrderRequest contains the parameters to cancel an order.
+// CancelOrderRequest names the order to cancel and the trader cancelling it, who must own it.
 type CancelOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	StockTicker   string                 `protobuf:"bytes,2,opt,name=stock_ticker,json=stockTicker,proto3" json:"stock_ticker,omitempty"`
-	Side          common.OrderSide       `protobuf:"varint,3,opt,name=side,proto3,enum=common.types.OrderSide" json:"side,omitempty"`
 	TraderId      int64                  `protobuf:"varint,4,opt,name=trader_id,json=traderId,proto3" json:"trader_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -264,20 +351,6 @@ func (x *CancelOrderRequest) GetOrderId() string {
 	return ""
 }
 
-func (x *CancelOrderRequest) GetStockTicker() string {
-	if x != nil {
-		return x.StockTicker
-	}
-	return ""
-}
-
-func (x *CancelOrderRequest) GetSide() common.OrderSide {
-	if x != nil {
-		return x.Side
-	}
-	return common.OrderSide(0)
-}
-
 func (x *CancelOrderRequest) GetTraderId() int64 {
 	if x != nil {
 		return x.TraderId
@@ -291,6 +364,7 @@ type CancelOrderResponse struct {
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
 	ErrorMessage  string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	ErrorCode     common.ErrorCode       `protobuf:"varint,4,opt,name=error_code,json=errorCode,proto3,enum=common.types.ErrorCode" json:"error_code,omitempty"` // UNAUTHORIZED if the order belongs to another trader
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -346,27 +420,37 @@ func (x *CancelOrderResponse) GetErrorMessage() string {
 	return ""
 }
 
-// HealthCheckRequest is an empty request for health checks.
-type HealthCheckRequest struct {
+func (x *CancelOrderResponse) GetErrorCode() common.ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return common.ErrorCode(0)
+}
+
+// MassCancelRequest selects the trader's open orders to cancel.
+type MassCancelRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	TraderId      int64                  `protobuf:"varint,1,opt,name=trader_id,json=traderId,proto3" json:"trader_id,omitempty"`
+	StockTicker   string                 `protobuf:"bytes,2,opt,name=stock_ticker,json=stockTicker,proto3" json:"stock_ticker,omitempty"` // Every stock if empty
+	Side          common.OrderSide       `protobuf:"varint,3,opt,name=side,proto3,enum=common.types.OrderSide" json:"side,omitempty"`     // Both sides if unspecified
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *HealthCheckRequest) Reset() {
-	*x = HealthCheckRequest{}
+func (x *MassCancelRequest) Reset() {
+	*x = MassCancelRequest{}
 	mi := &file_proto_v1_matching_engine_matching_engine_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HealthCheckRequest) String() string {
+func (x *MassCancelRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HealthCheckRequest) ProtoMessage() {}
+func (*MassCancelRequest) ProtoMessage() {}
 
-func (x *HealthCheckRequest) ProtoReflect() protoreflect.Message {
+func (x *MassCancelRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_v1_matching_engine_matching_engine_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -378,35 +462,58 @@ func (x *HealthCheckRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HealthCheckRequest.ProtoReflect.Descriptor instead.
-func (*HealthCheckRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use MassCancelRequest.ProtoReflect.Descriptor instead.
+func (*MassCancelRequest) Descriptor() ([]byte, []int) {
 	return file_proto_v1_matching_engine_matching_engine_proto_rawDescGZIP(), []int{4}
 }
 
-// HealthCheckResponse returns health and basic engine stats.
-type HealthCheckResponse struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	IsHealthy       bool                   `protobuf:"varint,1,opt,name=is_healthy,json=isHealthy,proto3" json:"is_healthy,omitempty"`
-	OrdersProcessed int64                  `protobuf:"varint,2,opt,name=orders_processed,json=ordersProcessed,proto3" json:"orders_processed,omitempty"`
-	UptimeSeconds   int64                  `protobuf:"varint,3,opt,name=uptime_seconds,json=uptimeSeconds,proto3" json:"uptime_seconds,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *MassCancelRequest) GetTraderId() int64 {
+	if x != nil {
+		return x.TraderId
+	}
+	return 0
 }
 
-func (x *HealthCheckResponse) Reset() {
-	*x = HealthCheckResponse{}
+func (x *MassCancelRequest) GetStockTicker() string {
+	if x != nil {
+		return x.StockTicker
+	}
+	return ""
+}
+
+func (x *MassCancelRequest) GetSide() common.OrderSide {
+	if x != nil {
+		return x.Side
+	}
+	return common.OrderSide(0)
+}
+
+// MassCancelResponse lists the orders cancelled.
+type MassCancelResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Success        bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	CancelledCount int64                  `protobuf:"varint,2,opt,name=cancelled_count,json=cancelledCount,proto3" json:"cancelled_count,omitempty"`
+	OrderIds       []string               `protobuf:"bytes,3,rep,name=order_ids,json=orderIds,proto3" json:"order_ids,omitempty"` // Also set when success is false, for the orders cancelled before the failure
+	ErrorMessage   string                 `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	ErrorCode      common.ErrorCode       `protobuf:"varint,5,opt,name=error_code,json=errorCode,proto3,enum=common.types.ErrorCode" json:"error_code,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MassCancelResponse) Reset() {
+	*x = MassCancelResponse{}
 	mi := &file_proto_v1_matching_engine_matching_engine_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HealthCheckResponse) String() string {
+func (x *MassCancelResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HealthCheckResponse) ProtoMessage() {}
+func (*MassCancelResponse) ProtoMessage() {}
 
-func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
+func (x *MassCancelResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_v1_matching_engine_matching_engine_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -418,75 +525,973 @@ func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HealthCheckResponse.ProtoReflect.Descriptor instead.
-func (*HealthCheckResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use MassCancelResponse.ProtoReflect.Descriptor instead.
+func (*MassCancelResponse) Descriptor() ([]byte, []int) {
 	return file_proto_v1_matching_engine_matching_engine_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *HealthCheckResponse) GetIsHealthy() bool {
+func (x *MassCancelResponse) GetSuccess() bool {
 	if x != nil {
-		return x.IsHealthy
+		return x.Success
 	}
 	return false
 }
 
-func (x *HealthCheckResponse) GetOrdersProcessed() int64 {
+func (x *MassCancelResponse) GetCancelledCount() int64 {
 	if x != nil {
-		return x.OrdersProcessed
+		return x.CancelledCount
 	}
 	return 0
 }
 
-func (x *HealthCheckResponse) GetUptimeSeconds() int64 {
+func (x *MassCancelResponse) GetOrderIds() []string {
 	if x != nil {
-		return x.UptimeSeconds
+		return x.OrderIds
+	}
+	return nil
+}
+
+func (x *MassCancelResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *MassCancelResponse) GetErrorCode() common.ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return common.ErrorCode(0)
+}
+
+// AmendOrderRequest contains the new remaining quantity and limit price of a resting order.
+// Reducing quantity keeps queue priority; changing price or increasing quantity re-queues the order.
+type AmendOrderRequest struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	OrderId               string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	TraderId              int64                  `protobuf:"varint,4,opt,name=trader_id,json=traderId,proto3" json:"trader_id,omitempty"`
+	Quantity              int64                  `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	LimitPriceCents       int64                  `protobuf:"varint,6,opt,name=limit_price_cents,json=limitPriceCents,proto3" json:"limit_price_cents,omitempty"`
+	AvailableShares       int64                  `protobuf:"varint,7,opt,name=available_shares,json=availableShares,proto3" json:"available_shares,omitempty"`                     // For SELL: must cover any quantity increase
+	AvailableBalanceCents int64                  `protobuf:"varint,8,opt,name=available_balance_cents,json=availableBalanceCents,proto3" json:"available_balance_cents,omitempty"` // For BUY: must cover any increase in quantity times limit price
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *AmendOrderRequest) Reset() {
+	*x = AmendOrderRequest{}
+	mi := &file_proto_v1_matching_engine_matching_engine_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AmendOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AmendOrderRequest) ProtoMessage() {}
+
+func (x *AmendOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_matching_engine_matching_engine_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AmendOrderRequest.ProtoReflect.Descriptor instead.
+func (*AmendOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_matching_engine_matching_engine_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AmendOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *AmendOrderRequest) GetTraderId() int64 {
+	if x != nil {
+		return x.TraderId
 	}
 	return 0
 }
 
-var File_proto_v1_matching_engine_matching_engine_proto protoreflect.FileDescriptor
+func (x *AmendOrderRequest) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
 
-const file_proto_v1_matching_engine_matching_engine_proto_rawDesc = "" +
-	"\n" +
-	".proto/v1/matching_engine/matching_engine.proto\x12\x17trading.matching_engine\x1a\x1bproto/v1/common/types.proto\"\xe0\x02\n" +
-	"\x11PlaceOrderRequest\x12\x1b\n" +
-	"\ttrader_id\x18\x01 \x01(\x03R\btraderId\x12!\n" +
-	"\fstock_ticker\x18\x03 \x01(\tR\vstockTicker\x126\n" +
-	"\n" +
-	"order_type\x18\x04 \x01(\x0e2\x17.common.types.OrderTypeR\torderType\x12+\n" +
-	"\x04side\x18\x05 \x01(\x0e2\x17.common.types.OrderSideR\x04side\x12\x1a\n" +
-	"\bquantity\x18\x06 \x01(\x03R\bquantity\x12*\n" +
-	"\x11limit_price_cents\x18\a \x01(\x03R\x0flimitPriceCents\x12&\n" +
-	"\x0fclient_order_id\x18\b \x01(\tR\rclientOrderId\x126\n" +
-	"\x17available_balance_cents\x18\t \x01(\x03R\x15availableBalanceCents\"\xbe\x02\n" +
-	"\x12PlaceOrderResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x19\n" +
-	"\border_id\x18\x02 \x01(\tR\aorderId\x124\n" +
-	"\x16was_filled_immediately\x18\x03 \x01(\bR\x14wasFilledImmediately\x12'\n" +
-	"\x0ffilled_quantity\x18\x04 \x01(\x03R\x0efilledQuantity\x127\n" +
-	"\x18average_fill_price_cents\x18\x05 \x01(\x03R\x15averageFillPriceCents\x12#\n" +
-	"\rerror_message\x18\x06 \x01(\tR\ferrorMessage\x126\n" +
-	"\n" +
-	"error_code\x18\a \x01(\x0e2\x17.common.types.ErrorCodeR\terrorCode\"\x9c\x01\n" +
-	"\x12CancelOrderRequest\x12\x19\n" +
-	"\border_id\x18\x01 \x01(\tR\aorderId\x12!\n" +
-	"\fstock_ticker\x18\x02 \x01(\tR\vstockTicker\x12+\n" +
-	"\x04side\x18\x03 \x01(\x0e2\x17.common.types.OrderSideR\x04side\x12\x1b\n" +
-	"\ttrader_id\x18\x04 \x01(\x03R\btraderId\"o\n" +
-	"\x13CancelOrderResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x19\n" +
-	"\border_id\x18\x02 \x01(\tR\aorderId\x12#\n" +
-	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\"\x14\n" +
-	"\x12HealthCheckRequest\"\x86\x01\n" +
-	"\x13HealthCheckResponse\x12\x1d\n" +
-	"\n" +
-	"is_healthy\x18\x01 \x01(\bR\tisHealthy\x12)\n" +
-	"\x10orders_processed\x18\x02 \x01(\x03R\x0fordersProcessed\x12%\n" +
-	"\x0euptime_seconds\x18\x03 \x01(\x03R\ruptimeSeconds2\xcb\x02\n" +
-	"\x0eMatchingEngine\x12e\n" +
-	"\n" +
-	"PlaceOrder\x12*.trading.matching_engine.PlaceOrderRequest\x1a+.trading.matching_engine.PlaceOrderResponse\x12h\n" +
-	"\vCancelOrder\x12+.trading.matching_engine.CancelOrderRequest\x1a,.trading.matching_engine.CancelOrderResponse\x12h\n" +
+func (x *AmendOrderRequest) GetLimitPriceCents() int64 {
+	if x != nil {
+		return x.LimitPriceCents
+	}
+	return 0
+}
+
+func (x *AmendOrderRequest) GetAvailableShares() int64 {
+	if x != nil {
+		return x.AvailableShares
+	}
+	return 0
+}
+
+func (x *AmendOrderRequest) GetAvailableBalanceCents() int64 {
+	if x != nil {
+		return x.AvailableBalanceCents
+	}
+	return 0
+}
+
+// AmendOrderResponse returns the result of amending an order.
+type AmendOrderResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Success        bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	OrderId        string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	FilledQuantity int64                  `protobuf:"varint,3,opt,name=filled_quantity,json=filledQuantity,proto3" json:"filled_quantity,omitempty"` // Filled immediately if the new price crosses
+	ErrorMessage   string                 `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	ErrorCode      common.ErrorCode       `protobuf:"varint,5,opt,name=error_code,json=errorCode,proto3,enum=common.types.ErrorCode" json:"error_code,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AmendOrderResponse) Reset() {
+	*x = AmendOrderResponse{}
+	mi := &file_proto_v1_matching_engine_matching_engine_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AmendOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AmendOrderResponse) ProtoMessage() {}
+
+func (x *AmendOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_matching_engine_matching_engine_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AmendOrderResponse.ProtoReflect.Descriptor instead.
+func (*AmendOrderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_matching_engine_matching_engine_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AmendOrderResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *AmendOrderResponse) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *AmendOrderResponse) GetFilledQuantity() int64 {
+	if x != nil {
+		return x.FilledQuantity
+	}
+	return 0
+}
+
+func (x *AmendOrderResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *AmendOrderResponse) GetErrorCode() common.ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return common.ErrorCode(0)
+}
+
+// StartAuctionRequest names the stock whose book enters auction mode.
+type StartAuctionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StockTicker   string                 `protobuf:"bytes,1,opt,name=stock_ticker,json=stockTicker,proto3" json:"stock_ticker,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartAuctionRequest) Reset() {
+	*x = StartAuctionRequest{}
+	mi := &file_proto_v1_matching_engine_matching_engine_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartAuctionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartAuctionRequest) ProtoMessage() {}
+
+func (x *StartAuctionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_matching_engine_matching_engine_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartAuctionRequest.ProtoReflect.Descriptor instead.
+func (*StartAuctionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_matching_engine_matching_engine_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StartAuctionRequest) GetStockTicker() string {
+	if x != nil {
+		return x.StockTicker
+	}
+	return ""
+}
+
+// StartAuctionResponse returns the result of starting an auction.
+type StartAuctionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartAuctionResponse) Reset() {
+	*x = StartAuctionResponse{}
+	mi := &file_proto_v1_matching_engine_matching_engine_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartAuctionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartAuctionResponse) ProtoMessage() {}
+
+func (x *StartAuctionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_matching_engine_matching_engine_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartAuctionResponse.ProtoReflect.Descriptor instead.
+func (*StartAuctionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_matching_engine_matching_engine_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *StartAuctionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// UncrossAuctionRequest names the stock whose auction ends.
+type UncrossAuctionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StockTicker   string                 `protobuf:"bytes,1,opt,name=stock_ticker,json=stockTicker,proto3" json:"stock_ticker,omitempty"`
+	Closing       bool                   `protobuf:"varint,2,opt,name=closing,proto3" json:"closing,omitempty"` // The auction price becomes the stock's previous close
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UncrossAuctionRequest) Reset() {
+	*x = UncrossAuctionRequest{}
+	mi := &file_proto_v1_matching_engine_matching_engine_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UncrossAuctionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UncrossAuctionRequest) ProtoMessage() {}
+
+func (x *UncrossAuctionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_matching_engine_matching_engine_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UncrossAuctionRequest.ProtoReflect.Descriptor instead.
+func (*UncrossAuctionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_matching_engine_matching_engine_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UncrossAuctionRequest) GetStockTicker() string {
+	if x != nil {
+		return x.StockTicker
+	}
+	return ""
+}
+
+func (x *UncrossAuctionRequest) GetClosing() bool {
+	if x != nil {
+		return x.Closing
+	}
+	return false
+}
+
+// UncrossAuctionResponse returns the auction price and executed volume.
+type UncrossAuctionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	PriceCents    int64                  `protobuf:"varint,2,opt,name=price_cents,json=priceCents,proto3" json:"price_cents,omitempty"` // 0 if nothing crossed
+	Volume        int64                  `protobuf:"varint,3,opt,name=volume,proto3" json:"volume,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UncrossAuctionResponse) Reset() {
+	*x = UncrossAuctionResponse{}
+	mi := &file_proto_v1_matching_engine_matching_engine_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UncrossAuctionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UncrossAuctionResponse) ProtoMessage() {}
+
+func (x *UncrossAuctionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_matching_engine_matching_engine_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UncrossAuctionResponse.ProtoReflect.Descriptor instead.
+func (*UncrossAuctionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_matching_engine_matching_engine_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UncrossAuctionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UncrossAuctionResponse) GetPriceCents() int64 {
+	if x != nil {
+		return x.PriceCents
+	}
+	return 0
+}
+
+func (x *UncrossAuctionResponse) GetVolume() int64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+// Instrument describes a stock the engine trades and the grid its orders must sit on.
+type Instrument struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StockTicker   string                 `protobuf:"bytes,1,opt,name=stock_ticker,json=stockTicker,proto3" json:"stock_ticker,omitempty"`
+	TickSizeCents int64                  `protobuf:"varint,2,opt,name=tick_size_cents,json=tickSizeCents,proto3" json:"tick_size_cents,omitempty"` // 0 defaults to 1
+	LotSize       int64                  `protobuf:"varint,3,opt,name=lot_size,json=lotSize,proto3" json:"lot_size,omitempty"`                     // 0 defaults to 1
+	MinQuantity   int64                  `protobuf:"varint,4,opt,name=min_quantity,json=minQuantity,proto3" json:"min_quantity,omitempty"`         // 0 defaults to 1
+	IsActive      bool                   `protobuf:"varint,5,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Instrument) Reset() {
+	*x = Instrument{}
+	mi := &file_proto_v1_matching_engine_matching_engine_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Instrument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Instrument) ProtoMessage() {}
+
+func (x *Instrument) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_matching_engine_matching_engine_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Instrument.ProtoReflect.Descriptor instead.
+func (*Instrument) Descriptor() ([]byte, []int) {
+	return file_proto_v1_matching_engine_matching_engine_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Instrument) GetStockTicker() string {
+	if x != nil {
+		return x.StockTicker
+	}
+	return ""
+}
+
+func (x *Instrument) GetTickSizeCents() int64 {
+	if x != nil {
+		return x.TickSizeCents
+	}
+	return 0
+}
+
+func (x *Instrument) GetLotSize() int64 {
+	if x != nil {
+		return x.LotSize
+	}
+	return 0
+}
+
+func (x *Instrument) GetMinQuantity() int64 {
+	if x != nil {
+		return x.MinQuantity
+	}
+	return 0
+}
+
+func (x *Instrument) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+// ListInstrumentsRequest is an empty request for the instrument list.
+type ListInstrumentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInstrumentsRequest) Reset() {
+	*x = ListInstrumentsRequest{}
+	mi := &file_proto_v1_matching_engine_matching_engine_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInstrumentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInstrumentsRequest) ProtoMessage() {}
+
+func (x *ListInstrumentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_matching_engine_matching_engine_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInstrumentsRequest.ProtoReflect.Descriptor instead.
+func (*ListInstrumentsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_matching_engine_matching_engine_proto_rawDescGZIP(), []int{13}
+}
+
+// ListInstrumentsResponse returns the registered instruments by ticker.
+type ListInstrumentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instruments   []*Instrument          `protobuf:"bytes,1,rep,name=instruments,proto3" json:"instruments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInstrumentsResponse) Reset() {
+	*x = ListInstrumentsResponse{}
+	mi := &file_proto_v1_matching_engine_matching_engine_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInstrumentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInstrumentsResponse) ProtoMessage() {}
+
+func (x *ListInstrumentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_matching_engine_matching_engine_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInstrumentsResponse.ProtoReflect.Descriptor instead.
+func (*ListInstrumentsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_matching_engine_matching_engine_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListInstrumentsResponse) GetInstruments() []*Instrument {
+	if x != nil {
+		return x.Instruments
+	}
+	return nil
+}
+
+// AddInstrumentRequest contains the instrument to register.
+type AddInstrumentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instrument    *Instrument            `protobuf:"bytes,1,opt,name=instrument,proto3" json:"instrument,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddInstrumentRequest) Reset() {
+	*x = AddInstrumentRequest{}
+	mi := &file_proto_v1_matching_engine_matching_engine_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddInstrumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddInstrumentRequest) ProtoMessage() {}
+
+func (x *AddInstrumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_matching_engine_matching_engine_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddInstrumentRequest.ProtoReflect.Descriptor instead.
+func (*AddInstrumentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_matching_engine_matching_engine_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AddInstrumentRequest) GetInstrument() *Instrument {
+	if x != nil {
+		return x.Instrument
+	}
+	return nil
+}
+
+// AddInstrumentResponse returns the result of registering an instrument.
+type AddInstrumentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddInstrumentResponse) Reset() {
+	*x = AddInstrumentResponse{}
+	mi := &file_proto_v1_matching_engine_matching_engine_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddInstrumentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddInstrumentResponse) ProtoMessage() {}
+
+func (x *AddInstrumentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_matching_engine_matching_engine_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddInstrumentResponse.ProtoReflect.Descriptor instead.
+func (*AddInstrumentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_matching_engine_matching_engine_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AddInstrumentResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// DeactivateInstrumentRequest names the stock to stop trading.
+type DeactivateInstrumentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StockTicker   string                 `protobuf:"bytes,1,opt,name=stock_ticker,json=stockTicker,proto3" json:"stock_ticker,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeactivateInstrumentRequest) Reset() {
+	*x = DeactivateInstrumentRequest{}
+	mi := &file_proto_v1_matching_engine_matching_engine_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeactivateInstrumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeactivateInstrumentRequest) ProtoMessage() {}
+
+func (x *DeactivateInstrumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_matching_engine_matching_engine_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeactivateInstrumentRequest.ProtoReflect.Descriptor instead.
+func (*DeactivateInstrumentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_matching_engine_matching_engine_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeactivateInstrumentRequest) GetStockTicker() string {
+	if x != nil {
+		return x.StockTicker
+	}
+	return ""
+}
+
+// DeactivateInstrumentResponse returns the result of deactivating an instrument.
+type DeactivateInstrumentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeactivateInstrumentResponse) Reset() {
+	*x = DeactivateInstrumentResponse{}
+	mi := &file_proto_v1_matching_engine_matching_engine_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeactivateInstrumentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeactivateInstrumentResponse) ProtoMessage() {}
+
+func (x *DeactivateInstrumentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_matching_engine_matching_engine_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeactivateInstrumentResponse.ProtoReflect.Descriptor instead.
+func (*DeactivateInstrumentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_matching_engine_matching_engine_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DeactivateInstrumentResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// HealthCheckRequest is an empty request for health checks.
+type HealthCheckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealthCheckRequest) Reset() {
+	*x = HealthCheckRequest{}
+	mi := &file_proto_v1_matching_engine_matching_engine_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthCheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthCheckRequest) ProtoMessage() {}
+
+func (x *HealthCheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_matching_engine_matching_engine_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthCheckRequest.ProtoReflect.Descriptor instead.
+func (*HealthCheckRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_matching_engine_matching_engine_proto_rawDescGZIP(), []int{19}
+}
+
+// HealthCheckResponse returns health and basic engine stats.
+type HealthCheckResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	IsHealthy       bool                   `protobuf:"varint,1,opt,name=is_healthy,json=isHealthy,proto3" json:"is_healthy,omitempty"`
+	OrdersProcessed int64                  `protobuf:"varint,2,opt,name=orders_processed,json=ordersProcessed,proto3" json:"orders_processed,omitempty"`
+	UptimeSeconds   int64                  `protobuf:"varint,3,opt,name=uptime_seconds,json=uptimeSeconds,proto3" json:"uptime_seconds,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *HealthCheckResponse) Reset() {
+	*x = HealthCheckResponse{}
+	mi := &file_proto_v1_matching_engine_matching_engine_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthCheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthCheckResponse) ProtoMessage() {}
+
+func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_matching_engine_matching_engine_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthCheckResponse.ProtoReflect.Descriptor instead.
+func (*HealthCheckResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_matching_engine_matching_engine_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *HealthCheckResponse) GetIsHealthy() bool {
+	if x != nil {
+		return x.IsHealthy
+	}
+	return false
+}
+
+func (x *HealthCheckResponse) GetOrdersProcessed() int64 {
+	if x != nil {
+		return x.OrdersProcessed
+	}
+	return 0
+}
+
+func (x *HealthCheckResponse) GetUptimeSeconds() int64 {
+	if x != nil {
+		return x.UptimeSeconds
+	}
+	return 0
+}
+
+var File_proto_v1_matching_engine_matching_engine_proto protoreflect.FileDescriptor
+
+const file_proto_v1_matching_engine_matching_engine_proto_rawDesc = "" +
+	"\n" +
+	".proto/v1/matching_engine/matching_engine.proto\x12\x17trading.matching_engine\x1a\x1bproto/v1/common/types.proto\x1a&proto/v1/market_data/market_data.proto\"\x8f\a\n" +
+	"\x11PlaceOrderRequest\x12\x1b\n" +
+	"\ttrader_id\x18\x01 \x01(\x03R\btraderId\x12!\n" +
+	"\fstock_ticker\x18\x02 \x01(\tR\vstockTicker\x126\n" +
+	"\n" +
+	"order_type\x18\x03 \x01(\x0e2\x17.common.types.OrderTypeR\torderType\x12+\n" +
+	"\x04side\x18\x04 \x01(\x0e2\x17.common.types.OrderSideR\x04side\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\x03R\bquantity\x12*\n" +
+	"\x11limit_price_cents\x18\x06 \x01(\x03R\x0flimitPriceCents\x12&\n" +
+	"\x0fclient_order_id\x18\a \x01(\tR\rclientOrderId\x126\n" +
+	"\x17available_balance_cents\x18\b \x01(\x03R\x15availableBalanceCents\x12.\n" +
+	"\x13trigger_price_cents\x18\t \x01(\x03R\x11triggerPriceCents\x12=\n" +
+	"\rtime_in_force\x18\n" +
+	" \x01(\x0e2\x19.common.types.TimeInForceR\vtimeInForce\x12\"\n" +
+	"\rexpires_at_ms\x18\v \x01(\x03R\vexpiresAtMs\x12\x1b\n" +
+	"\tpost_only\x18\f \x01(\bR\bpostOnly\x12*\n" +
+	"\x11post_only_reprice\x18\r \x01(\bR\x0fpostOnlyReprice\x12)\n" +
+	"\x10display_quantity\x18\x0e \x01(\x03R\x0fdisplayQuantity\x12&\n" +
+	"\x0fowner_trader_id\x18\x0f \x01(\x03R\rownerTraderId\x12U\n" +
+	"\x15self_trade_prevention\x18\x10 \x01(\x0e2!.common.types.SelfTradePreventionR\x13selfTradePrevention\x122\n" +
+	"\x15trailing_offset_cents\x18\x11 \x01(\x03R\x13trailingOffsetCents\x12.\n" +
+	"\x13trailing_offset_bps\x18\x12 \x01(\x03R\x11trailingOffsetBps\x12.\n" +
+	"\x10available_shares\x18\x13 \x01(\x03H\x00R\x0favailableShares\x88\x01\x01B\x13\n" +
+	"\x11_available_shares\"\xbe\x02\n" +
+	"\x12PlaceOrderResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x124\n" +
+	"\x16was_filled_immediately\x18\x03 \x01(\bR\x14wasFilledImmediately\x12'\n" +
+	"\x0ffilled_quantity\x18\x04 \x01(\x03R\x0efilledQuantity\x127\n" +
+	"\x18average_fill_price_cents\x18\x05 \x01(\x03R\x15averageFillPriceCents\x12#\n" +
+	"\rerror_message\x18\x06 \x01(\tR\ferrorMessage\x126\n" +
+	"\n" +
+	"error_code\x18\a \x01(\x0e2\x17.common.types.ErrorCodeR\terrorCode\"l\n" +
+	"\x12CancelOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1b\n" +
+	"\ttrader_id\x18\x04 \x01(\x03R\btraderIdJ\x04\b\x02\x10\x03J\x04\b\x03\x10\x04R\fstock_tickerR\x04side\"\xa7\x01\n" +
+	"\x13CancelOrderResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12#\n" +
+	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\x126\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\x0e2\x17.common.types.ErrorCodeR\terrorCode\"\x80\x01\n" +
+	"\x11MassCancelRequest\x12\x1b\n" +
+	"\ttrader_id\x18\x01 \x01(\x03R\btraderId\x12!\n" +
+	"\fstock_ticker\x18\x02 \x01(\tR\vstockTicker\x12+\n" +
+	"\x04side\x18\x03 \x01(\x0e2\x17.common.types.OrderSideR\x04side\"\xd1\x01\n" +
+	"\x12MassCancelResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12'\n" +
+	"\x0fcancelled_count\x18\x02 \x01(\x03R\x0ecancelledCount\x12\x1b\n" +
+	"\torder_ids\x18\x03 \x03(\tR\borderIds\x12#\n" +
+	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\x126\n" +
+	"\n" +
+	"error_code\x18\x05 \x01(\x0e2\x17.common.types.ErrorCodeR\terrorCode\"\x96\x02\n" +
+	"\x11AmendOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1b\n" +
+	"\ttrader_id\x18\x04 \x01(\x03R\btraderId\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\x03R\bquantity\x12*\n" +
+	"\x11limit_price_cents\x18\x06 \x01(\x03R\x0flimitPriceCents\x12)\n" +
+	"\x10available_shares\x18\a \x01(\x03R\x0favailableShares\x126\n" +
+	"\x17available_balance_cents\x18\b \x01(\x03R\x15availableBalanceCentsJ\x04\b\x02\x10\x03J\x04\b\x03\x10\x04R\fstock_tickerR\x04side\"\xcf\x01\n" +
+	"\x12AmendOrderResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12'\n" +
+	"\x0ffilled_quantity\x18\x03 \x01(\x03R\x0efilledQuantity\x12#\n" +
+	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\x126\n" +
+	"\n" +
+	"error_code\x18\x05 \x01(\x0e2\x17.common.types.ErrorCodeR\terrorCode\"8\n" +
+	"\x13StartAuctionRequest\x12!\n" +
+	"\fstock_ticker\x18\x01 \x01(\tR\vstockTicker\"0\n" +
+	"\x14StartAuctionResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"T\n" +
+	"\x15UncrossAuctionRequest\x12!\n" +
+	"\fstock_ticker\x18\x01 \x01(\tR\vstockTicker\x12\x18\n" +
+	"\aclosing\x18\x02 \x01(\bR\aclosing\"k\n" +
+	"\x16UncrossAuctionResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1f\n" +
+	"\vprice_cents\x18\x02 \x01(\x03R\n" +
+	"priceCents\x12\x16\n" +
+	"\x06volume\x18\x03 \x01(\x03R\x06volume\"\xb2\x01\n" +
+	"\n" +
+	"Instrument\x12!\n" +
+	"\fstock_ticker\x18\x01 \x01(\tR\vstockTicker\x12&\n" +
+	"\x0ftick_size_cents\x18\x02 \x01(\x03R\rtickSizeCents\x12\x19\n" +
+	"\blot_size\x18\x03 \x01(\x03R\alotSize\x12!\n" +
+	"\fmin_quantity\x18\x04 \x01(\x03R\vminQuantity\x12\x1b\n" +
+	"\tis_active\x18\x05 \x01(\bR\bisActive\"\x18\n" +
+	"\x16ListInstrumentsRequest\"`\n" +
+	"\x17ListInstrumentsResponse\x12E\n" +
+	"\vinstruments\x18\x01 \x03(\v2#.trading.matching_engine.InstrumentR\vinstruments\"[\n" +
+	"\x14AddInstrumentRequest\x12C\n" +
+	"\n" +
+	"instrument\x18\x01 \x01(\v2#.trading.matching_engine.InstrumentR\n" +
+	"instrument\"1\n" +
+	"\x15AddInstrumentResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"@\n" +
+	"\x1bDeactivateInstrumentRequest\x12!\n" +
+	"\fstock_ticker\x18\x01 \x01(\tR\vstockTicker\"8\n" +
+	"\x1cDeactivateInstrumentResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x14\n" +
+	"\x12HealthCheckRequest\"\x86\x01\n" +
+	"\x13HealthCheckResponse\x12\x1d\n" +
+	"\n" +
+	"is_healthy\x18\x01 \x01(\bR\tisHealthy\x12)\n" +
+	"\x10orders_processed\x18\x02 \x01(\x03R\x0fordersProcessed\x12%\n" +
+	"\x0euptime_seconds\x18\x03 \x01(\x03R\ruptimeSeconds2\xbf\t\n" +
+	"\x0eMatchingEngine\x12e\n" +
+	"\n" +
+	"PlaceOrder\x12*.trading.matching_engine.PlaceOrderRequest\x1a+.trading.matching_engine.PlaceOrderResponse\x12h\n" +
+	"\vCancelOrder\x12+.trading.matching_engine.CancelOrderRequest\x1a,.trading.matching_engine.CancelOrderResponse\x12e\n" +
+	"\n" +
+	"MassCancel\x12*.trading.matching_engine.MassCancelRequest\x1a+.trading.matching_engine.MassCancelResponse\x12e\n" +
+	"\n" +
+	"AmendOrder\x12*.trading.matching_engine.AmendOrderRequest\x1a+.trading.matching_engine.AmendOrderResponse\x12k\n" +
+	"\fStartAuction\x12,.trading.matching_engine.StartAuctionRequest\x1a-.trading.matching_engine.StartAuctionResponse\x12q\n" +
+	"\x0eUncrossAuction\x12..trading.matching_engine.UncrossAuctionRequest\x1a/.trading.matching_engine.UncrossAuctionResponse\x12t\n" +
+	"\x0fListInstruments\x12/.trading.matching_engine.ListInstrumentsRequest\x1a0.trading.matching_engine.ListInstrumentsResponse\x12n\n" +
+	"\rAddInstrument\x12-.trading.matching_engine.AddInstrumentRequest\x1a..trading.matching_engine.AddInstrumentResponse\x12\x83\x01\n" +
+	"\x14DeactivateInstrument\x124.trading.matching_engine.DeactivateInstrumentRequest\x1a5.trading.matching_engine.DeactivateInstrumentResponse\x12X\n" +
+	"\fGetOrderBook\x12(.trading.market_data.GetOrderBookRequest\x1a\x1e.trading.market_data.OrderBook\x12h\n" +
 	"\vHealthCheck\x12+.trading.matching_engine.HealthCheckRequest\x1a,.trading.matching_engine.HealthCheckResponseBMZKgithub.com/Marwan051/tradding_platform_game/proto/gen/go/v1/matching_engineb\x06proto3"
 
 var (
@@ -501,34 +1506,76 @@ func file_proto_v1_matching_engine_matching_engine_proto_rawDescGZIP() []byte {
 	return file_proto_v1_matching_engine_matching_engine_proto_rawDescData
 }
 
-var file_proto_v1_matching_engine_matching_engine_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_proto_v1_matching_engine_matching_engine_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_proto_v1_matching_engine_matching_engine_proto_goTypes = []any{
-	(*PlaceOrderRequest)(nil),   // 0: trading.matching_engine.PlaceOrderRequest
-	(*PlaceOrderResponse)(nil),  // 1: trading.matching_engine.PlaceOrderResponse
-	(*CancelOrderRequest)(nil),  // 2: trading.matching_engine.CancelOrderRequest
-	(*CancelOrderResponse)(nil), // 3: trading.matching_engine.CancelOrderResponse
-	(*HealthCheckRequest)(nil),  // 4: trading.matching_engine.HealthCheckRequest
-	(*HealthCheckResponse)(nil), // 5: trading.matching_engine.HealthCheckResponse
-	(common.OrderType)(0),       // 6: common.types.OrderType
-	(common.OrderSide)(0),       // 7: common.types.OrderSide
-	(common.ErrorCode)(0),       // 8: common.types.ErrorCode
+	(*PlaceOrderRequest)(nil),               // 0: trading.matching_engine.PlaceOrderRequest
+	(*PlaceOrderResponse)(nil),              // 1: trading.matching_engine.PlaceOrderResponse
+	(*CancelOrderRequest)(nil),              // 2: trading.matching_engine.CancelOrderRequest
+	(*CancelOrderResponse)(nil),             // 3: trading.matching_engine.CancelOrderResponse
+	(*MassCancelRequest)(nil),               // 4: trading.matching_engine.MassCancelRequest
+	(*MassCancelResponse)(nil),              // 5: trading.matching_engine.MassCancelResponse
+	(*AmendOrderRequest)(nil),               // 6: trading.matching_engine.AmendOrderRequest
+	(*AmendOrderResponse)(nil),              // 7: trading.matching_engine.AmendOrderResponse
+	(*StartAuctionRequest)(nil),             // 8: trading.matching_engine.StartAuctionRequest
+	(*StartAuctionResponse)(nil),            // 9: trading.matching_engine.StartAuctionResponse
+	(*UncrossAuctionRequest)(nil),           // 10: trading.matching_engine.UncrossAuctionRequest
+	(*UncrossAuctionResponse)(nil),          // 11: trading.matching_engine.UncrossAuctionResponse
+	(*Instrument)(nil),                      // 12: trading.matching_engine.Instrument
+	(*ListInstrumentsRequest)(nil),          // 13: trading.matching_engine.ListInstrumentsRequest
+	(*ListInstrumentsResponse)(nil),         // 14: trading.matching_engine.ListInstrumentsResponse
+	(*AddInstrumentRequest)(nil),            // 15: trading.matching_engine.AddInstrumentRequest
+	(*AddInstrumentResponse)(nil),           // 16: trading.matching_engine.AddInstrumentResponse
+	(*DeactivateInstrumentRequest)(nil),     // 17: trading.matching_engine.DeactivateInstrumentRequest
+	(*DeactivateInstrumentResponse)(nil),    // 18: trading.matching_engine.DeactivateInstrumentResponse
+	(*HealthCheckRequest)(nil),              // 19: trading.matching_engine.HealthCheckRequest
+	(*HealthCheckResponse)(nil),             // 20: trading.matching_engine.HealthCheckResponse
+	(common.OrderType)(0),                   // 21: common.types.OrderType
+	(common.OrderSide)(0),                   // 22: common.types.OrderSide
+	(common.TimeInForce)(0),                 // 23: common.types.TimeInForce
+	(common.SelfTradePrevention)(0),         // 24: common.types.SelfTradePrevention
+	(common.ErrorCode)(0),                   // 25: common.types.ErrorCode
+	(*market_data.GetOrderBookRequest)(nil), // 26: trading.market_data.GetOrderBookRequest
+	(*market_data.OrderBook)(nil),           // 27: trading.market_data.OrderBook
 }
 var file_proto_v1_matching_engine_matching_engine_proto_depIdxs = []int32{
-	6, // 0: trading.matching_engine.PlaceOrderRequest.order_type:type_name -> common.types.OrderType
-	7, // 1: trading.matching_engine.PlaceOrderRequest.side:type_name -> common.types.OrderSide
-	8, // 2: trading.matching_engine.PlaceOrderResponse.error_code:type_name -> common.types.ErrorCode
-	7, // 3: trading.matching_engine.CancelOrderRequest.side:type_name -> common.types.OrderSide
-	0, // 4: trading.matching_engine.MatchingEngine.PlaceOrder:input_type -> trading.matching_engine.PlaceOrderRequest
-	2, // 5: trading.matching_engine.MatchingEngine.CancelOrder:input_type -> trading.matching_engine.CancelOrderRequest
-	4, // 6: trading.matching_engine.MatchingEngine.HealthCheck:input_type -> trading.matching_engine.HealthCheckRequest
-	1, // 7: trading.matching_engine.MatchingEngine.PlaceOrder:output_type -> trading.matching_engine.PlaceOrderResponse
-	3, // 8: trading.matching_engine.MatchingEngine.CancelOrder:output_type -> trading.matching_engine.CancelOrderResponse
-	5, // 9: trading.matching_engine.MatchingEngine.HealthCheck:output_type -> trading.matching_engine.HealthCheckResponse
-	7, // [7:10] is the sub-list for method output_type
-	4, // [4:7] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	21, // 0: trading.matching_engine.PlaceOrderRequest.order_type:type_name -> common.types.OrderType
+	22, // 1: trading.matching_engine.PlaceOrderRequest.side:type_name -> common.types.OrderSide
+	23, // 2: trading.matching_engine.PlaceOrderRequest.time_in_force:type_name -> common.types.TimeInForce
+	24, // 3: trading.matching_engine.PlaceOrderRequest.self_trade_prevention:type_name -> common.types.SelfTradePrevention
+	25, // 4: trading.matching_engine.PlaceOrderResponse.error_code:type_name -> common.types.ErrorCode
+	25, // 5: trading.matching_engine.CancelOrderResponse.error_code:type_name -> common.types.ErrorCode
+	22, // 6: trading.matching_engine.MassCancelRequest.side:type_name -> common.types.OrderSide
+	25, // 7: trading.matching_engine.MassCancelResponse.error_code:type_name -> common.types.ErrorCode
+	25, // 8: trading.matching_engine.AmendOrderResponse.error_code:type_name -> common.types.ErrorCode
+	12, // 9: trading.matching_engine.ListInstrumentsResponse.instruments:type_name -> trading.matching_engine.Instrument
+	12, // 10: trading.matching_engine.AddInstrumentRequest.instrument:type_name -> trading.matching_engine.Instrument
+	0,  // 11: trading.matching_engine.MatchingEngine.PlaceOrder:input_type -> trading.matching_engine.PlaceOrderRequest
+	2,  // 12: trading.matching_engine.MatchingEngine.CancelOrder:input_type -> trading.matching_engine.CancelOrderRequest
+	4,  // 13: trading.matching_engine.MatchingEngine.MassCancel:input_type -> trading.matching_engine.MassCancelRequest
+	6,  // 14: trading.matching_engine.MatchingEngine.AmendOrder:input_type -> trading.matching_engine.AmendOrderRequest
+	8,  // 15: trading.matching_engine.MatchingEngine.StartAuction:input_type -> trading.matching_engine.StartAuctionRequest
+	10, // 16: trading.matching_engine.MatchingEngine.UncrossAuction:input_type -> trading.matching_engine.UncrossAuctionRequest
+	13, // 17: trading.matching_engine.MatchingEngine.ListInstruments:input_type -> trading.matching_engine.ListInstrumentsRequest
+	15, // 18: trading.matching_engine.MatchingEngine.AddInstrument:input_type -> trading.matching_engine.AddInstrumentRequest
+	17, // 19: trading.matching_engine.MatchingEngine.DeactivateInstrument:input_type -> trading.matching_engine.DeactivateInstrumentRequest
+	26, // 20: trading.matching_engine.MatchingEngine.GetOrderBook:input_type -> trading.market_data.GetOrderBookRequest
+	19, // 21: trading.matching_engine.MatchingEngine.HealthCheck:input_type -> trading.matching_engine.HealthCheckRequest
+	1,  // 22: trading.matching_engine.MatchingEngine.PlaceOrder:output_type -> trading.matching_engine.PlaceOrderResponse
+	3,  // 23: trading.matching_engine.MatchingEngine.CancelOrder:output_type -> trading.matching_engine.CancelOrderResponse
+	5,  // 24: trading.matching_engine.MatchingEngine.MassCancel:output_type -> trading.matching_engine.MassCancelResponse
+	7,  // 25: trading.matching_engine.MatchingEngine.AmendOrder:output_type -> trading.matching_engine.AmendOrderResponse
+	9,  // 26: trading.matching_engine.MatchingEngine.StartAuction:output_type -> trading.matching_engine.StartAuctionResponse
+	11, // 27: trading.matching_engine.MatchingEngine.UncrossAuction:output_type -> trading.matching_engine.UncrossAuctionResponse
+	14, // 28: trading.matching_engine.MatchingEngine.ListInstruments:output_type -> trading.matching_engine.ListInstrumentsResponse
+	16, // 29: trading.matching_engine.MatchingEngine.AddInstrument:output_type -> trading.matching_engine.AddInstrumentResponse
+	18, // 30: trading.matching_engine.MatchingEngine.DeactivateInstrument:output_type -> trading.matching_engine.DeactivateInstrumentResponse
+	27, // 31: trading.matching_engine.MatchingEngine.GetOrderBook:output_type -> trading.market_data.OrderBook
+	20, // 32: trading.matching_engine.MatchingEngine.HealthCheck:output_type -> trading.matching_engine.HealthCheckResponse
+	22, // [22:33] is the sub-list for method output_type
+	11, // [11:22] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_proto_v1_matching_engine_matching_engine_proto_init() }
@@ -536,13 +1583,14 @@ func file_proto_v1_matching_engine_matching_engine_proto_init() {
 	if File_proto_v1_matching_engine_matching_engine_proto != nil {
 		return
 	}
+	file_proto_v1_matching_engine_matching_engine_proto_msgTypes[0].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_v1_matching_engine_matching_engine_proto_rawDesc), len(file_proto_v1_matching_engine_matching_engine_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
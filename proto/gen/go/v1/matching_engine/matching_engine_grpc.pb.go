@@ -8,6 +8,7 @@ package matching_engine
 
 import (
 	context "context"
+	market_data "github.com/Marwan051/tradding_platform_game/proto/gen/go/v1/market_data"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -19,9 +20,17 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	MatchingEngine_PlaceOrder_FullMethodName  = "/trading.matching_engine.MatchingEngine/PlaceOrder"
-	MatchingEngine_CancelOrder_FullMethodName = "/trading.matching_engine.MatchingEngine/CancelOrder"
-	MatchingEngine_HealthCheck_FullMethodName = "/trading.matching_engine.MatchingEngine/HealthCheck"
+	MatchingEngine_PlaceOrder_FullMethodName           = "/trading.matching_engine.MatchingEngine/PlaceOrder"
+	MatchingEngine_CancelOrder_FullMethodName          = "/trading.matching_engine.MatchingEngine/CancelOrder"
+	MatchingEngine_MassCancel_FullMethodName           = "/trading.matching_engine.MatchingEngine/MassCancel"
+	MatchingEngine_AmendOrder_FullMethodName           = "/trading.matching_engine.MatchingEngine/AmendOrder"
+	MatchingEngine_StartAuction_FullMethodName         = "/trading.matching_engine.MatchingEngine/StartAuction"
+	MatchingEngine_UncrossAuction_FullMethodName       = "/trading.matching_engine.MatchingEngine/UncrossAuction"
+	MatchingEngine_ListInstruments_FullMethodName      = "/trading.matching_engine.MatchingEngine/ListInstruments"
+	MatchingEngine_AddInstrument_FullMethodName        = "/trading.matching_engine.MatchingEngine/AddInstrument"
+	MatchingEngine_DeactivateInstrument_FullMethodName = "/trading.matching_engine.MatchingEngine/DeactivateInstrument"
+	MatchingEngine_GetOrderBook_FullMethodName         = "/trading.matching_engine.MatchingEngine/GetOrderBook"
+	MatchingEngine_HealthCheck_FullMethodName          = "/trading.matching_engine.MatchingEngine/HealthCheck"
 )
 
 // MatchingEngineClient is the client API for MatchingEngine service.
@@ -34,6 +43,22 @@ type MatchingEngineClient interface {
 	PlaceOrder(ctx context.Context, in *PlaceOrderRequest, opts ...grpc.CallOption) (*PlaceOrderResponse, error)
 	// CancelOrder cancels an existing order by ID.
 	CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error)
+	// MassCancel cancels every open order of a trader, optionally only for one stock and/or side.
+	MassCancel(ctx context.Context, in *MassCancelRequest, opts ...grpc.CallOption) (*MassCancelResponse, error)
+	// AmendOrder changes the quantity and/or limit price of a resting order, keeping its ID.
+	AmendOrder(ctx context.Context, in *AmendOrderRequest, opts ...grpc.CallOption) (*AmendOrderResponse, error)
+	// StartAuction stops continuous matching for a stock and collects orders for an auction.
+	StartAuction(ctx context.Context, in *StartAuctionRequest, opts ...grpc.CallOption) (*StartAuctionResponse, error)
+	// UncrossAuction executes a stock's auction at a single price and resumes continuous matching.
+	UncrossAuction(ctx context.Context, in *UncrossAuctionRequest, opts ...grpc.CallOption) (*UncrossAuctionResponse, error)
+	// ListInstruments returns the stocks the engine accepts orders for.
+	ListInstruments(ctx context.Context, in *ListInstrumentsRequest, opts ...grpc.CallOption) (*ListInstrumentsResponse, error)
+	// AddInstrument registers a stock, or updates the spec and status of a registered one.
+	AddInstrument(ctx context.Context, in *AddInstrumentRequest, opts ...grpc.CallOption) (*AddInstrumentResponse, error)
+	// DeactivateInstrument stops a stock from accepting new orders.
+	DeactivateInstrument(ctx context.Context, in *DeactivateInstrumentRequest, opts ...grpc.CallOption) (*DeactivateInstrumentResponse, error)
+	// GetOrderBook returns the live aggregated price levels of a stock's book.
+	GetOrderBook(ctx context.Context, in *market_data.GetOrderBookRequest, opts ...grpc.CallOption) (*market_data.OrderBook, error)
 	// HealthCheck returns the current health status of the engine.
 	HealthCheck(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error)
 }
@@ -66,6 +91,86 @@ func (c *matchingEngineClient) CancelOrder(ctx context.Context, in *CancelOrderR
 	return out, nil
 }
 
+func (c *matchingEngineClient) MassCancel(ctx context.Context, in *MassCancelRequest, opts ...grpc.CallOption) (*MassCancelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MassCancelResponse)
+	err := c.cc.Invoke(ctx, MatchingEngine_MassCancel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchingEngineClient) AmendOrder(ctx context.Context, in *AmendOrderRequest, opts ...grpc.CallOption) (*AmendOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AmendOrderResponse)
+	err := c.cc.Invoke(ctx, MatchingEngine_AmendOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchingEngineClient) StartAuction(ctx context.Context, in *StartAuctionRequest, opts ...grpc.CallOption) (*StartAuctionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartAuctionResponse)
+	err := c.cc.Invoke(ctx, MatchingEngine_StartAuction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchingEngineClient) UncrossAuction(ctx context.Context, in *UncrossAuctionRequest, opts ...grpc.CallOption) (*UncrossAuctionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UncrossAuctionResponse)
+	err := c.cc.Invoke(ctx, MatchingEngine_UncrossAuction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchingEngineClient) ListInstruments(ctx context.Context, in *ListInstrumentsRequest, opts ...grpc.CallOption) (*ListInstrumentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListInstrumentsResponse)
+	err := c.cc.Invoke(ctx, MatchingEngine_ListInstruments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchingEngineClient) AddInstrument(ctx context.Context, in *AddInstrumentRequest, opts ...grpc.CallOption) (*AddInstrumentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddInstrumentResponse)
+	err := c.cc.Invoke(ctx, MatchingEngine_AddInstrument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchingEngineClient) DeactivateInstrument(ctx context.Context, in *DeactivateInstrumentRequest, opts ...grpc.CallOption) (*DeactivateInstrumentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeactivateInstrumentResponse)
+	err := c.cc.Invoke(ctx, MatchingEngine_DeactivateInstrument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchingEngineClient) GetOrderBook(ctx context.Context, in *market_data.GetOrderBookRequest, opts ...grpc.CallOption) (*market_data.OrderBook, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(market_data.OrderBook)
+	err := c.cc.Invoke(ctx, MatchingEngine_GetOrderBook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *matchingEngineClient) HealthCheck(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(HealthCheckResponse)
@@ -86,6 +191,22 @@ type MatchingEngineServer interface {
 	PlaceOrder(context.Context, *PlaceOrderRequest) (*PlaceOrderResponse, error)
 	// CancelOrder cancels an existing order by ID.
 	CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderResponse, error)
+	// MassCancel cancels every open order of a trader, optionally only for one stock and/or side.
+	MassCancel(context.Context, *MassCancelRequest) (*MassCancelResponse, error)
+	// AmendOrder changes the quantity and/or limit price of a resting order, keeping its ID.
+	AmendOrder(context.Context, *AmendOrderRequest) (*AmendOrderResponse, error)
+	// StartAuction stops continuous matching for a stock and collects orders for an auction.
+	StartAuction(context.Context, *StartAuctionRequest) (*StartAuctionResponse, error)
+	// UncrossAuction executes a stock's auction at a single price and resumes continuous matching.
+	UncrossAuction(context.Context, *UncrossAuctionRequest) (*UncrossAuctionResponse, error)
+	// ListInstruments returns the stocks the engine accepts orders for.
+	ListInstruments(context.Context, *ListInstrumentsRequest) (*ListInstrumentsResponse, error)
+	// AddInstrument registers a stock, or updates the spec and status of a registered one.
+	AddInstrument(context.Context, *AddInstrumentRequest) (*AddInstrumentResponse, error)
+	// DeactivateInstrument stops a stock from accepting new orders.
+	DeactivateInstrument(context.Context, *DeactivateInstrumentRequest) (*DeactivateInstrumentResponse, error)
+	// GetOrderBook returns the live aggregated price levels of a stock's book.
+	GetOrderBook(context.Context, *market_data.GetOrderBookRequest) (*market_data.OrderBook, error)
 	// HealthCheck returns the current health status of the engine.
 	HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error)
 	mustEmbedUnimplementedMatchingEngineServer()
@@ -104,6 +225,30 @@ func (UnimplementedMatchingEngineServer) PlaceOrder(context.Context, *PlaceOrder
 func (UnimplementedMatchingEngineServer) CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method CancelOrder not implemented")
 }
+func (UnimplementedMatchingEngineServer) MassCancel(context.Context, *MassCancelRequest) (*MassCancelResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MassCancel not implemented")
+}
+func (UnimplementedMatchingEngineServer) AmendOrder(context.Context, *AmendOrderRequest) (*AmendOrderResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AmendOrder not implemented")
+}
+func (UnimplementedMatchingEngineServer) StartAuction(context.Context, *StartAuctionRequest) (*StartAuctionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method StartAuction not implemented")
+}
+func (UnimplementedMatchingEngineServer) UncrossAuction(context.Context, *UncrossAuctionRequest) (*UncrossAuctionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UncrossAuction not implemented")
+}
+func (UnimplementedMatchingEngineServer) ListInstruments(context.Context, *ListInstrumentsRequest) (*ListInstrumentsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListInstruments not implemented")
+}
+func (UnimplementedMatchingEngineServer) AddInstrument(context.Context, *AddInstrumentRequest) (*AddInstrumentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddInstrument not implemented")
+}
+func (UnimplementedMatchingEngineServer) DeactivateInstrument(context.Context, *DeactivateInstrumentRequest) (*DeactivateInstrumentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeactivateInstrument not implemented")
+}
+func (UnimplementedMatchingEngineServer) GetOrderBook(context.Context, *market_data.GetOrderBookRequest) (*market_data.OrderBook, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetOrderBook not implemented")
+}
 func (UnimplementedMatchingEngineServer) HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method HealthCheck not implemented")
 }
@@ -164,6 +309,150 @@ func _MatchingEngine_CancelOrder_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MatchingEngine_MassCancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MassCancelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingEngineServer).MassCancel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingEngine_MassCancel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingEngineServer).MassCancel(ctx, req.(*MassCancelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchingEngine_AmendOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AmendOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingEngineServer).AmendOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingEngine_AmendOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingEngineServer).AmendOrder(ctx, req.(*AmendOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchingEngine_StartAuction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartAuctionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingEngineServer).StartAuction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingEngine_StartAuction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingEngineServer).StartAuction(ctx, req.(*StartAuctionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchingEngine_UncrossAuction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UncrossAuctionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingEngineServer).UncrossAuction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingEngine_UncrossAuction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingEngineServer).UncrossAuction(ctx, req.(*UncrossAuctionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchingEngine_ListInstruments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInstrumentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingEngineServer).ListInstruments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingEngine_ListInstruments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingEngineServer).ListInstruments(ctx, req.(*ListInstrumentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchingEngine_AddInstrument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddInstrumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingEngineServer).AddInstrument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingEngine_AddInstrument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingEngineServer).AddInstrument(ctx, req.(*AddInstrumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchingEngine_DeactivateInstrument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeactivateInstrumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingEngineServer).DeactivateInstrument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingEngine_DeactivateInstrument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingEngineServer).DeactivateInstrument(ctx, req.(*DeactivateInstrumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchingEngine_GetOrderBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(market_data.GetOrderBookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingEngineServer).GetOrderBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingEngine_GetOrderBook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingEngineServer).GetOrderBook(ctx, req.(*market_data.GetOrderBookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MatchingEngine_HealthCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(HealthCheckRequest)
 	if err := dec(in); err != nil {
@@ -197,6 +486,38 @@ var MatchingEngine_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CancelOrder",
 			Handler:    _MatchingEngine_CancelOrder_Handler,
 		},
+		{
+			MethodName: "MassCancel",
+			Handler:    _MatchingEngine_MassCancel_Handler,
+		},
+		{
+			MethodName: "AmendOrder",
+			Handler:    _MatchingEngine_AmendOrder_Handler,
+		},
+		{
+			MethodName: "StartAuction",
+			Handler:    _MatchingEngine_StartAuction_Handler,
+		},
+		{
+			MethodName: "UncrossAuction",
+			Handler:    _MatchingEngine_UncrossAuction_Handler,
+		},
+		{
+			MethodName: "ListInstruments",
+			Handler:    _MatchingEngine_ListInstruments_Handler,
+		},
+		{
+			MethodName: "AddInstrument",
+			Handler:    _MatchingEngine_AddInstrument_Handler,
+		},
+		{
+			MethodName: "DeactivateInstrument",
+			Handler:    _MatchingEngine_DeactivateInstrument_Handler,
+		},
+		{
+			MethodName: "GetOrderBook",
+			Handler:    _MatchingEngine_GetOrderBook_Handler,
+		},
 		{
 			MethodName: "HealthCheck",
 			Handler:    _MatchingEngine_HealthCheck_Handler,